@@ -6,14 +6,22 @@ package v1
 
 import (
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // KubeNovaSpec 定义了 KubeNova 的期望状态
+// targetNamespace 创建后不可设置、修改或删除(字段级规则不会校验从未设置到设置的变化)
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.targetNamespace) == has(self.targetNamespace) && (!has(self.targetNamespace) || self.targetNamespace == oldSelf.targetNamespace)",message="targetNamespace 创建后不可修改"
 type KubeNovaSpec struct {
 	// ImageRegistry 全局镜像仓库配置
+	// 配置后 Operator 内置的第三方默认镜像(托管依赖和演示数据重置)也从该仓库拉取，
+	// 例如 mysql:8.0 对应 <registry>/<organization>/mysql:8.0
 	// +optional
 	ImageRegistry *ImageRegistryConfig `json:"imageRegistry,omitempty"`
 
@@ -41,8 +49,71 @@ type KubeNovaSpec struct {
 	// Web 前端配置
 	// +kubebuilder:validation:Required
 	Web WebConfig `json:"web"`
+
+	// Maintenance 维护模式配置
+	// 可选，用于数据库维护等场景下暂停协调或展示维护页面
+	// +optional
+	Maintenance *MaintenanceConfig `json:"maintenance,omitempty"`
+
+	// DeletionPolicy 删除 KubeNova 时对子资源的处理策略
+	// - Delete: 删除所有子资源(包括托管依赖的 PVC 和 cert-manager 证书 Secret)，等待 Pod 全部退出后移除 Finalizer
+	// - Retain: 保留 Secret、ConfigMap、PVC 和 Certificate 等数据类资源(移除 OwnerReference)，删除其余资源
+	// - Orphan: 保留所有子资源(移除 OwnerReference)，服务继续运行
+	// +kubebuilder:default=Delete
+	// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// DeletionOptions 删除选项
+	// +optional
+	DeletionOptions *DeletionOptions `json:"deletionOptions,omitempty"`
+
+	// TargetNamespace 部署目标命名空间(可选，默认为 KubeNova 资源所在命名空间)
+	// 跨命名空间部署时无法使用 OwnerReference，Operator 通过标签记录归属关系，
+	// 并在删除 KubeNova 时由 Finalizer 清理子资源
+	// 引用的 TLS Secret 等资源需要创建在目标命名空间中
+	// 每个目标命名空间只能部署一个 KubeNova，后创建的实例验证失败(Reason: NamespaceConflict)
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// TargetNamespaceOptions 目标命名空间选项
+	// +optional
+	TargetNamespaceOptions *TargetNamespaceOptions `json:"targetNamespaceOptions,omitempty"`
+
+	// InternalTLS 内部 TLS 配置
+	// 启用后 Web、API、RPC 之间的流量使用 Operator 签发的证书加密
+	// +optional
+	InternalTLS *InternalTLSConfig `json:"internalTLS,omitempty"`
+
+	// Alerting Alertmanager 告警接入配置
+	// 启用后 Operator 生成指向 manager-api 的 Alertmanager 接收配置
+	// +optional
+	Alerting *AlertingConfig `json:"alerting,omitempty"`
+
+	// Timezone 容器时区(IANA 时区名称，例如：Europe/Berlin)，可在服务和 Web 配置中单独覆盖
+	// +kubebuilder:default="Asia/Shanghai"
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// Locale 容器语言环境(设置为 LANG 环境变量，例如：en_US.UTF-8)，不配置则使用镜像默认值
+	// +optional
+	Locale string `json:"locale,omitempty"`
+
+	// LogTimeFormat 后端服务日志的时间格式(Go 时间格式)
+	// 写入 kube-nova-secret，修改后后端服务通过 Secret checksum 自动滚动更新
+	// +kubebuilder:default="2006-01-02 15:04:05"
+	// +optional
+	LogTimeFormat string `json:"logTimeFormat,omitempty"`
 }
 
+const (
+	// DefaultTimezone 默认时区
+	DefaultTimezone = "Asia/Shanghai"
+	// DefaultLogTimeFormat 默认日志时间格式
+	DefaultLogTimeFormat = "2006-01-02 15:04:05"
+)
+
 // ImageRegistryConfig 全局镜像仓库配置
 type ImageRegistryConfig struct {
 	// Registry 镜像仓库地址，例如：registry.cn-hangzhou.aliyuncs.com
@@ -69,9 +140,18 @@ type ImageRegistryConfig struct {
 
 // DatabaseConfig MySQL 数据库配置
 type DatabaseConfig struct {
-	// Host 数据库主机地址，例如：mysql.default.svc.cluster.local
-	// +kubebuilder:validation:Required
-	Host string `json:"host"`
+	// Managed 由 Operator 部署单节点 MySQL(仅用于试用和评估环境)
+	// 启用后 host 和 password 不需要配置，database 和 user 未配置时使用 kube_nova
+	// +optional
+	Managed bool `json:"managed,omitempty"`
+
+	// Instance 托管 MySQL 的部署配置
+	// +optional
+	Instance *ManagedInstanceConfig `json:"instance,omitempty"`
+
+	// Host 数据库主机地址，例如：mysql.default.svc.cluster.local(未启用 managed 时必填)
+	// +optional
+	Host string `json:"host,omitempty"`
 
 	// Port 数据库端口
 	// +kubebuilder:default=3306
@@ -79,18 +159,18 @@ type DatabaseConfig struct {
 	// +kubebuilder:validation:Maximum=65535
 	Port int32 `json:"port,omitempty"`
 
-	// Database 数据库名称
-	// +kubebuilder:validation:Required
-	Database string `json:"database"`
+	// Database 数据库名称(未启用 managed 时必填)
+	// +optional
+	Database string `json:"database,omitempty"`
 
-	// User 数据库用户名
-	// +kubebuilder:validation:Required
-	User string `json:"user"`
+	// User 数据库用户名(未启用 managed 时必填)
+	// +optional
+	User string `json:"user,omitempty"`
 
-	// Password 数据库密码(明文，Operator 会自动存储到 Secret)
-	// +kubebuilder:validation:Required
+	// Password 数据库密码(明文，Operator 会自动存储到 Secret，未启用 managed 时必填)
 	// +kubebuilder:validation:MinLength=1
-	Password string `json:"password"`
+	// +optional
+	Password string `json:"password,omitempty"`
 
 	// MaxOpenConns 最大打开连接数
 	// +kubebuilder:default=100
@@ -114,9 +194,18 @@ type DatabaseConfig struct {
 
 // CacheConfig Redis 缓存配置
 type CacheConfig struct {
-	// Host Redis 主机地址，例如：redis.default.svc.cluster.local
-	// +kubebuilder:validation:Required
-	Host string `json:"host"`
+	// Managed 由 Operator 部署单节点 Redis(仅用于试用和评估环境)
+	// 启用后 host 和 password 不需要配置，密码由 Operator 生成
+	// +optional
+	Managed bool `json:"managed,omitempty"`
+
+	// Instance 托管 Redis 的部署配置
+	// +optional
+	Instance *ManagedInstanceConfig `json:"instance,omitempty"`
+
+	// Host Redis 主机地址，例如：redis.default.svc.cluster.local(未启用 managed 时必填)
+	// +optional
+	Host string `json:"host,omitempty"`
 
 	// Port Redis 端口
 	// +kubebuilder:default=6379
@@ -148,27 +237,37 @@ type CacheConfig struct {
 
 // StorageConfig 对象存储配置
 type StorageConfig struct {
-	// Endpoint 存储端点地址(不含 http:// 或 https://)
+	// Managed 由 Operator 部署单节点 MinIO(仅用于试用和评估环境)
+	// 启用后 endpoint、accessKey 和 secretKey 不需要配置，bucket 未配置时使用 kube-nova；
+	// 托管 MinIO 只能在集群内访问，浏览器访问文件需要启用 web.minioProxy
+	// +optional
+	Managed bool `json:"managed,omitempty"`
+
+	// Instance 托管 MinIO 的部署配置
+	// +optional
+	Instance *ManagedInstanceConfig `json:"instance,omitempty"`
+
+	// Endpoint 存储端点地址(不含 http:// 或 https://，未启用 managed 时必填)
 	// 例如：minio.default.svc.cluster.local:9000
-	// +kubebuilder:validation:Required
-	Endpoint string `json:"endpoint"`
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
 
 	// EndpointProxy 代理端点 提供给用户的访问入口
 	// 例如：https://minio.default.svc.cluster.local:9000
 	EndpointProxy string `json:"endpointProxy,omitempty"`
 
-	// AccessKey 访问密钥 ID
-	// +kubebuilder:validation:Required
-	AccessKey string `json:"accessKey"`
+	// AccessKey 访问密钥 ID(未启用 managed 时必填)
+	// +optional
+	AccessKey string `json:"accessKey,omitempty"`
 
-	// SecretKey 访问密钥(明文，Operator 会自动存储到 Secret)
-	// +kubebuilder:validation:Required
+	// SecretKey 访问密钥(明文，Operator 会自动存储到 Secret，未启用 managed 时必填)
 	// +kubebuilder:validation:MinLength=1
-	SecretKey string `json:"secretKey"`
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
 
-	// Bucket 存储桶名称
-	// +kubebuilder:validation:Required
-	Bucket string `json:"bucket"`
+	// Bucket 存储桶名称(未启用 managed 时必填)
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
 
 	// TLS TLS 配置(如果 MinIO 启用了 HTTPS)
 	// +optional
@@ -186,11 +285,70 @@ type MinIOTLSConfig struct {
 	// - public.crt: 公钥证书
 	// - private.key: 私钥
 	// 用户需要提前创建此 Secret
-	// 当 Enabled=true 时此字段必填
+	// 当 Enabled=true 且未配置 IssuerRef 时此字段必填
 	// +optional
 	SecretName string `json:"secretName,omitempty"`
+
+	// IssuerRef cert-manager 签发者引用(可选)
+	// 配置后由 Operator 创建 Certificate 并自动续期，证书写入 SecretName(默认 kube-nova-minio-tls)
+	// 证书 Secret 使用 cert-manager 的 tls.crt/tls.key 键
+	// +optional
+	IssuerRef *CertIssuerRef `json:"issuerRef,omitempty"`
+}
+
+// CertIssuerRef cert-manager 签发者引用
+type CertIssuerRef struct {
+	// Name Issuer 或 ClusterIssuer 名称
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Kind 签发者类型：Issuer 或 ClusterIssuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group 签发者 API 组
+	// +kubebuilder:default="cert-manager.io"
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// ManagedInstanceConfig 托管依赖的部署配置
+// 托管依赖以单副本 StatefulSet 部署，关闭 managed 时保留 PVC，删除 KubeNova 时按 deletionPolicy 处理 PVC
+type ManagedInstanceConfig struct {
+	// Image 完整镜像名称(不配置则使用 Operator 内置的默认镜像，配置了 imageRegistry 时从该仓库拉取)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// StorageSize 数据卷大小
+	// +kubebuilder:default="10Gi"
+	// +optional
+	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
+
+	// StorageClassName 数据卷的 StorageClass(不配置则使用集群默认 StorageClass)
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Resources 资源配置
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
+const (
+	// ManagedMySQLName 托管 MySQL 的 StatefulSet 和 Service 名称
+	ManagedMySQLName = "kube-nova-mysql"
+	// ManagedRedisName 托管 Redis 的 StatefulSet 和 Service 名称
+	ManagedRedisName = "kube-nova-redis"
+	// ManagedMinIOName 托管 MinIO 的 StatefulSet 和 Service 名称
+	ManagedMinIOName = "kube-nova-minio"
+
+	// ManagedDatabaseName 托管 MySQL 默认的数据库名称和用户名
+	ManagedDatabaseName = "kube_nova"
+	// ManagedBucketName 托管 MinIO 默认的存储桶名称
+	ManagedBucketName = "kube-nova"
+)
+
 // TelemetryConfig 链路追踪配置
 type TelemetryConfig struct {
 	// Enabled 是否启用链路追踪
@@ -223,14 +381,17 @@ type ServicesConfig struct {
 	GlobalTimeout int64 `json:"globalTimeout,omitempty"`
 
 	// JWT JWT 配置
-	// +kubebuilder:validation:Required
-	JWT JWTConfig `json:"jwt"`
+	// 省略时自动生成访问令牌和刷新令牌密钥
+	// +kubebuilder:default={}
+	// +optional
+	JWT JWTConfig `json:"jwt,omitempty"`
 
 	// Portal Portal 配置
 	// +optional
 	Portal *PortalConfig `json:"portal,omitempty"`
 
 	// WebhookToken Alertmanager Webhook Token(可选)
+	// 写入 kube-nova-secret，修改后 manager-api 通过 Secret checksum 自动滚动更新
 	// +optional
 	WebhookToken string `json:"webhookToken,omitempty"`
 
@@ -270,18 +431,20 @@ type ServicesConfig struct {
 // JWTConfig JWT 认证配置
 type JWTConfig struct {
 	// AccessSecret 访问令牌密钥(至少 32 字符)
-	// +kubebuilder:validation:Required
+	// 未配置时由 Operator 生成随机密钥并保存在 kube-nova-jwt Secret 中
 	// +kubebuilder:validation:MinLength=32
-	AccessSecret string `json:"accessSecret"`
+	// +optional
+	AccessSecret string `json:"accessSecret,omitempty"`
 
 	// AccessExpire 访问令牌过期时间(秒)
 	// +kubebuilder:default=86400
 	AccessExpire int64 `json:"accessExpire,omitempty"`
 
 	// RefreshSecret 刷新令牌密钥(至少 32 字符)
-	// +kubebuilder:validation:Required
+	// 未配置时由 Operator 生成随机密钥并保存在 kube-nova-jwt Secret 中
 	// +kubebuilder:validation:MinLength=32
-	RefreshSecret string `json:"refreshSecret"`
+	// +optional
+	RefreshSecret string `json:"refreshSecret,omitempty"`
 
 	// RefreshExpire 刷新令牌过期时间(秒)
 	// +kubebuilder:default=604800
@@ -290,6 +453,30 @@ type JWTConfig struct {
 	// RefreshAfter 刷新令牌生效时间(秒)
 	// +kubebuilder:default=604800
 	RefreshAfter int64 `json:"refreshAfter,omitempty"`
+
+	// Rotation 自动生成密钥的定期轮换配置
+	// 只适用于 Operator 自动生成的密钥，不能与 accessSecret/refreshSecret 同时使用
+	// +optional
+	Rotation *JWTRotationConfig `json:"rotation,omitempty"`
+}
+
+// JWTRotationConfig JWT 密钥轮换配置
+// 轮换后旧密钥以 JWT_ACCESS_SECRET_PREVIOUS/JWT_REFRESH_SECRET_PREVIOUS 发布，
+// 过渡期内 portal-rpc 同时接受新旧密钥签发的令牌
+type JWTRotationConfig struct {
+	// Enabled 是否启用定期轮换
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval 轮换周期(例如：720h)
+	// +kubebuilder:default="720h"
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// GracePeriod 旧密钥的保留时间(例如：168h)
+	// 默认与 refreshExpire 相同，保证轮换前签发的令牌在过期前都能通过校验
+	// +optional
+	GracePeriod string `json:"gracePeriod,omitempty"`
 }
 
 // PortalConfig Portal 门户配置
@@ -305,6 +492,77 @@ type PortalConfig struct {
 	// DemoMode 是否启用演示模式
 	// +kubebuilder:default=false
 	DemoMode bool `json:"demoMode,omitempty"`
+
+	// Demo 演示环境的种子数据和定时重置(需要同时启用 demoMode)
+	// +optional
+	Demo *DemoConfig `json:"demo,omitempty"`
+}
+
+// DemoConfig 演示环境配置
+// Operator 创建 CronJob，按计划重建数据库并导入种子数据，将种子对象同步到平台存储桶
+// 重置期间后端服务保持运行且不会断开数据库连接：数据库删除到导入完成之间的请求会失败，
+// 导入完成后连接池自动恢复，建议将计划安排在低峰时段；
+// 重置失败时平台数据可能只导入了一部分，失败原因记录在 status.demo 并产生 DemoResetFailed 事件
+type DemoConfig struct {
+	// ResetSchedule 重置数据的 Cron 表达式(如 "0 3 * * *")，按全局时区执行
+	// 时区通过 CronJob 的 timeZone 字段设置，需要 Kubernetes 1.27 及以上版本
+	// +kubebuilder:validation:MinLength=1
+	ResetSchedule string `json:"resetSchedule"`
+
+	// Suspend 暂停定时重置，已有的种子数据配置保留
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Seed 种子数据来源
+	Seed DemoSeedConfig `json:"seed"`
+
+	// MySQLClientImage 导入 SQL 使用的镜像，需要包含 mysql 客户端和 gzip(默认 mysql:8.0)
+	// +optional
+	MySQLClientImage string `json:"mysqlClientImage,omitempty"`
+
+	// MinIOClientImage 下载和同步对象使用的镜像，需要包含 mc(默认 minio/mc:RELEASE.2025-04-16T18-13-26Z)
+	// +optional
+	MinIOClientImage string `json:"minioClientImage,omitempty"`
+
+	// ActiveDeadlineSeconds 单次重置的超时时间(秒)
+	// +kubebuilder:default=1800
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// DemoSeedConfig 演示环境种子数据
+// SQL 来源 configMap 和 sqlObject 二选一；重置时使用 kube-nova-secret 中的数据库用户(MYSQL_USER)
+// 执行 DROP DATABASE 和 CREATE DATABASE 后导入 SQL，该用户需要业务库的 DROP 和 CREATE 权限
+// (如 GRANT ALL ON `<database>`.*，托管 MySQL 创建的业务用户已具备)，权限不足时重置 Job 失败
+type DemoSeedConfig struct {
+	// ConfigMap 包含 SQL 种子数据的 ConfigMap(受 ConfigMap 1MiB 大小限制)
+	// +optional
+	ConfigMap *DemoSeedConfigMapRef `json:"configMap,omitempty"`
+
+	// Bucket 存放种子数据的存储桶，使用平台的 MinIO 连接，不能与平台存储桶相同
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+
+	// SQLObject 存储桶中的 SQL 种子文件路径，以 .gz 结尾时按 gzip 解压
+	// +optional
+	SQLObject string `json:"sqlObject,omitempty"`
+
+	// ObjectsPrefix 存储桶中种子对象的前缀，重置时镜像到平台存储桶
+	// 平台存储桶中不在种子数据中的对象会被删除
+	// +optional
+	ObjectsPrefix string `json:"objectsPrefix,omitempty"`
+}
+
+// DemoSeedConfigMapRef SQL 种子数据所在的 ConfigMap
+type DemoSeedConfigMapRef struct {
+	// Name ConfigMap 名称(与 KubeNova 部署在同一命名空间)
+	Name string `json:"name"`
+
+	// Key SQL 所在的键，以 .gz 结尾时按 gzip 解压(压缩数据放在 binaryData 中)
+	// +kubebuilder:default="seed.sql"
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // ServiceConfig 单个服务配置
@@ -328,589 +586,2694 @@ type ServiceConfig struct {
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
-	// Env 额外的环境变量
+	// Env 额外的环境变量，与 Operator 生成的环境变量同名时覆盖生成的值
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
-}
 
-// WebConfig Web 前端配置
-type WebConfig struct {
-	// Replicas 副本数
-	// +kubebuilder:default=3
-	// +kubebuilder:validation:Minimum=1
-	// +kubebuilder:validation:Maximum=100
-	Replicas int32 `json:"replicas,omitempty"`
+	// Timezone 覆盖全局时区
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
 
-	// Image 完整镜像名称(如果需要覆盖全局配置)
+	// RPCClients 本服务调用 RPC 服务的客户端配置(按目标服务覆盖)
 	// +optional
-	Image string `json:"image,omitempty"`
+	// +listType=map
+	// +listMapKey=target
+	RPCClients []RPCClientConfig `json:"rpcClients,omitempty"`
 
-	// Resources 资源配置
+	// Headless 是否将 Service 设置为 headless(仅 RPC 服务)
+	// headless Service 的 DNS 直接解析为 Pod IP，配合 dns 服务发现由 gRPC 客户端在副本之间负载均衡；
+	// 切换时 Operator 会删除并重建 Service
 	// +optional
-	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	Headless bool `json:"headless,omitempty"`
 
-	// ExposeType 暴露方式：ingress 或 nodeport
-	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=ingress;nodeport
-	ExposeType string `json:"exposeType"`
+	// GRPCHealthProbe 是否使用 RPC 端口的 gRPC 健康检查作为就绪探针，默认启用(仅 RPC 服务生效)
+	// 启用内部 TLS 时 kubelet 的 gRPC 探针无法建立 TLS 连接，改为检查 RPC 端口是否可连接
+	// +optional
+	GRPCHealthProbe *bool `json:"grpcHealthProbe,omitempty"`
 
-	// Ingress Ingress 配置(当 ExposeType=ingress 时必填)
+	// Probes 探针参数覆盖(例如启动时执行较慢的数据库迁移时延长启动探针)
 	// +optional
-	Ingress *IngressConfig `json:"ingress,omitempty"`
+	Probes *ProbesConfig `json:"probes,omitempty"`
 
-	// NodePort NodePort 配置(当 ExposeType=nodeport 时可选，不配置则使用自动分配的端口)
+	// Lifecycle 优雅停止配置
 	// +optional
-	NodePort *NodePortConfig `json:"nodePort,omitempty"`
+	Lifecycle *LifecycleConfig `json:"lifecycle,omitempty"`
 
-	// MinIOProxy MinIO 代理配置
+	// EnvFrom 额外的环境变量来源(追加在 kube-nova-secret 之后，同名变量以后者为准)
 	// +optional
-	MinIOProxy *MinIOProxyConfig `json:"minioProxy,omitempty"`
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
 
-	// CustomNginxConfigMap 自定义 Nginx 配置的 ConfigMap 名称
-	// 如果指定，将使用此 ConfigMap 替代默认的 Nginx 配置
-	// ConfigMap 必须包含 nginx.conf 和 default.conf 两个 key
+	// InitContainers 额外的 init 容器(例如等待 MySQL 就绪)
+	// 容器和卷的完整 schema 会使 CRD 超过 etcd 的对象大小限制，这里不生成 schema，由 API Server 在创建 Pod 时校验
 	// +optional
-	CustomNginxConfigMap string `json:"customNginxConfigMap,omitempty"`
-}
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
 
-// IngressConfig Ingress 配置
-type IngressConfig struct {
-	// ClassName Ingress 类名
-	// +kubebuilder:default="nginx"
-	ClassName string `json:"className,omitempty"`
+	// ExtraContainers 额外的 sidecar 容器(例如日志采集、Vault Agent)
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
 
-	// Host 域名
-	// +kubebuilder:validation:Required
-	Host string `json:"host"`
+	// ExtraVolumes 额外的卷，名称不能与 Operator 生成的卷重复
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
 
-	// TLS TLS 配置
+	// ExtraVolumeMounts 挂载到主容器的额外卷
 	// +optional
-	TLS *IngressTLSConfig `json:"tls,omitempty"`
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
 
-	// Annotations 额外的注解
+	// PodAnnotations 额外的 Pod 注解
 	// +optional
-	Annotations map[string]string `json:"annotations,omitempty"`
-}
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
 
-// IngressTLSConfig Ingress TLS 配置
-type IngressTLSConfig struct {
-	// Enabled 是否启用 TLS
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled,omitempty"`
+	// PodLabels 额外的 Pod 标签，不能覆盖 Operator 使用的选择器标签
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
 
-	// SecretName TLS 证书 Secret 名称
-	// Secret 必须包含 tls.crt 和 tls.key
-	// 用户需要提前创建此 Secret
-	// 当 Enabled=true 时此字段必填
+	// Volumes 日志和缓存目录使用的卷，不配置时使用 emptyDir
+	// 配置 logs 后服务日志写入 /app/logs 下的文件，不再输出到标准输出；
+	// cache 仅对使用缓存目录的服务(console-api、console-rpc)生效
 	// +optional
-	SecretName string `json:"secretName,omitempty"`
+	Volumes *ComponentVolumesConfig `json:"volumes,omitempty"`
 }
 
-// NodePortConfig NodePort 配置
-type NodePortConfig struct {
-	// HTTPPort HTTP NodePort 端口(可选，不指定则自动分配)
+// ProbesConfig 探针参数覆盖，只调整时间参数，检查方式由 Operator 决定
+type ProbesConfig struct {
+	// Startup 启动探针
 	// +optional
-	// +kubebuilder:validation:Minimum=30000
-	// +kubebuilder:validation:Maximum=32767
-	HTTPPort int32 `json:"httpPort,omitempty"`
+	Startup *ProbeOverride `json:"startup,omitempty"`
 
-	// HTTPS HTTPS 配置
+	// Liveness 存活探针
 	// +optional
-	HTTPS *NodePortHTTPSConfig `json:"https,omitempty"`
+	Liveness *ProbeOverride `json:"liveness,omitempty"`
+
+	// Readiness 就绪探针
+	// +optional
+	Readiness *ProbeOverride `json:"readiness,omitempty"`
 }
 
-// NodePortHTTPSConfig NodePort HTTPS 配置
-type NodePortHTTPSConfig struct {
-	// Enabled 是否启用 HTTPS
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled,omitempty"`
+// ProbeOverride 单个探针的参数覆盖，未设置的字段使用默认值
+type ProbeOverride struct {
+	// InitialDelaySeconds 容器启动后首次检查的延迟(秒)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
 
-	// Port HTTPS NodePort 端口(可选，不指定则自动分配)
+	// PeriodSeconds 检查间隔(秒)
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	// +kubebuilder:validation:Minimum=30000
-	// +kubebuilder:validation:Maximum=32767
-	Port int32 `json:"port,omitempty"`
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
 
-	// SecretName TLS 证书 Secret 名称
-	// Secret 必须包含 tls.crt 和 tls.key
-	// 用户需要提前创建此 Secret
-	// 当 Enabled=true 时此字段必填
+	// TimeoutSeconds 检查超时时间(秒)
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	SecretName string `json:"secretName,omitempty"`
-}
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 
-// MinIOProxyConfig MinIO 代理配置
-type MinIOProxyConfig struct {
-	// Enabled 是否在 Nginx 中启用 MinIO 代理
-	// 启用后可以通过 Web 前端访问 MinIO 的对象
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled,omitempty"`
+	// FailureThreshold 连续失败多少次视为失败
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
 
-	// PathPrefix 代理路径前缀
-	// 例如：/storage，则通过 http://web-host/storage/bucket/object 访问
-	// +kubebuilder:default="/storage"
-	PathPrefix string `json:"pathPrefix,omitempty"`
+// LifecycleConfig 优雅停止配置
+type LifecycleConfig struct {
+	// PreStopSleepSeconds 停止前等待的时间(秒)
+	// 等待 Endpoints 摘除传播到 kube-proxy 和 Ingress 后再发送 SIGTERM，避免停止过程中仍有新请求进入；
+	// 通过 exec 执行镜像中的 sleep 命令(兼容 Kubernetes 1.30 之前不支持原生 sleep 动作的集群)
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	PreStopSleepSeconds *int64 `json:"preStopSleepSeconds,omitempty"`
 
-	// ProxyEndpoint 代理地址(可选，通常自动推断)
-	// 如果手动配置，后端服务将通过此地址访问 MinIO
-	// 例如：http://www.example.com/storage
-	// 如果不配置，将根据 exposeType 自动推断：
-	// - Ingress 模式：http(s)://域名/storage
-	// - NodePort 模式：http://<NODE_IP>:<NODE_PORT>/storage
+	// TerminationGracePeriodSeconds 优雅停止时间(秒)，包含 preStop 等待时间
+	// 控制台长连接较多时可适当延长，等待进行中的会话结束
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
 	// +optional
-	ProxyEndpoint string `json:"proxyEndpoint,omitempty"`
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 }
 
-// ========================================
-// KubeNovaStatus - 状态定义
-// ========================================
-
-// KubeNovaStatus 定义了 KubeNova 的观测状态
-type KubeNovaStatus struct {
-	// Phase 当前部署阶段
+// ComponentVolumesConfig 组件日志和缓存目录的卷配置
+type ComponentVolumesConfig struct {
+	// Logs 日志目录
+	// 使用已有 PVC 时每个 Pod 写入以 Pod 名称命名的子目录，滚动更新后旧 Pod 的目录保留，需要自行归档和清理
 	// +optional
-	Phase DeploymentPhase `json:"phase,omitempty"`
+	Logs *VolumeConfig `json:"logs,omitempty"`
 
-	// Conditions 条件列表
+	// Cache 缓存目录
+	// 使用已有 PVC 时写入固定的 cache 子目录，只支持单副本
 	// +optional
-	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	Cache *VolumeConfig `json:"cache,omitempty"`
+}
 
-	// ComponentStatus 组件状态
+// VolumeConfig 日志或缓存目录的卷
+type VolumeConfig struct {
+	// Type 卷类型
+	// - emptyDir: 临时目录，Pod 重建后数据丢失(默认)
+	// - ephemeral: 随 Pod 创建和删除的 PVC，数据不占用节点磁盘，容器重启后保留
+	// - persistentVolumeClaim: 已有的 PVC，日志按 Pod 名称分子目录(多副本时需要支持 ReadWriteMany)，缓存只支持单副本
+	// +kubebuilder:default=emptyDir
+	// +kubebuilder:validation:Enum=emptyDir;ephemeral;persistentVolumeClaim
 	// +optional
-	ComponentStatus ComponentStatusMap `json:"componentStatus,omitempty"`
+	Type string `json:"type,omitempty"`
 
-	// AccessInfo 访问信息
+	// Size emptyDir 的容量上限或 ephemeral PVC 申请的容量(ephemeral 类型必填)
 	// +optional
-	AccessInfo *AccessInfo `json:"accessInfo,omitempty"`
+	Size *resource.Quantity `json:"size,omitempty"`
 
-	// Message 状态消息
+	// Medium emptyDir 的存储介质，Memory 使用 tmpfs 并计入容器内存
+	// +kubebuilder:validation:Enum="";Memory
 	// +optional
-	Message string `json:"message,omitempty"`
+	Medium corev1.StorageMedium `json:"medium,omitempty"`
 
-	// LastUpdateTime 最后更新时间
+	// StorageClassName ephemeral PVC 的 StorageClass(不配置则使用集群默认 StorageClass)
 	// +optional
-	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	StorageClassName *string `json:"storageClassName,omitempty"`
 
-	// ObservedGeneration 观测到的 generation
+	// ClaimName 已有 PVC 的名称(persistentVolumeClaim 类型必填)
 	// +optional
-	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	ClaimName string `json:"claimName,omitempty"`
 }
 
-// DeploymentPhase 部署阶段
-type DeploymentPhase string
+// 日志和缓存目录的卷类型
+const (
+	// VolumeTypeEmptyDir 临时目录
+	VolumeTypeEmptyDir = "emptyDir"
+	// VolumeTypeEphemeral 随 Pod 创建和删除的 PVC
+	VolumeTypeEphemeral = "ephemeral"
+	// VolumeTypePersistentVolumeClaim 已有的 PVC
+	VolumeTypePersistentVolumeClaim = "persistentVolumeClaim"
+)
 
+// RPC 客户端服务发现方式
 const (
-	// PhaseValidating 正在验证配置
-	PhaseValidating DeploymentPhase = "验证中"
-	// PhasePending 等待部署
-	PhasePending DeploymentPhase = "等待中"
-	// PhaseCreating 正在创建资源
-	PhaseCreating DeploymentPhase = "创建中"
-	// PhaseReady 部署就绪
-	PhaseReady DeploymentPhase = "就绪"
-	// PhaseUpdating 正在更新
-	PhaseUpdating DeploymentPhase = "更新中"
-	// PhaseFailed 部署失败
-	PhaseFailed DeploymentPhase = "失败"
-	// PhaseDeleting 正在删除
-	PhaseDeleting DeploymentPhase = "删除中"
+	// RPCDiscoveryK8s 通过 Endpoints 发现，需要 ServiceAccount 具有 endpoints 的 list/watch 权限
+	RPCDiscoveryK8s = "k8s"
+	// RPCDiscoveryDNS 通过 headless Service 的 DNS 记录发现，由 gRPC 客户端负载均衡
+	RPCDiscoveryDNS = "dns"
+	// RPCDiscoveryDirect 直接连接 ClusterIP Service，由 kube-proxy 负载均衡
+	RPCDiscoveryDirect = "direct"
 )
 
-// ComponentStatusMap 组件状态映射
-type ComponentStatusMap struct {
-	// Database 数据库状态
+// RPCClientConfig 调用单个 RPC 服务的客户端配置
+type RPCClientConfig struct {
+	// Target 目标 RPC 服务
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=portal-rpc;manager-rpc;console-rpc
+	Target string `json:"target"`
+
+	// Timeout 调用超时时间(毫秒)，不配置则使用 services.globalTimeout
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	Database *ComponentStatus `json:"database,omitempty"`
+	Timeout *int64 `json:"timeout,omitempty"`
 
-	// Cache 缓存状态
+	// Optional 目标服务不可用时是否允许本服务正常启动
+	// +kubebuilder:default=true
 	// +optional
-	Cache *ComponentStatus `json:"cache,omitempty"`
+	Optional *bool `json:"optional,omitempty"`
 
-	// Storage 存储状态
+	// NonBlock 启动时是否不等待连接建立
+	// +kubebuilder:default=true
 	// +optional
-	Storage *ComponentStatus `json:"storage,omitempty"`
+	NonBlock *bool `json:"nonBlock,omitempty"`
 
-	// Telemetry 链路追踪状态
+	// Discovery 服务发现方式：
+	// k8s - 通过 Endpoints 发现(需要 ServiceAccount 具有 endpoints 的 list/watch 权限)
+	// dns - 通过 headless Service 的 DNS 记录发现，Operator 自动创建 <服务名>-headless Service
+	// direct - 直接连接 ClusterIP Service
+	// +kubebuilder:default=k8s
+	// +kubebuilder:validation:Enum=k8s;dns;direct
 	// +optional
-	Telemetry *ComponentStatus `json:"telemetry,omitempty"`
+	Discovery string `json:"discovery,omitempty"`
+}
 
-	// Services 后端服务状态
+// WebConfig Web 前端配置
+type WebConfig struct {
+	// Replicas 副本数
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Image 完整镜像名称(如果需要覆盖全局配置)
 	// +optional
-	Services map[string]*ComponentStatus `json:"services,omitempty"`
+	Image string `json:"image,omitempty"`
 
-	// Web Web 前端状态
+	// Resources 资源配置
 	// +optional
-	Web *ComponentStatus `json:"web,omitempty"`
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Timezone 覆盖全局时区
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// ExposeType 暴露方式：ingress、nodeport、gateway 或 loadbalancer
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=ingress;nodeport;gateway;loadbalancer
+	ExposeType string `json:"exposeType"`
+
+	// Ingress Ingress 配置(当 ExposeType=ingress 时必填)
+	// +optional
+	Ingress *IngressConfig `json:"ingress,omitempty"`
+
+	// LoadBalancer LoadBalancer 配置(当 ExposeType=loadbalancer 时可选)
+	// +optional
+	LoadBalancer *LoadBalancerConfig `json:"loadBalancer,omitempty"`
+
+	// Gateway Gateway API 配置(当 ExposeType=gateway 时必填)
+	// 需要集群已安装 Gateway API CRD(gateway.networking.k8s.io/v1)
+	// +optional
+	Gateway *GatewayConfig `json:"gateway,omitempty"`
+
+	// NodePort NodePort 配置(当 ExposeType=nodeport 时可选，不配置则使用自动分配的端口)
+	// +optional
+	NodePort *NodePortConfig `json:"nodePort,omitempty"`
+
+	// MinIOProxy MinIO 代理配置
+	// +optional
+	MinIOProxy *MinIOProxyConfig `json:"minioProxy,omitempty"`
+
+	// Nginx Nginx 细粒度配置和自定义片段(不配置则使用默认值)
+	// 使用 CustomNginxConfigMap 时不生效
+	// +optional
+	Nginx *NginxConfig `json:"nginx,omitempty"`
+
+	// CustomNginxConfigMap 自定义 Nginx 配置的 ConfigMap 名称
+	// 如果指定，将使用此 ConfigMap 完全替代默认的 Nginx 配置
+	// ConfigMap 必须包含 nginx.conf 和 default.conf 两个 key
+	// 只需要追加配置时建议使用 nginx.snippets，保留 Operator 生成的配置
+	// 配置校验通过后复制到 frontend-nginx-config，校验失败时保留上一次有效的配置
+	// +optional
+	CustomNginxConfigMap string `json:"customNginxConfigMap,omitempty"`
+
+	// NginxConfigTest 使用 Web 镜像运行 nginx -t 校验自定义配置(仅 CustomNginxConfigMap 生效)
+	// +optional
+	NginxConfigTest *NginxConfigTestConfig `json:"nginxConfigTest,omitempty"`
+
+	// NginxConfigReload Nginx 配置变更的生效方式
+	// +optional
+	NginxConfigReload *NginxConfigReloadConfig `json:"nginxConfigReload,omitempty"`
+
+	// SecurityHeaders 安全响应头配置(使用 CustomNginxConfigMap 时不生效)
+	// +optional
+	SecurityHeaders *SecurityHeadersConfig `json:"securityHeaders,omitempty"`
+
+	// AccessControl 访问控制配置(IP 黑白名单和外部认证)
+	// +optional
+	AccessControl *AccessControlConfig `json:"accessControl,omitempty"`
+
+	// Probes 探针参数覆盖
+	// +optional
+	Probes *ProbesConfig `json:"probes,omitempty"`
+
+	// Lifecycle 优雅停止配置
+	// +optional
+	Lifecycle *LifecycleConfig `json:"lifecycle,omitempty"`
+
+	// EnvFrom 额外的环境变量来源(追加在 kube-nova-secret 之后，同名变量以后者为准)
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// InitContainers 额外的 init 容器(例如等待 MySQL 就绪)
+	// 容器和卷的完整 schema 会使 CRD 超过 etcd 的对象大小限制，这里不生成 schema，由 API Server 在创建 Pod 时校验
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// ExtraContainers 额外的 sidecar 容器(例如日志采集、Vault Agent)
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraVolumes 额外的卷，名称不能与 Operator 生成的卷重复
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts 挂载到主容器的额外卷
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// PodAnnotations 额外的 Pod 注解
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels 额外的 Pod 标签，不能覆盖 Operator 使用的选择器标签
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// Volumes Nginx 日志(/var/log/nginx)和代理缓存(/var/cache/nginx)目录使用的卷，不配置时使用 emptyDir
+	// +optional
+	Volumes *ComponentVolumesConfig `json:"volumes,omitempty"`
 }
 
-// ComponentStatus 组件状态
-type ComponentStatus struct {
-	// State 状态
+// AccessControlConfig 访问控制配置
+// Nginx 规则依赖客户端真实 IP：Ingress/Gateway 模式下需要同时配置 nginx.realIP，
+// LoadBalancer 模式下需要配置 nginx.realIP 或 externalTrafficPolicy=Local，
+// NodePort 模式下未配置 nginx.realIP 时 Service 自动使用 Local 策略(只有运行 Web Pod 的节点接收流量)；
+// Ingress 模式下全局规则还会渲染为 Ingress 注解
+type AccessControlConfig struct {
+	// Allow 允许访问的 IP 或 CIDR，配置后其他地址拒绝访问
 	// +optional
-	State ComponentState `json:"state,omitempty"`
+	Allow []string `json:"allow,omitempty"`
 
-	// ReadyReplicas 就绪副本数
+	// Deny 拒绝访问的 IP 或 CIDR，优先于 Allow
 	// +optional
-	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	Deny []string `json:"deny,omitempty"`
 
-	// DesiredReplicas 期望副本数
+	// Paths 按路径追加的规则(例如 /portal 和 MinIO 代理路径)
+	// 路径规则先于全局规则匹配，未匹配路径规则的地址继续按全局规则判断
 	// +optional
-	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+	Paths []PathAccessRule `json:"paths,omitempty"`
 
-	// Message 状态消息
+	// Auth 外部认证配置(Nginx auth_request)
 	// +optional
-	Message string `json:"message,omitempty"`
+	Auth *ExternalAuthConfig `json:"auth,omitempty"`
+}
 
-	// LastTransitionTime 最后状态转换时间
+// PathAccessRule 路径访问规则
+type PathAccessRule struct {
+	// Path 内置 location 路径(例如：/portal)
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Allow 允许访问的 IP 或 CIDR
 	// +optional
-	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny 拒绝访问的 IP 或 CIDR
+	// +optional
+	Deny []string `json:"deny,omitempty"`
 }
 
-// ComponentState 组件状态
-type ComponentState string
+// ExternalAuthConfig 外部认证配置
+type ExternalAuthConfig struct {
+	// Mode 认证代理的部署方式：
+	// sidecar - oauth2-proxy 作为 Web Pod 的 sidecar
+	// deployment - oauth2-proxy 作为独立的 Deployment
+	// external - 使用已有的认证代理(需要配置 URL)
+	// +kubebuilder:default=sidecar
+	// +kubebuilder:validation:Enum=sidecar;deployment;external
+	// +optional
+	Mode string `json:"mode,omitempty"`
 
-const (
-	// ComponentStatePending 等待中
-	ComponentStatePending ComponentState = "等待中"
-	// ComponentStateCreating 创建中
-	ComponentStateCreating ComponentState = "创建中"
-	// ComponentStateRunning 运行中
-	ComponentStateRunning ComponentState = "运行中"
-	// ComponentStateReady 就绪
-	ComponentStateReady ComponentState = "就绪"
-	// ComponentStateFailed 失败
-	ComponentStateFailed ComponentState = "失败"
-	// ComponentStateUpdating 更新中
-	ComponentStateUpdating ComponentState = "更新中"
-)
+	// URL 外部认证代理地址，external 模式必填(例如：http://oauth2-proxy.auth.svc:4180)
+	// +optional
+	URL string `json:"url,omitempty"`
 
-// AccessInfo 访问信息
-type AccessInfo struct {
-	// WebURL Web 访问地址
+	// OAuth2Proxy sidecar 和 deployment 模式的 oauth2-proxy 配置
 	// +optional
-	WebURL string `json:"webURL,omitempty"`
+	OAuth2Proxy *OAuth2ProxyConfig `json:"oauth2Proxy,omitempty"`
 
-	// DatabaseEndpoint 数据库端点
+	// ResponseHeaders 认证通过后透传给后端 API 的认证响应头
 	// +optional
-	DatabaseEndpoint string `json:"databaseEndpoint,omitempty"`
+	ResponseHeaders []string `json:"responseHeaders,omitempty"`
 
-	// CacheEndpoint 缓存端点
+	// ExcludePaths 不需要认证的内置 location 路径(例如使用 Token 访问的 API)
 	// +optional
-	CacheEndpoint string `json:"cacheEndpoint,omitempty"`
+	ExcludePaths []string `json:"excludePaths,omitempty"`
+}
 
-	// StorageEndpoint 存储端点
+// OAuth2ProxyConfig oauth2-proxy 配置
+type OAuth2ProxyConfig struct {
+	// Image oauth2-proxy 镜像
+	// +kubebuilder:default="quay.io/oauth2-proxy/oauth2-proxy:v7.6.0"
 	// +optional
-	StorageEndpoint string `json:"storageEndpoint,omitempty"`
+	Image string `json:"image,omitempty"`
 
-	// StorageConsoleURL MinIO 控制台地址
+	// Provider 认证提供方
+	// +kubebuilder:default=oidc
 	// +optional
-	StorageConsoleURL string `json:"storageConsoleURL,omitempty"`
+	Provider string `json:"provider,omitempty"`
 
-	// JaegerUIURL Jaeger UI 访问地址
+	// IssuerURL OIDC Issuer 地址
 	// +optional
-	JaegerUIURL string `json:"jaegerUIURL,omitempty"`
+	IssuerURL string `json:"issuerURL,omitempty"`
 
-	// ServiceEndpoints 服务端点映射
+	// SecretName 包含 client-id、client-secret、cookie-secret 的 Secret 名称
+	// +kubebuilder:validation:Required
+	SecretName string `json:"secretName"`
+
+	// RedirectURL OAuth 回调地址，不配置时根据请求 Host 生成
 	// +optional
-	ServiceEndpoints map[string]string `json:"serviceEndpoints,omitempty"`
+	RedirectURL string `json:"redirectURL,omitempty"`
+
+	// EmailDomains 允许登录的邮箱域名
+	// +optional
+	EmailDomains []string `json:"emailDomains,omitempty"`
+
+	// Replicas deployment 模式的副本数
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// ExtraArgs 额外的启动参数
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// Resources 资源配置
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
-// ========================================
-// Condition Types
-// ========================================
+// SecurityHeadersConfig 安全响应头配置
+// 响应头在所有 server 块和设置了响应头的 location 块中保持一致
+type SecurityHeadersConfig struct {
+	// FrameOptions X-Frame-Options 响应头：DENY、SAMEORIGIN 或 off(不发送)
+	// 配置 FrameAncestors 时不发送 X-Frame-Options，由 CSP frame-ancestors 控制
+	// +kubebuilder:default=SAMEORIGIN
+	// +kubebuilder:validation:Enum=DENY;SAMEORIGIN;off
+	// +optional
+	FrameOptions string `json:"frameOptions,omitempty"`
 
-const (
-	// ConditionTypeReady KubeNova 是否就绪
-	ConditionTypeReady = "Ready"
-	// ConditionTypeValidated 配置是否已验证
-	ConditionTypeValidated = "Validated"
-	// ConditionTypeDatabaseConnected 数据库是否连接成功
-	ConditionTypeDatabaseConnected = "DatabaseConnected"
-	// ConditionTypeCacheConnected 缓存是否连接成功
-	ConditionTypeCacheConnected = "CacheConnected"
-	// ConditionTypeStorageConnected 存储是否连接成功
-	ConditionTypeStorageConnected = "StorageConnected"
-	// ConditionTypeTelemetryReady 链路追踪是否就绪
-	ConditionTypeTelemetryReady = "TelemetryReady"
-	// ConditionTypeServicesReady 后端服务是否就绪
-	ConditionTypeServicesReady = "ServicesReady"
-	// ConditionTypeWebReady Web 前端是否就绪
-	ConditionTypeWebReady = "WebReady"
-)
+	// FrameAncestors 允许嵌入 Web 页面的来源(例如：https://dashboard.example.com)
+	// 渲染为 CSP frame-ancestors 'self' <来源...>
+	// +optional
+	FrameAncestors []string `json:"frameAncestors,omitempty"`
 
-// ========================================
-// Kubebuilder Markers
-// ========================================
+	// ContentSecurityPolicy Content-Security-Policy 响应头
+	// 不能包含 frame-ancestors 指令，嵌入来源请使用 FrameAncestors
+	// +optional
+	ContentSecurityPolicy string `json:"contentSecurityPolicy,omitempty"`
 
-//+kubebuilder:object:root=true
-//+kubebuilder:subresource:status
-//+kubebuilder:resource:scope=Namespaced,shortName=kn
-//+kubebuilder:printcolumn:name="阶段",type=string,JSONPath=`.status.phase`
-//+kubebuilder:printcolumn:name="Web",type=string,JSONPath=`.status.componentStatus.web.state`
-//+kubebuilder:printcolumn:name="访问地址",type=string,JSONPath=`.status.accessInfo.webURL`
-//+kubebuilder:printcolumn:name="运行时长",type="date",JSONPath=".metadata.creationTimestamp"
+	// ReferrerPolicy Referrer-Policy 响应头
+	// +kubebuilder:default="no-referrer-when-downgrade"
+	// +optional
+	ReferrerPolicy string `json:"referrerPolicy,omitempty"`
 
-// KubeNova is the Schema for the KubeNova API
-type KubeNova struct {
-	metav1.TypeMeta   `json:",inline"`
-	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// PermissionsPolicy Permissions-Policy 响应头
+	// +kubebuilder:default="geolocation=(), microphone=(), camera=()"
+	// +optional
+	PermissionsPolicy string `json:"permissionsPolicy,omitempty"`
 
-	Spec   KubeNovaSpec   `json:"spec,omitempty"`
-	Status KubeNovaStatus `json:"status,omitempty"`
+	// XSSProtection 是否发送 X-XSS-Protection 响应头
+	// +kubebuilder:default=true
+	// +optional
+	XSSProtection *bool `json:"xssProtection,omitempty"`
+
+	// HSTS Strict-Transport-Security 配置
+	// +optional
+	HSTS *HSTSConfig `json:"hsts,omitempty"`
+
+	// CORS API 路径(/portal、/manager、/workload、/console)的跨域配置
+	// +optional
+	CORS *CORSConfig `json:"cors,omitempty"`
+
+	// CustomHeaders 额外的自定义响应头
+	// +optional
+	CustomHeaders []HTTPHeader `json:"customHeaders,omitempty"`
 }
 
-//+kubebuilder:object:root=true
+// HSTSConfig Strict-Transport-Security 配置
+type HSTSConfig struct {
+	// Enabled 是否发送 HSTS 响应头
+	// 不配置时仅在 NodePort HTTPS 模式下发送；Ingress/Gateway 启用 TLS 时需要显式开启
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
 
-// KubeNovaList contains a list of KubeNova
-type KubeNovaList struct {
-	metav1.TypeMeta `json:",inline"`
-	metav1.ListMeta `json:"metadata,omitempty"`
-	Items           []KubeNova `json:"items"`
+	// MaxAge 有效期(秒)
+	// +kubebuilder:default=31536000
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAge *int32 `json:"maxAge,omitempty"`
+
+	// IncludeSubDomains 是否包含子域名
+	// +kubebuilder:default=true
+	// +optional
+	IncludeSubDomains *bool `json:"includeSubDomains,omitempty"`
+
+	// Preload 是否添加 preload 标记
+	// +kubebuilder:default=false
+	// +optional
+	Preload bool `json:"preload,omitempty"`
 }
 
-func init() {
-	SchemeBuilder.Register(&KubeNova{}, &KubeNovaList{})
+// CORSConfig 跨域配置
+type CORSConfig struct {
+	// AllowedOrigins 允许的来源，"*" 表示允许所有来源
+	// +kubebuilder:validation:MinItems=1
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// AllowedMethods 允许的请求方法
+	// +kubebuilder:default="GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	// +optional
+	AllowedMethods string `json:"allowedMethods,omitempty"`
+
+	// AllowedHeaders 允许的请求头
+	// +kubebuilder:default="Authorization, Content-Type, X-Requested-With"
+	// +optional
+	AllowedHeaders string `json:"allowedHeaders,omitempty"`
+
+	// AllowCredentials 是否允许携带凭证(不能与 "*" 来源同时使用)
+	// +kubebuilder:default=false
+	// +optional
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+
+	// MaxAge 预检请求缓存时间(秒)
+	// +kubebuilder:default=86400
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAge *int32 `json:"maxAge,omitempty"`
 }
 
-// ========================================
-// Helper Methods
-// ========================================
+// HTTPHeader HTTP 响应头
+type HTTPHeader struct {
+	// Name 响应头名称
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+$`
+	Name string `json:"name"`
 
-// GetImageRegistry 获取镜像仓库配置
-func (k *KubeNova) GetImageRegistry() ImageRegistryConfig {
-	if k.Spec.ImageRegistry != nil {
-		return *k.Spec.ImageRegistry
-	}
-	return ImageRegistryConfig{
-		Registry:     "registry.cn-hangzhou.aliyuncs.com",
-		Organization: "kube-nova",
-		Tag:          "latest",
-		PullPolicy:   corev1.PullAlways,
+	// Value 响应头的值
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
+}
+
+// NginxConfigReloadConfig Nginx 配置变更生效方式配置
+type NginxConfigReloadConfig struct {
+	// Mode 配置变更的生效方式
+	// - rollout: 滚动更新 Web Pod(默认)，会断开控制台 WebSocket 长连接
+	// - reload: 不重启 Pod，由 reloader sidecar 校验后执行 nginx -s reload，已建立的连接不受影响
+	// reload 模式下 ConfigMap 变更需要等待 kubelet 同步(通常 1 分钟内)
+	// +kubebuilder:default=rollout
+	// +kubebuilder:validation:Enum=rollout;reload
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// IntervalSeconds reloader 检查配置变化的间隔(秒，仅 reload 模式)
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+}
+
+// NginxConfigTestConfig nginx -t 校验配置
+type NginxConfigTestConfig struct {
+	// Enabled 是否在更新配置前运行 nginx -t 校验 Job
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// TimeoutSeconds 校验 Job 的超时时间(秒)
+	// +kubebuilder:default=120
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// IngressConfig Ingress 配置
+type IngressConfig struct {
+	// ClassName Ingress 类名
+	// +kubebuilder:default="nginx"
+	ClassName string `json:"className,omitempty"`
+
+	// Host 域名
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+
+	// TLS TLS 配置
+	// +optional
+	TLS *IngressTLSConfig `json:"tls,omitempty"`
+
+	// Annotations 额外的注解
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IngressTLSConfig Ingress TLS 配置
+type IngressTLSConfig struct {
+	// Enabled 是否启用 TLS
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SecretName TLS 证书 Secret 名称
+	// Secret 必须包含 tls.crt 和 tls.key
+	// 用户需要提前创建此 Secret
+	// 当 Enabled=true 且未配置 IssuerRef 时此字段必填
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// IssuerRef cert-manager 签发者引用(可选)
+	// 配置后由 Operator 创建 Certificate，证书写入 SecretName(默认 kube-nova-web-tls)
+	// +optional
+	IssuerRef *CertIssuerRef `json:"issuerRef,omitempty"`
+}
+
+// GatewayConfig Gateway API 配置
+type GatewayConfig struct {
+	// Hostnames 域名列表，第一个域名用于生成访问地址
+	// +kubebuilder:validation:MinItems=1
+	Hostnames []string `json:"hostnames"`
+
+	// ParentRefs HTTPRoute 关联的已有 Gateway 列表
+	// 未启用 Listener 时必填
+	// +optional
+	ParentRefs []GatewayParentRef `json:"parentRefs,omitempty"`
+
+	// Listener 由 Operator 创建的 Gateway 监听器配置(可选)
+	// +optional
+	Listener *GatewayListenerConfig `json:"listener,omitempty"`
+
+	// TLS TLS 配置
+	// 使用已有 Gateway 时 TLS 由 Gateway 终止，此处仅用于生成 https 访问地址
+	// +optional
+	TLS *GatewayTLSConfig `json:"tls,omitempty"`
+
+	// Annotations HTTPRoute 额外的注解
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GatewayParentRef Gateway 引用
+type GatewayParentRef struct {
+	// Name Gateway 名称
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace Gateway 所在命名空间(默认与 HTTPRoute 相同)
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName 监听器名称(可选)
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// GatewayListenerConfig Gateway 监听器配置
+type GatewayListenerConfig struct {
+	// Enabled 是否创建 Gateway
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// GatewayClassName GatewayClass 名称(Enabled=true 时必填)
+	// +optional
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+
+	// HTTPPort HTTP 监听端口
+	// +kubebuilder:default=80
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	HTTPPort int32 `json:"httpPort,omitempty"`
+
+	// HTTPSPort HTTPS 监听端口(启用 TLS 时生效)
+	// +kubebuilder:default=443
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	HTTPSPort int32 `json:"httpsPort,omitempty"`
+}
+
+// GatewayTLSConfig Gateway TLS 配置
+type GatewayTLSConfig struct {
+	// Enabled 是否启用 TLS
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// CertificateRefs HTTPS 监听器使用的证书 Secret 名称列表
+	// Secret 必须包含 tls.crt 和 tls.key，仅在 Operator 创建 Gateway 时使用
+	// +optional
+	CertificateRefs []string `json:"certificateRefs,omitempty"`
+}
+
+// NodePortConfig NodePort 配置
+type NodePortConfig struct {
+	// HTTPPort HTTP NodePort 端口(可选，不指定则自动分配)
+	// +optional
+	// +kubebuilder:validation:Minimum=30000
+	// +kubebuilder:validation:Maximum=32767
+	HTTPPort int32 `json:"httpPort,omitempty"`
+
+	// HTTPS HTTPS 配置
+	// +optional
+	HTTPS *NodePortHTTPSConfig `json:"https,omitempty"`
+}
+
+// LoadBalancerConfig LoadBalancer 配置
+type LoadBalancerConfig struct {
+	// LoadBalancerIP 指定的静态 IP(可选，需负载均衡器支持，如 MetalLB)
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+
+	// LoadBalancerClass 负载均衡器类型(可选，创建后不可修改)
+	// +optional
+	LoadBalancerClass string `json:"loadBalancerClass,omitempty"`
+
+	// SourceRanges 允许访问的客户端 CIDR 列表
+	// +optional
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+
+	// Annotations Service 额外的注解(如云厂商负载均衡器配置)
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ExternalTrafficPolicy 外部流量策略：Cluster 或 Local
+	// Local 可以保留客户端源 IP
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +kubebuilder:default=Cluster
+	// +optional
+	ExternalTrafficPolicy string `json:"externalTrafficPolicy,omitempty"`
+
+	// Scheme 外部访问协议：http 或 https
+	// 负载均衡器终止 TLS(如通过云厂商证书注解)时设置为 https，
+	// 用于生成 status.accessInfo.webURL 和 MINIO_ENDPOINT_PROXY
+	// +kubebuilder:validation:Enum=http;https
+	// +kubebuilder:default=http
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// NodePortHTTPSConfig NodePort HTTPS 配置
+type NodePortHTTPSConfig struct {
+	// Enabled 是否启用 HTTPS
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Port HTTPS NodePort 端口(可选，不指定则自动分配)
+	// +optional
+	// +kubebuilder:validation:Minimum=30000
+	// +kubebuilder:validation:Maximum=32767
+	Port int32 `json:"port,omitempty"`
+
+	// SecretName TLS 证书 Secret 名称
+	// Secret 必须包含 tls.crt 和 tls.key
+	// 用户需要提前创建此 Secret
+	// 当 Enabled=true 且未配置 IssuerRef 时此字段必填
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// IssuerRef cert-manager 签发者引用(可选)
+	// 配置后由 Operator 创建 Certificate，证书写入 SecretName(默认 kube-nova-web-tls)
+	// 证书包含 Web Service 域名和节点 IP，续期后 Web 自动滚动更新
+	// +optional
+	IssuerRef *CertIssuerRef `json:"issuerRef,omitempty"`
+
+	// DNSNames 证书额外的 DNS 名称(仅 IssuerRef 配置时生效)
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+// MinIOProxyConfig MinIO 代理配置
+type MinIOProxyConfig struct {
+	// Enabled 是否在 Nginx 中启用 MinIO 代理
+	// 启用后可以通过 Web 前端访问 MinIO 的对象
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// PathPrefix 代理路径前缀
+	// 例如：/storage，则通过 http://web-host/storage/bucket/object 访问
+	// +kubebuilder:default="/storage"
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// ProxyEndpoint 代理地址(可选，通常自动推断)
+	// 如果手动配置，后端服务将通过此地址访问 MinIO
+	// 例如：http://www.example.com/storage
+	// 如果不配置，将根据 exposeType 自动推断：
+	// - Ingress/Gateway 模式：http(s)://域名/storage
+	// - NodePort 模式：http://<NODE_IP>:<NODE_PORT>/storage
+	// +optional
+	ProxyEndpoint string `json:"proxyEndpoint,omitempty"`
+}
+
+// NginxConfig Nginx 细粒度配置
+type NginxConfig struct {
+	// WorkerProcesses worker 进程数：auto 或具体数量
+	// +kubebuilder:default="auto"
+	// +kubebuilder:validation:Pattern=`^(auto|[1-9][0-9]*)$`
+	// +optional
+	WorkerProcesses string `json:"workerProcesses,omitempty"`
+
+	// WorkerConnections 每个 worker 的最大连接数
+	// +kubebuilder:default=4096
+	// +kubebuilder:validation:Minimum=64
+	// +optional
+	WorkerConnections int32 `json:"workerConnections,omitempty"`
+
+	// WorkerRlimitNofile worker 进程最大打开文件数
+	// +kubebuilder:default=65535
+	// +kubebuilder:validation:Minimum=1024
+	// +optional
+	WorkerRlimitNofile int32 `json:"workerRlimitNofile,omitempty"`
+
+	// ClientMaxBodySize 请求体大小上限(例如：1024m)
+	// +kubebuilder:default="1024m"
+	// +kubebuilder:validation:Pattern=`^[0-9]+[kKmMgG]?$`
+	// +optional
+	ClientMaxBodySize string `json:"clientMaxBodySize,omitempty"`
+
+	// RateLimit 请求限流配置
+	// +optional
+	RateLimit *NginxRateLimitConfig `json:"rateLimit,omitempty"`
+
+	// MaxConnsPerIP 单个客户端 IP 的最大并发连接数，0 表示不限制
+	// 企业 NAT 出口后的用户较多时需要调大
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConnsPerIP *int32 `json:"maxConnsPerIP,omitempty"`
+
+	// Gzip Gzip 压缩配置
+	// +optional
+	Gzip *NginxGzipConfig `json:"gzip,omitempty"`
+
+	// Keepalive 客户端长连接配置
+	// +optional
+	Keepalive *NginxKeepaliveConfig `json:"keepalive,omitempty"`
+
+	// RealIP 真实客户端 IP 配置(位于负载均衡或代理之后时使用)
+	// +optional
+	RealIP *NginxRealIPConfig `json:"realIP,omitempty"`
+
+	// AccessLogFormat 访问日志格式：main、json 或 off
+	// +kubebuilder:default=main
+	// +kubebuilder:validation:Enum=main;json;off
+	// +optional
+	AccessLogFormat string `json:"accessLogFormat,omitempty"`
+
+	// Snippets 注入到生成配置中的自定义片段
+	// +optional
+	Snippets *NginxSnippetsConfig `json:"snippets,omitempty"`
+}
+
+// NginxSnippetsConfig Nginx 自定义片段配置
+// 片段内容原样写入生成的配置，Operator 生成的 upstream、MinIO 代理和 TLS 配置保持不变
+type NginxSnippetsConfig struct {
+	// HTTP http 块级别的片段(写入 nginx.conf 的 http 块)
+	// +optional
+	HTTP string `json:"http,omitempty"`
+
+	// Server server 块级别的片段(写入所有提供服务的 server 块)
+	// +optional
+	Server string `json:"server,omitempty"`
+
+	// Locations 内置 location 的片段
+	// +optional
+	Locations []NginxLocationSnippet `json:"locations,omitempty"`
+
+	// Upstreams 额外的 upstream 定义
+	// +optional
+	Upstreams []NginxUpstream `json:"upstreams,omitempty"`
+
+	// ExtraLocations 额外的 location 块
+	// +optional
+	ExtraLocations []NginxExtraLocation `json:"extraLocations,omitempty"`
+
+	// ConfigMapName 包含片段的 ConfigMap 名称(可选)
+	// 支持的 key：http-snippet、server-snippet(文本片段)，
+	// locations、upstreams、extra-locations(与对应字段结构相同的 YAML 列表)，内容追加在 CR 中配置的片段之后
+	// ConfigMap 变化后 Web 自动滚动更新
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// NginxLocationSnippet 内置 location 的片段
+type NginxLocationSnippet struct {
+	// Path 内置 location 路径
+	// 可选值：/、/portal、/manager、/workload、/console、/ws/v1/pod、/ws/v1/site-messages
+	// 以及启用 MinIO 代理时的 pathPrefix
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Snippet 片段内容
+	// +kubebuilder:validation:Required
+	Snippet string `json:"snippet"`
+}
+
+// NginxUpstream 额外的 upstream 定义
+type NginxUpstream struct {
+	// Name upstream 名称
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+
+	// Servers 后端地址列表(例如：my-service:8080)
+	// +kubebuilder:validation:MinItems=1
+	Servers []string `json:"servers"`
+
+	// Keepalive 保持的空闲长连接数
+	// +kubebuilder:default=32
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Keepalive *int32 `json:"keepalive,omitempty"`
+}
+
+// NginxExtraLocation 额外的 location 块
+type NginxExtraLocation struct {
+	// Path location 匹配规则，可以包含修饰符(例如：/api/v2、= /status、~ ^/download/)
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Upstream 代理到的 upstream 名称(可选，必须在 upstreams 中定义)
+	// 配置后自动生成 proxy_pass 和常用的代理请求头
+	// +optional
+	Upstream string `json:"upstream,omitempty"`
+
+	// Snippet location 内的自定义配置
+	// +optional
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// NginxRateLimitConfig Nginx 限流配置
+type NginxRateLimitConfig struct {
+	// GeneralRate 全局限流速率(例如：100r/s)
+	// +kubebuilder:default="100r/s"
+	// +kubebuilder:validation:Pattern=`^[1-9][0-9]*r/[sm]$`
+	// +optional
+	GeneralRate string `json:"generalRate,omitempty"`
+
+	// GeneralBurst 全局限流突发请求数
+	// +kubebuilder:default=200
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GeneralBurst *int32 `json:"generalBurst,omitempty"`
+
+	// APIRate API 限流速率(例如：50r/s)
+	// +kubebuilder:default="50r/s"
+	// +kubebuilder:validation:Pattern=`^[1-9][0-9]*r/[sm]$`
+	// +optional
+	APIRate string `json:"apiRate,omitempty"`
+
+	// APIBurst API 限流突发请求数
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	APIBurst *int32 `json:"apiBurst,omitempty"`
+
+	// WebSocketBurst WebSocket 限流突发请求数
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WebSocketBurst *int32 `json:"websocketBurst,omitempty"`
+}
+
+// NginxGzipConfig Nginx Gzip 压缩配置
+type NginxGzipConfig struct {
+	// Enabled 是否启用 Gzip 压缩
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// CompLevel 压缩级别(1-9)
+	// +kubebuilder:default=6
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9
+	// +optional
+	CompLevel int32 `json:"compLevel,omitempty"`
+
+	// MinLength 启用压缩的最小响应长度(字节)
+	// +kubebuilder:default=1000
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinLength *int32 `json:"minLength,omitempty"`
+}
+
+// NginxKeepaliveConfig Nginx 客户端长连接配置
+type NginxKeepaliveConfig struct {
+	// Timeout 长连接超时时间(例如：65 或 65s，不带单位时为秒)
+	// +kubebuilder:default="65"
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m|h)?$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// Requests 单个长连接最大请求数
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Requests int32 `json:"requests,omitempty"`
+}
+
+// NginxRealIPConfig 真实客户端 IP 配置
+type NginxRealIPConfig struct {
+	// TrustedProxies 受信任的代理地址(IP 或 CIDR)
+	// +kubebuilder:validation:MinItems=1
+	TrustedProxies []string `json:"trustedProxies"`
+
+	// Header 携带客户端 IP 的请求头(例如：X-Forwarded-For、X-Real-IP)
+	// 监听端口未启用 PROXY 协议，不支持 proxy_protocol
+	// +kubebuilder:default="X-Forwarded-For"
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Recursive 是否递归排除受信任代理地址
+	// +kubebuilder:default=true
+	// +optional
+	Recursive *bool `json:"recursive,omitempty"`
+}
+
+// MaintenanceConfig 维护模式配置
+type MaintenanceConfig struct {
+	// Enabled 是否启用维护模式
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Mode 维护模式类型
+	// - paused: 暂停协调，Operator 不再修改任何资源，仅更新状态
+	// - maintenancePage: Web 前端对 / 和 API 路径返回 503 维护页面
+	// +kubebuilder:default=maintenancePage
+	// +kubebuilder:validation:Enum=paused;maintenancePage
+	Mode string `json:"mode,omitempty"`
+
+	// Message 维护页面展示的提示信息(仅 maintenancePage 模式)
+	// +kubebuilder:default="系统维护中，请稍后访问"
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ScaleDownBackend 是否将后端服务缩容到 0(仅 maintenancePage 模式)
+	// Web 前端保持运行以展示维护页面
+	// +kubebuilder:default=false
+	// +optional
+	ScaleDownBackend bool `json:"scaleDownBackend,omitempty"`
+}
+
+// DeletionOptions 删除选项
+type DeletionOptions struct {
+	// BackupSecret 删除前是否将 kube-nova-secret 备份为带时间戳的 Secret
+	// 备份名称格式：kube-nova-secret-backup-20060102-150405
+	// 备份 Secret 不设置 OwnerReference，需要手动清理
+	// +kubebuilder:default=false
+	// +optional
+	BackupSecret bool `json:"backupSecret,omitempty"`
+
+	// PodTerminationTimeout 等待 Pod 全部退出的最长时间(例如：5m)
+	// 超时后不再等待，直接移除 Finalizer
+	// +kubebuilder:default="5m"
+	// +optional
+	PodTerminationTimeout string `json:"podTerminationTimeout,omitempty"`
+}
+
+// TargetNamespaceOptions 目标命名空间选项
+type TargetNamespaceOptions struct {
+	// Create 目标命名空间不存在时是否自动创建
+	// Operator 不会在删除 KubeNova 时删除命名空间
+	// +kubebuilder:default=false
+	// +optional
+	Create bool `json:"create,omitempty"`
+
+	// Labels 命名空间标签(自动创建或已存在时合并)
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations 命名空间注解(自动创建或已存在时合并)
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// InternalTLSConfig 内部 TLS 配置
+// Operator 生成自签名 CA，并为每个服务签发证书，到期前自动轮换
+type InternalTLSConfig struct {
+	// Enabled 是否启用内部 TLS
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// CAValidity CA 证书有效期
+	// +kubebuilder:default="87600h"
+	// +optional
+	CAValidity string `json:"caValidity,omitempty"`
+
+	// CertValidity 服务证书有效期
+	// +kubebuilder:default="8760h"
+	// +optional
+	CertValidity string `json:"certValidity,omitempty"`
+
+	// RenewBefore 证书到期前多久轮换
+	// +kubebuilder:default="720h"
+	// +optional
+	RenewBefore string `json:"renewBefore,omitempty"`
+}
+
+// AlertingConfig Alertmanager 告警接入配置
+// 接收地址由 manager-api Service 生成，使用 services.webhookToken 作为 Bearer Token，
+// 未配置 Token 时自动生成并保存在 kube-nova-secret 中
+type AlertingConfig struct {
+	// Enabled 是否生成 Alertmanager 接收配置
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Mode 接收配置的生成方式：
+	// alertmanagerConfig - 生成 Prometheus Operator 的 AlertmanagerConfig(monitoring.coreos.com/v1alpha1)
+	// secret - 生成包含 receiver 配置片段的 Secret，供手动维护的 Alertmanager 使用
+	// +kubebuilder:default=alertmanagerConfig
+	// +kubebuilder:validation:Enum=alertmanagerConfig;secret
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// WebhookPath manager-api 接收告警的路径
+	// +kubebuilder:default="/manager/v1/alertmanager/webhook"
+	// +optional
+	WebhookPath string `json:"webhookPath,omitempty"`
+
+	// ReceiverName receiver 名称
+	// +kubebuilder:default="kube-nova"
+	// +optional
+	ReceiverName string `json:"receiverName,omitempty"`
+
+	// SendResolved 是否发送告警恢复通知
+	// +kubebuilder:default=true
+	// +optional
+	SendResolved *bool `json:"sendResolved,omitempty"`
+
+	// Labels AlertmanagerConfig 的额外标签(用于匹配 Alertmanager 的 alertmanagerConfigSelector)
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Route 告警路由配置
+	// +optional
+	Route *AlertingRouteConfig `json:"route,omitempty"`
+}
+
+// AlertingRouteConfig 告警路由配置
+type AlertingRouteConfig struct {
+	// Matchers 告警匹配条件，为空时接收所有告警
+	// +optional
+	Matchers []AlertMatcher `json:"matchers,omitempty"`
+
+	// GroupBy 分组标签
+	// +optional
+	GroupBy []string `json:"groupBy,omitempty"`
+
+	// GroupWait 分组等待时间(例如：30s)
+	// +optional
+	GroupWait string `json:"groupWait,omitempty"`
+
+	// GroupInterval 分组发送间隔(例如：5m)
+	// +optional
+	GroupInterval string `json:"groupInterval,omitempty"`
+
+	// RepeatInterval 重复发送间隔(例如：4h)
+	// +optional
+	RepeatInterval string `json:"repeatInterval,omitempty"`
+}
+
+// AlertMatcher 告警匹配条件
+type AlertMatcher struct {
+	// Name 标签名称
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Value 标签值
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// MatchType 匹配方式
+	// +kubebuilder:default="="
+	// +kubebuilder:validation:Enum="=";"!=";"=~";"!~"
+	// +optional
+	MatchType string `json:"matchType,omitempty"`
+}
+
+// ========================================
+// KubeNovaStatus - 状态定义
+// ========================================
+
+// KubeNovaStatus 定义了 KubeNova 的观测状态
+type KubeNovaStatus struct {
+	// Phase 当前部署阶段
+	// +optional
+	Phase DeploymentPhase `json:"phase,omitempty"`
+
+	// Conditions 条件列表
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ComponentStatus 组件状态
+	// +optional
+	ComponentStatus ComponentStatusMap `json:"componentStatus,omitempty"`
+
+	// AccessInfo 访问信息
+	// +optional
+	AccessInfo *AccessInfo `json:"accessInfo,omitempty"`
+
+	// Message 状态消息
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastUpdateTime 最后更新时间
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// ObservedGeneration 观测到的 generation
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Certificates 实例引用的 TLS 证书状态(Web 和 MinIO)
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// JWT 自动生成的 JWT 密钥状态
+	// +optional
+	JWT *JWTStatus `json:"jwt,omitempty"`
+
+	// ManagedDependencies 由 Operator 部署的依赖状态(非生产环境)
+	// +optional
+	ManagedDependencies []ManagedDependencyStatus `json:"managedDependencies,omitempty"`
+
+	// Demo 演示环境数据重置状态
+	// +optional
+	Demo *DemoStatus `json:"demo,omitempty"`
+}
+
+// ManagedDependencyStatus 托管依赖状态
+type ManagedDependencyStatus struct {
+	// Name 依赖名称：mysql、redis 或 minio
+	Name string `json:"name"`
+
+	// Endpoint 集群内访问地址
+	Endpoint string `json:"endpoint"`
+
+	// Ready 是否就绪
+	Ready bool `json:"ready"`
+}
+
+// DemoStatus 演示环境数据重置状态
+type DemoStatus struct {
+	// CronJob 执行重置的 CronJob 名称
+	CronJob string `json:"cronJob,omitempty"`
+
+	// LastResetTime 最近一次成功重置的时间
+	// +optional
+	LastResetTime *metav1.Time `json:"lastResetTime,omitempty"`
+
+	// LastScheduleTime 最近一次触发重置的时间
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Active 是否正在重置
+	Active bool `json:"active,omitempty"`
+
+	// LastFailureTime 最近一次重置失败的时间(之后已成功重置时为空)
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// LastFailureMessage 最近一次重置失败的原因
+	// +optional
+	LastFailureMessage string `json:"lastFailureMessage,omitempty"`
+}
+
+// JWTStatus JWT 密钥状态
+type JWTStatus struct {
+	// Generated 是否使用 Operator 自动生成的密钥
+	Generated bool `json:"generated,omitempty"`
+
+	// LastRotationTime 最近一次生成或轮换密钥的时间
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// NextRotationTime 下一次轮换时间(未启用轮换时为空)
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+
+	// PreviousSecretExpireTime 旧密钥停止发布的时间(没有旧密钥时为空)
+	// +optional
+	PreviousSecretExpireTime *metav1.Time `json:"previousSecretExpireTime,omitempty"`
+}
+
+// DeploymentPhase 部署阶段
+type DeploymentPhase string
+
+const (
+	// PhaseValidating 正在验证配置
+	PhaseValidating DeploymentPhase = "验证中"
+	// PhasePending 等待部署
+	PhasePending DeploymentPhase = "等待中"
+	// PhaseCreating 正在创建资源
+	PhaseCreating DeploymentPhase = "创建中"
+	// PhaseReady 部署就绪
+	PhaseReady DeploymentPhase = "就绪"
+	// PhaseUpdating 正在更新
+	PhaseUpdating DeploymentPhase = "更新中"
+	// PhaseFailed 部署失败
+	PhaseFailed DeploymentPhase = "失败"
+	// PhaseDeleting 正在删除
+	PhaseDeleting DeploymentPhase = "删除中"
+	// PhasePaused 协调已暂停(维护模式)
+	PhasePaused DeploymentPhase = "已暂停"
+	// PhaseMaintenance 维护页面已启用(维护模式)
+	PhaseMaintenance DeploymentPhase = "维护中"
+)
+
+// ComponentStatusMap 组件状态映射
+type ComponentStatusMap struct {
+	// Database 数据库状态
+	// +optional
+	Database *ComponentStatus `json:"database,omitempty"`
+
+	// Cache 缓存状态
+	// +optional
+	Cache *ComponentStatus `json:"cache,omitempty"`
+
+	// Storage 存储状态
+	// +optional
+	Storage *ComponentStatus `json:"storage,omitempty"`
+
+	// Telemetry 链路追踪状态
+	// +optional
+	Telemetry *ComponentStatus `json:"telemetry,omitempty"`
+
+	// Services 后端服务状态
+	// +optional
+	Services map[string]*ComponentStatus `json:"services,omitempty"`
+
+	// Web Web 前端状态
+	// +optional
+	Web *ComponentStatus `json:"web,omitempty"`
+}
+
+// ComponentStatus 组件状态
+type ComponentStatus struct {
+	// State 状态
+	// +optional
+	State ComponentState `json:"state,omitempty"`
+
+	// ReadyReplicas 就绪副本数
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// DesiredReplicas 期望副本数
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// Message 状态消息
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime 最后状态转换时间
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ComponentState 组件状态
+type ComponentState string
+
+const (
+	// ComponentStatePending 等待中
+	ComponentStatePending ComponentState = "等待中"
+	// ComponentStateCreating 创建中
+	ComponentStateCreating ComponentState = "创建中"
+	// ComponentStateRunning 运行中
+	ComponentStateRunning ComponentState = "运行中"
+	// ComponentStateReady 就绪
+	ComponentStateReady ComponentState = "就绪"
+	// ComponentStateFailed 失败
+	ComponentStateFailed ComponentState = "失败"
+	// ComponentStateUpdating 更新中
+	ComponentStateUpdating ComponentState = "更新中"
+)
+
+// CertificateStatus TLS 证书状态
+type CertificateStatus struct {
+	// Source 证书用途：web 或 storage
+	Source string `json:"source"`
+
+	// SecretName 证书所在 Secret 名称
+	SecretName string `json:"secretName"`
+
+	// Subject 证书主题
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// SANs 证书包含的 DNS 名称和 IP 地址
+	// +optional
+	SANs []string `json:"sans,omitempty"`
+
+	// NotAfter 证书过期时间
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// DaysRemaining 距离过期的剩余天数(已过期为负数)
+	// +optional
+	DaysRemaining int32 `json:"daysRemaining,omitempty"`
+
+	// Message 证书无法读取或解析时的错误信息
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AccessInfo 访问信息
+type AccessInfo struct {
+	// WebURL Web 访问地址
+	// +optional
+	WebURL string `json:"webURL,omitempty"`
+
+	// LoadBalancerAddress LoadBalancer 分配的外部地址(IP 或域名)
+	// +optional
+	LoadBalancerAddress string `json:"loadBalancerAddress,omitempty"`
+
+	// DatabaseEndpoint 数据库端点
+	// +optional
+	DatabaseEndpoint string `json:"databaseEndpoint,omitempty"`
+
+	// CacheEndpoint 缓存端点
+	// +optional
+	CacheEndpoint string `json:"cacheEndpoint,omitempty"`
+
+	// StorageEndpoint 存储端点
+	// +optional
+	StorageEndpoint string `json:"storageEndpoint,omitempty"`
+
+	// StorageConsoleURL MinIO 控制台地址
+	// +optional
+	StorageConsoleURL string `json:"storageConsoleURL,omitempty"`
+
+	// JaegerUIURL Jaeger UI 访问地址
+	// +optional
+	JaegerUIURL string `json:"jaegerUIURL,omitempty"`
+
+	// ServiceEndpoints 服务端点映射
+	// +optional
+	ServiceEndpoints map[string]string `json:"serviceEndpoints,omitempty"`
+}
+
+// ========================================
+// Condition Types
+// ========================================
+
+const (
+	// ConditionTypeReady KubeNova 是否就绪
+	ConditionTypeReady = "Ready"
+	// ConditionTypeValidated 配置是否已验证
+	ConditionTypeValidated = "Validated"
+	// ConditionTypeDatabaseConnected 数据库是否连接成功
+	ConditionTypeDatabaseConnected = "DatabaseConnected"
+	// ConditionTypeCacheConnected 缓存是否连接成功
+	ConditionTypeCacheConnected = "CacheConnected"
+	// ConditionTypeStorageConnected 存储是否连接成功
+	ConditionTypeStorageConnected = "StorageConnected"
+	// ConditionTypeTelemetryReady 链路追踪是否就绪
+	ConditionTypeTelemetryReady = "TelemetryReady"
+	// ConditionTypeServicesReady 后端服务是否就绪
+	ConditionTypeServicesReady = "ServicesReady"
+	// ConditionTypeWebReady Web 前端是否就绪
+	ConditionTypeWebReady = "WebReady"
+	// ConditionTypeMaintenance 是否处于维护模式
+	ConditionTypeMaintenance = "Maintenance"
+	// ConditionTypeCertificatesReady cert-manager 证书是否已签发
+	ConditionTypeCertificatesReady = "CertificatesReady"
+	// ConditionTypeWebTLSValid Web 证书 Secret 是否有效
+	ConditionTypeWebTLSValid = "WebTLSValid"
+	// ConditionTypeStorageTLSValid MinIO 证书 Secret 是否有效
+	ConditionTypeStorageTLSValid = "StorageTLSValid"
+	// ConditionTypeCustomNginxConfigValid 自定义 Nginx ConfigMap 是否有效
+	ConditionTypeCustomNginxConfigValid = "CustomNginxConfigValid"
+	// ConditionTypeWebConfigValid 渲染后的 Nginx 配置是否通过校验
+	ConditionTypeWebConfigValid = "WebConfigValid"
+	// ConditionTypeAuthProxySecretValid oauth2-proxy Secret 是否有效
+	ConditionTypeAuthProxySecretValid = "AuthProxySecretValid"
+	// ConditionTypeDemoSeedValid 演示环境种子数据 ConfigMap 是否有效
+	ConditionTypeDemoSeedValid = "DemoSeedValid"
+	// ConditionTypeNonProduction 是否使用了 Operator 托管的单节点依赖(不适用于生产环境)
+	ConditionTypeNonProduction = "NonProduction"
+)
+
+// ========================================
+// Deletion Policies
+// ========================================
+
+const (
+	// DeletionPolicyDelete 删除所有子资源
+	DeletionPolicyDelete = "Delete"
+	// DeletionPolicyRetain 保留数据类子资源
+	DeletionPolicyRetain = "Retain"
+	// DeletionPolicyOrphan 保留所有子资源
+	DeletionPolicyOrphan = "Orphan"
+)
+
+// ========================================
+// Maintenance Modes
+// ========================================
+
+const (
+	// MaintenanceModePaused 暂停协调
+	MaintenanceModePaused = "paused"
+	// MaintenanceModePage 展示维护页面
+	MaintenanceModePage = "maintenancePage"
+)
+
+// ========================================
+// Nginx Reload Modes
+// ========================================
+
+const (
+	// NginxReloadModeRollout 滚动更新 Web Pod
+	NginxReloadModeRollout = "rollout"
+	// NginxReloadModeReload 通过 nginx -s reload 生效
+	NginxReloadModeReload = "reload"
+)
+
+// ========================================
+// Kubebuilder Markers
+// ========================================
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,shortName=kn
+//+kubebuilder:printcolumn:name="阶段",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Web",type=string,JSONPath=`.status.componentStatus.web.state`
+//+kubebuilder:printcolumn:name="访问地址",type=string,JSONPath=`.status.accessInfo.webURL`
+//+kubebuilder:printcolumn:name="运行时长",type="date",JSONPath=".metadata.creationTimestamp"
+
+// KubeNova is the Schema for the KubeNova API
+type KubeNova struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KubeNovaSpec   `json:"spec,omitempty"`
+	Status KubeNovaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KubeNovaList contains a list of KubeNova
+type KubeNovaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubeNova `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KubeNova{}, &KubeNovaList{})
+}
+
+// ========================================
+// Helper Methods
+// ========================================
+
+// GetTimezone 获取全局时区
+func (k *KubeNova) GetTimezone() string {
+	if k.Spec.Timezone == "" {
+		return DefaultTimezone
+	}
+	return k.Spec.Timezone
+}
+
+// GetServiceTimezone 获取服务的时区，服务未配置时使用全局时区
+func (k *KubeNova) GetServiceTimezone(s *ServiceConfig) string {
+	if s != nil && s.Timezone != "" {
+		return s.Timezone
+	}
+	return k.GetTimezone()
+}
+
+// GetWebTimezone 获取 Web 的时区，未配置时使用全局时区
+func (k *KubeNova) GetWebTimezone() string {
+	if k.Spec.Web.Timezone != "" {
+		return k.Spec.Web.Timezone
+	}
+	return k.GetTimezone()
+}
+
+// GetLogTimeFormat 获取后端服务日志的时间格式
+func (k *KubeNova) GetLogTimeFormat() string {
+	if k.Spec.LogTimeFormat == "" {
+		return DefaultLogTimeFormat
+	}
+	return k.Spec.LogTimeFormat
+}
+
+// GetDemoReset 获取演示环境的定时重置配置，未启用演示模式或未配置时返回 nil
+func (k *KubeNova) GetDemoReset() *DemoConfig {
+	portal := k.Spec.Services.Portal
+	if portal == nil || !portal.DemoMode {
+		return nil
+	}
+	return portal.Demo
+}
+
+// GetKey 获取 SQL 所在的键
+func (c *DemoSeedConfigMapRef) GetKey() string {
+	if c.Key == "" {
+		return "seed.sql"
+	}
+	return c.Key
+}
+
+// GetActiveDeadlineSeconds 获取单次重置的超时时间(秒)
+func (d *DemoConfig) GetActiveDeadlineSeconds() int64 {
+	if d.ActiveDeadlineSeconds == nil {
+		return 1800
+	}
+	return *d.ActiveDeadlineSeconds
+}
+
+// GetImageRegistry 获取镜像仓库配置
+func (k *KubeNova) GetImageRegistry() ImageRegistryConfig {
+	if k.Spec.ImageRegistry != nil {
+		return *k.Spec.ImageRegistry
+	}
+	return ImageRegistryConfig{
+		Registry:     "registry.cn-hangzhou.aliyuncs.com",
+		Organization: "kube-nova",
+		Tag:          "latest",
+		PullPolicy:   corev1.PullAlways,
+	}
+}
+
+// IsServiceEnabled 检查服务是否启用
+func (s *ServiceConfig) IsServiceEnabled() bool {
+	if s == nil {
+		return true
+	}
+	if s.Enabled == nil {
+		return true
+	}
+	return *s.Enabled
+}
+
+// GetReplicas 获取副本数
+func (s *ServiceConfig) GetReplicas() int32 {
+	if s == nil || s.Replicas <= 0 {
+		return 2
+	}
+	return s.Replicas
+}
+
+// IsHeadless 检查 Service 是否为 headless
+func (s *ServiceConfig) IsHeadless() bool {
+	return s != nil && s.Headless
+}
+
+// IsGRPCHealthProbeEnabled 检查是否使用 gRPC 健康检查作为就绪探针
+func (s *ServiceConfig) IsGRPCHealthProbeEnabled() bool {
+	return s == nil || s.GRPCHealthProbe == nil || *s.GRPCHealthProbe
+}
+
+// GetProbes 获取探针参数覆盖
+func (s *ServiceConfig) GetProbes() *ProbesConfig {
+	if s == nil {
+		return nil
+	}
+	return s.Probes
+}
+
+// GetLifecycle 获取优雅停止配置
+func (s *ServiceConfig) GetLifecycle() *LifecycleConfig {
+	if s == nil {
+		return nil
+	}
+	return s.Lifecycle
+}
+
+// GetLogsVolume 获取日志目录的卷配置
+func (s *ServiceConfig) GetLogsVolume() *VolumeConfig {
+	if s == nil || s.Volumes == nil {
+		return nil
+	}
+	return s.Volumes.Logs
+}
+
+// GetCacheVolume 获取缓存目录的卷配置
+func (s *ServiceConfig) GetCacheVolume() *VolumeConfig {
+	if s == nil || s.Volumes == nil {
+		return nil
+	}
+	return s.Volumes.Cache
+}
+
+// GetPreStopSleepSeconds 获取停止前等待时间，0 表示不等待
+func (l *LifecycleConfig) GetPreStopSleepSeconds() int64 {
+	if l == nil || l.PreStopSleepSeconds == nil {
+		return 0
+	}
+	return *l.PreStopSleepSeconds
+}
+
+// GetTerminationGracePeriodSeconds 获取优雅停止时间
+func (l *LifecycleConfig) GetTerminationGracePeriodSeconds() int64 {
+	if l == nil || l.TerminationGracePeriodSeconds == nil {
+		return 30
+	}
+	return *l.TerminationGracePeriodSeconds
+}
+
+// GetRPCClient 获取调用指定 RPC 服务的客户端配置，未配置时返回 nil
+func (s *ServiceConfig) GetRPCClient(target string) *RPCClientConfig {
+	if s == nil {
+		return nil
+	}
+	for i := range s.RPCClients {
+		if s.RPCClients[i].Target == target {
+			return &s.RPCClients[i]
+		}
+	}
+	return nil
+}
+
+// IsOptional 检查目标服务不可用时是否允许启动
+func (c *RPCClientConfig) IsOptional() bool {
+	return c == nil || c.Optional == nil || *c.Optional
+}
+
+// IsNonBlock 检查启动时是否不等待连接建立
+func (c *RPCClientConfig) IsNonBlock() bool {
+	return c == nil || c.NonBlock == nil || *c.NonBlock
+}
+
+// GetDiscovery 获取服务发现方式
+func (c *RPCClientConfig) GetDiscovery() string {
+	if c == nil || c.Discovery == "" {
+		return RPCDiscoveryK8s
+	}
+	return c.Discovery
+}
+
+// GetResources 获取资源配置
+func (s *ServiceConfig) GetResources() *corev1.ResourceRequirements {
+	if s == nil || s.Resources == nil {
+		return nil
+	}
+	return s.Resources
+}
+
+// GetRPCCallers 获取调用 RPC 服务的服务配置(服务名 -> 配置)
+func (k *KubeNova) GetRPCCallers() map[string]*ServiceConfig {
+	return map[string]*ServiceConfig{
+		"portal-api":   k.Spec.Services.PortalAPI,
+		"manager-api":  k.Spec.Services.ManagerAPI,
+		"manager-rpc":  k.Spec.Services.ManagerRPC,
+		"workload-api": k.Spec.Services.WorkloadAPI,
+		"console-api":  k.Spec.Services.ConsoleAPI,
+	}
+}
+
+// GetRPCServiceConfig 获取 RPC 服务的配置，非 RPC 服务返回 nil
+func (k *KubeNova) GetRPCServiceConfig(service string) *ServiceConfig {
+	switch service {
+	case "portal-rpc":
+		return k.Spec.Services.PortalRPC
+	case "manager-rpc":
+		return k.Spec.Services.ManagerRPC
+	case "console-rpc":
+		return k.Spec.Services.ConsoleRPC
+	}
+	return nil
+}
+
+// IsRPCDNSDiscoveryUsed 检查是否有服务通过 DNS 发现指定的 RPC 服务
+func (k *KubeNova) IsRPCDNSDiscoveryUsed(target string) bool {
+	for _, caller := range k.GetRPCCallers() {
+		if caller.IsServiceEnabled() && caller.GetRPCClient(target).GetDiscovery() == RPCDiscoveryDNS {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTelemetryEnabled 检查链路追踪是否启用
+func (k *KubeNova) IsTelemetryEnabled() bool {
+	return k.Spec.Telemetry != nil && k.Spec.Telemetry.Enabled
+}
+
+// ValidateTelemetryConfig 验证链路追踪配置
+func (t *TelemetryConfig) ValidateTelemetryConfig() error {
+	if !t.Enabled {
+		return nil
+	}
+	if t.JaegerEndpoint == "" {
+		return fmt.Errorf("链路追踪已启用但未配置 Jaeger 端点地址")
+	}
+	return nil
+}
+
+// ValidateMinIOTLS 验证 MinIO TLS 配置
+func (tls *MinIOTLSConfig) ValidateMinIOTLS() error {
+	if !tls.Enabled {
+		return nil
+	}
+	if tls.SecretName == "" && tls.IssuerRef == nil {
+		return fmt.Errorf("MinIO TLS 已启用但未指定证书 Secret 名称或 issuerRef")
+	}
+	return nil
+}
+
+// ValidateWebConfig 验证 Web 配置
+func (w *WebConfig) ValidateWebConfig() error {
+	switch w.ExposeType {
+	case "ingress":
+		if w.Ingress == nil {
+			return fmt.Errorf("暴露方式为 ingress 但未配置 Ingress")
+		}
+		if w.Ingress.Host == "" {
+			return fmt.Errorf("ingress 配置缺少域名")
+		}
+		if w.Ingress.TLS != nil && w.Ingress.TLS.Enabled && w.Ingress.TLS.SecretName == "" && w.Ingress.TLS.IssuerRef == nil {
+			return fmt.Errorf("ingress TLS 已启用但未指定证书 Secret 名称")
+		}
+	case "gateway":
+		if w.Gateway == nil {
+			return fmt.Errorf("暴露方式为 gateway 但未配置 Gateway")
+		}
+		if len(w.Gateway.Hostnames) == 0 {
+			return fmt.Errorf("gateway 配置缺少域名")
+		}
+		listenerEnabled := w.Gateway.Listener != nil && w.Gateway.Listener.Enabled
+		if listenerEnabled && w.Gateway.Listener.GatewayClassName == "" {
+			return fmt.Errorf("gateway 监听器已启用但未指定 gatewayClassName")
+		}
+		if !listenerEnabled && len(w.Gateway.ParentRefs) == 0 {
+			return fmt.Errorf("gateway 未启用监听器时必须配置 parentRefs")
+		}
+		if listenerEnabled && w.Gateway.TLS != nil && w.Gateway.TLS.Enabled && len(w.Gateway.TLS.CertificateRefs) == 0 {
+			return fmt.Errorf("gateway TLS 已启用但未指定证书 Secret 名称")
+		}
+	case "nodeport":
+		// NodePort 可以为 nil，这种情况下使用自动分配的端口
+		// 只有当 HTTPS 启用时才需要验证证书配置
+		if w.NodePort != nil && w.NodePort.HTTPS != nil && w.NodePort.HTTPS.Enabled && w.NodePort.HTTPS.SecretName == "" && w.NodePort.HTTPS.IssuerRef == nil {
+			return fmt.Errorf("NodePort HTTPS 已启用但未指定证书 Secret 名称")
+		}
+	case "loadbalancer":
+		// LoadBalancer 可以为 nil，这种情况下由负载均衡器自动分配地址
+		if w.LoadBalancer != nil {
+			for _, cidr := range w.LoadBalancer.SourceRanges {
+				if _, _, err := net.ParseCIDR(cidr); err != nil {
+					return fmt.Errorf("LoadBalancer sourceRanges 格式错误 %q: %w", cidr, err)
+				}
+			}
+			if ip := w.LoadBalancer.LoadBalancerIP; ip != "" && net.ParseIP(ip) == nil {
+				return fmt.Errorf("LoadBalancer loadBalancerIP 格式错误: %s", ip)
+			}
+		}
+	default:
+		return fmt.Errorf("不支持的暴露方式: %s", w.ExposeType)
+	}
+	return nil
+}
+
+// GetCachePassword 获取 Redis 密码
+func (c *CacheConfig) GetCachePassword() string {
+	return c.Password
+}
+
+// GetDatabaseConnectionString 获取数据库连接字符串(用于内部)
+func (d *DatabaseConfig) GetDatabaseConnectionString() string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=10s",
+		d.User, d.Password, d.Host, d.Port, d.Database)
+}
+
+// GetStorageEndpoint 获取存储端点(带协议)
+func (s *StorageConfig) GetStorageEndpoint() string {
+	if s.TLS != nil && s.TLS.Enabled {
+		return "https://" + s.Endpoint
+	}
+	return "http://" + s.Endpoint
+}
+
+// GetCacheEndpoint 获取缓存端点
+func (c *CacheConfig) GetCacheEndpoint() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
+// GetDatabaseEndpoint 获取数据库端点
+func (d *DatabaseConfig) GetDatabaseEndpoint() string {
+	return fmt.Sprintf("%s:%d", d.Host, d.Port)
+}
+
+// IsManagedDependencyUsed 检查是否使用了 Operator 托管的依赖
+func (k *KubeNova) IsManagedDependencyUsed() bool {
+	return k.Spec.Database.Managed || k.Spec.Cache.Managed || k.Spec.Storage.Managed
+}
+
+// getManagedHost 获取托管依赖在目标命名空间中的 Service 地址
+func (k *KubeNova) getManagedHost(name string) string {
+	return fmt.Sprintf("%s.%s.svc", name, k.GetTargetNamespace())
+}
+
+// GetDatabaseHost 获取数据库主机地址(托管时为 Operator 部署的 MySQL)
+func (k *KubeNova) GetDatabaseHost() string {
+	if k.Spec.Database.Managed {
+		return k.getManagedHost(ManagedMySQLName)
+	}
+	return k.Spec.Database.Host
+}
+
+// GetDatabasePort 获取数据库端口
+func (k *KubeNova) GetDatabasePort() int32 {
+	if k.Spec.Database.Managed {
+		return 3306
+	}
+	return k.Spec.Database.Port
+}
+
+// GetDatabaseName 获取数据库名称
+func (k *KubeNova) GetDatabaseName() string {
+	if k.Spec.Database.Managed && k.Spec.Database.Database == "" {
+		return ManagedDatabaseName
+	}
+	return k.Spec.Database.Database
+}
+
+// GetDatabaseUser 获取数据库用户名
+func (k *KubeNova) GetDatabaseUser() string {
+	if k.Spec.Database.Managed && k.Spec.Database.User == "" {
+		return ManagedDatabaseName
+	}
+	return k.Spec.Database.User
+}
+
+// GetCacheHost 获取 Redis 主机地址(托管时为 Operator 部署的 Redis)
+func (k *KubeNova) GetCacheHost() string {
+	if k.Spec.Cache.Managed {
+		return k.getManagedHost(ManagedRedisName)
+	}
+	return k.Spec.Cache.Host
+}
+
+// GetCachePort 获取 Redis 端口
+func (k *KubeNova) GetCachePort() int32 {
+	if k.Spec.Cache.Managed {
+		return 6379
+	}
+	return k.Spec.Cache.Port
+}
+
+// GetStorageAddress 获取存储端点地址(不含协议，托管时为 Operator 部署的 MinIO)
+func (k *KubeNova) GetStorageAddress() string {
+	if k.Spec.Storage.Managed {
+		return k.getManagedHost(ManagedMinIOName) + ":9000"
+	}
+	return k.Spec.Storage.Endpoint
+}
+
+// GetStorageBucket 获取存储桶名称
+func (k *KubeNova) GetStorageBucket() string {
+	if k.Spec.Storage.Managed && k.Spec.Storage.Bucket == "" {
+		return ManagedBucketName
+	}
+	return k.Spec.Storage.Bucket
+}
+
+// GetWebReplicas 获取 Web 副本数
+func (w *WebConfig) GetWebReplicas() int32 {
+	if w.Replicas <= 0 {
+		return 3
+	}
+	return w.Replicas
+}
+
+// IsMinIOProxyEnabled 检查 MinIO 代理是否启用
+func (k *KubeNova) IsMinIOProxyEnabled() bool {
+	return k.Spec.Web.MinIOProxy != nil && k.Spec.Web.MinIOProxy.Enabled
+}
+
+// GetMinIOProxyPath 获取 MinIO 代理路径
+func (k *KubeNova) GetMinIOProxyPath() string {
+	if k.Spec.Web.MinIOProxy == nil || k.Spec.Web.MinIOProxy.PathPrefix == "" {
+		return "/storage"
+	}
+	return k.Spec.Web.MinIOProxy.PathPrefix
+}
+
+// GetMinIOProxyEndpoint 获取用户配置的 MinIO 代理端点
+func (k *KubeNova) GetMinIOProxyEndpoint() string {
+	if k.Spec.Web.MinIOProxy != nil && k.Spec.Web.MinIOProxy.ProxyEndpoint != "" {
+		return k.Spec.Web.MinIOProxy.ProxyEndpoint
+	}
+	return ""
+}
+
+// GetMinIOEndpointForBackend 获取后端服务使用的 MinIO 端点
+// - 未启用代理：minio-service:9000
+// - Ingress/Gateway 模式：www.example.com/storage
+// - NodePort 模式：<NODE_IP>:<NODE_PORT>/storage
+func (k *KubeNova) GetMinIOEndpointForBackend() string {
+	// 如果未启用代理，直接返回实际 MinIO 地址
+	if !k.IsMinIOProxyEnabled() {
+		return k.GetStorageAddress()
+	}
+
+	// 如果用户手动配置了代理端点，使用用户配置
+	if k.Spec.Web.MinIOProxy.ProxyEndpoint != "" {
+		return k.Spec.Web.MinIOProxy.ProxyEndpoint
+	}
+
+	// 自动推断代理端点
+	pathPrefix := k.GetMinIOProxyPath()
+
+	if host := k.GetWebHostname(); host != "" {
+		// Ingress/Gateway 模式：返回域名 + pathPrefix
+		// 例如：www.example.com/storage
+		// 移除前导斜杠
+		if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
+			pathPrefix = pathPrefix[1:]
+		}
+		return fmt.Sprintf("%s/%s", host, pathPrefix)
+	}
+
+	if k.Spec.Web.ExposeType == "loadbalancer" {
+		// LoadBalancer 模式：返回占位符，实际地址在外部地址分配后更新
+		if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
+			pathPrefix = pathPrefix[1:]
+		}
+		return fmt.Sprintf("<LB_ADDRESS>/%s", pathPrefix)
+	}
+
+	// NodePort 模式：返回占位符，实际地址在 Status 中更新
+	// 例如：<NODE_IP>:<NODE_PORT>/storage
+	// 移除前导斜杠
+	if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
+		pathPrefix = pathPrefix[1:]
+	}
+	return fmt.Sprintf("<NODE_IP>:<NODE_PORT>/%s", pathPrefix)
+}
+
+// GetMinIOEndpointForBackendWithNodeInfo 获取后端服务使用的 MinIO 端点(带 Node 信息)
+// 用于 controller 在运行时生成实际地址
+// LoadBalancer 模式下 nodeIP 传入 Service 分配的外部地址，nodePort 不使用
+func (k *KubeNova) GetMinIOEndpointForBackendWithNodeInfo(nodeIP string, nodePort int32) string {
+	// 如果未启用代理，直接返回实际 MinIO 地址
+	if !k.IsMinIOProxyEnabled() {
+		return k.GetStorageAddress()
+	}
+
+	// 如果用户手动配置了代理端点，使用用户配置
+	if k.Spec.Web.MinIOProxy.ProxyEndpoint != "" {
+		return k.Spec.Web.MinIOProxy.ProxyEndpoint
+	}
+
+	// 自动推断代理端点
+	pathPrefix := k.GetMinIOProxyPath()
+
+	if host := k.GetWebHostname(); host != "" {
+		protocol := "http"
+		if k.IsWebHostTLSEnabled() {
+			protocol = "https"
+		}
+		if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
+			pathPrefix = pathPrefix[1:]
+		}
+		return fmt.Sprintf("%s://%s/%s", protocol, host, pathPrefix)
+	}
+
+	// LoadBalancer 模式：nodeIP 为 Service 分配的外部地址
+	if k.Spec.Web.ExposeType == "loadbalancer" {
+		if nodeIP == "" {
+			nodeIP = "<LB_ADDRESS>"
+		}
+		if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
+			pathPrefix = pathPrefix[1:]
+		}
+		return fmt.Sprintf("%s://%s/%s", k.GetLoadBalancerScheme(), nodeIP, pathPrefix)
+	}
+
+	// NodePort 模式：使用实际的 Node IP 和 NodePort，并判断 HTTPS
+	protocol := "http"
+	if k.Spec.Web.NodePort != nil && k.Spec.Web.NodePort.HTTPS != nil && k.Spec.Web.NodePort.HTTPS.Enabled {
+		protocol = "https"
+	}
+	if nodeIP == "" {
+		nodeIP = "<NODE_IP>"
+	}
+	if nodePort == 0 {
+		if protocol == "https" {
+			nodePort = 30443 // HTTPS 默认值
+		} else {
+			nodePort = 30080 // HTTP 默认值
+		}
+	}
+	if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
+		pathPrefix = pathPrefix[1:]
+	}
+	return fmt.Sprintf("%s://%s:%d/%s", protocol, nodeIP, nodePort, pathPrefix)
+}
+
+// GetLoadBalancerScheme 获取 LoadBalancer 模式下的外部访问协议
+func (k *KubeNova) GetLoadBalancerScheme() string {
+	if lb := k.Spec.Web.LoadBalancer; lb != nil && lb.Scheme != "" {
+		return lb.Scheme
+	}
+	return "http"
+}
+
+// IsLoadBalancerPending 检查 LoadBalancer 模式下是否仍在等待分配外部地址
+func (k *KubeNova) IsLoadBalancerPending() bool {
+	return k.Spec.Web.ExposeType == "loadbalancer" &&
+		(k.Status.AccessInfo == nil || k.Status.AccessInfo.LoadBalancerAddress == "")
+}
+
+// GetWebTLSSecretName 获取 Web 证书 Secret 名称(ingress TLS 或 NodePort HTTPS)
+// 未启用 TLS 时返回空字符串
+func (k *KubeNova) GetWebTLSSecretName() string {
+	switch k.Spec.Web.ExposeType {
+	case "ingress":
+		if tls := k.Spec.Web.Ingress; tls != nil && tls.TLS != nil && tls.TLS.Enabled {
+			return tlsSecretNameOrDefault(tls.TLS.SecretName, tls.TLS.IssuerRef, "kube-nova-web-tls")
+		}
+	case "nodeport":
+		if https := k.Spec.Web.NodePort; https != nil && https.HTTPS != nil && https.HTTPS.Enabled {
+			return tlsSecretNameOrDefault(https.HTTPS.SecretName, https.HTTPS.IssuerRef, "kube-nova-web-tls")
+		}
+	}
+	return ""
+}
+
+// GetWebTLSIssuerRef 获取 Web 证书的 cert-manager 签发者引用
+func (k *KubeNova) GetWebTLSIssuerRef() *CertIssuerRef {
+	if k.GetWebTLSSecretName() == "" {
+		return nil
+	}
+	switch k.Spec.Web.ExposeType {
+	case "ingress":
+		return k.Spec.Web.Ingress.TLS.IssuerRef
+	case "nodeport":
+		return k.Spec.Web.NodePort.HTTPS.IssuerRef
+	}
+	return nil
+}
+
+// IsNodePortHTTPSEnabled 检查 NodePort 模式下是否启用 HTTPS
+func (k *KubeNova) IsNodePortHTTPSEnabled() bool {
+	return k.Spec.Web.ExposeType == "nodeport" && k.GetWebTLSSecretName() != ""
+}
+
+// GetMinIOTLSSecretName 获取 MinIO 证书 Secret 名称
+// 未启用 TLS 时返回空字符串
+func (k *KubeNova) GetMinIOTLSSecretName() string {
+	if tls := k.Spec.Storage.TLS; tls != nil && tls.Enabled {
+		return tlsSecretNameOrDefault(tls.SecretName, tls.IssuerRef, "kube-nova-minio-tls")
+	}
+	return ""
+}
+
+// IsMinIOCertIssued 检查 MinIO 证书是否由 cert-manager 签发
+func (k *KubeNova) IsMinIOCertIssued() bool {
+	return k.GetMinIOTLSSecretName() != "" && k.Spec.Storage.TLS.IssuerRef != nil
+}
+
+// tlsSecretNameOrDefault 证书由 cert-manager 签发且未指定 Secret 名称时使用默认名称
+func tlsSecretNameOrDefault(secretName string, issuerRef *CertIssuerRef, defaultName string) string {
+	if secretName == "" && issuerRef != nil {
+		return defaultName
+	}
+	return secretName
+}
+
+// GetWebHostname 获取基于域名暴露(ingress/gateway)时的访问域名
+// 其他暴露方式返回空字符串
+func (k *KubeNova) GetWebHostname() string {
+	switch k.Spec.Web.ExposeType {
+	case "ingress":
+		if k.Spec.Web.Ingress != nil {
+			return k.Spec.Web.Ingress.Host
+		}
+	case "gateway":
+		if k.Spec.Web.Gateway != nil && len(k.Spec.Web.Gateway.Hostnames) > 0 {
+			return k.Spec.Web.Gateway.Hostnames[0]
+		}
+	}
+	return ""
+}
+
+// IsWebHostTLSEnabled 检查基于域名暴露(ingress/gateway)时是否启用 TLS
+func (k *KubeNova) IsWebHostTLSEnabled() bool {
+	switch k.Spec.Web.ExposeType {
+	case "ingress":
+		return k.Spec.Web.Ingress != nil && k.Spec.Web.Ingress.TLS != nil && k.Spec.Web.Ingress.TLS.Enabled
+	case "gateway":
+		return k.Spec.Web.Gateway != nil && k.Spec.Web.Gateway.TLS != nil && k.Spec.Web.Gateway.TLS.Enabled
+	}
+	return false
+}
+
+// IsGatewayListenerEnabled 检查是否由 Operator 创建 Gateway
+func (k *KubeNova) IsGatewayListenerEnabled() bool {
+	return k.Spec.Web.ExposeType == "gateway" &&
+		k.Spec.Web.Gateway != nil &&
+		k.Spec.Web.Gateway.Listener != nil &&
+		k.Spec.Web.Gateway.Listener.Enabled
+}
+
+// GetSecurityHeaders 获取安全响应头配置(未配置时返回空配置，各字段使用默认值)
+func (w *WebConfig) GetSecurityHeaders() *SecurityHeadersConfig {
+	if w.SecurityHeaders == nil {
+		return &SecurityHeadersConfig{}
+	}
+	return w.SecurityHeaders
+}
+
+// GetFrameOptions 获取 X-Frame-Options 响应头的值，返回空字符串表示不发送
+func (s *SecurityHeadersConfig) GetFrameOptions() string {
+	if len(s.FrameAncestors) > 0 || s.FrameOptions == "off" {
+		return ""
+	}
+	if s.FrameOptions == "" {
+		return "SAMEORIGIN"
+	}
+	return s.FrameOptions
+}
+
+// GetContentSecurityPolicy 获取 Content-Security-Policy 响应头的值(合并 FrameAncestors)
+func (s *SecurityHeadersConfig) GetContentSecurityPolicy() string {
+	csp := strings.TrimRight(strings.TrimSpace(s.ContentSecurityPolicy), ";")
+	if len(s.FrameAncestors) == 0 {
+		return csp
+	}
+	frameAncestors := "frame-ancestors 'self' " + strings.Join(s.FrameAncestors, " ")
+	if csp == "" {
+		return frameAncestors
+	}
+	return csp + "; " + frameAncestors
+}
+
+// GetReferrerPolicy 获取 Referrer-Policy 响应头的值
+func (s *SecurityHeadersConfig) GetReferrerPolicy() string {
+	if s.ReferrerPolicy == "" {
+		return "no-referrer-when-downgrade"
+	}
+	return s.ReferrerPolicy
+}
+
+// GetPermissionsPolicy 获取 Permissions-Policy 响应头的值
+func (s *SecurityHeadersConfig) GetPermissionsPolicy() string {
+	if s.PermissionsPolicy == "" {
+		return "geolocation=(), microphone=(), camera=()"
+	}
+	return s.PermissionsPolicy
+}
+
+// IsXSSProtectionEnabled 检查是否发送 X-XSS-Protection 响应头(默认发送)
+func (s *SecurityHeadersConfig) IsXSSProtectionEnabled() bool {
+	return s.XSSProtection == nil || *s.XSSProtection
+}
+
+// IsHSTSEnabled 检查是否发送 HSTS 响应头
+// 未显式配置时仅在 NodePort HTTPS 模式下发送
+func (k *KubeNova) IsHSTSEnabled() bool {
+	headers := k.Spec.Web.GetSecurityHeaders()
+	if headers.HSTS != nil && headers.HSTS.Enabled != nil {
+		return *headers.HSTS.Enabled && (k.IsNodePortHTTPSEnabled() || k.IsWebHostTLSEnabled())
+	}
+	return k.IsNodePortHTTPSEnabled()
+}
+
+// GetHSTSValue 获取 Strict-Transport-Security 响应头的值
+func (s *SecurityHeadersConfig) GetHSTSValue() string {
+	maxAge := int32(31536000)
+	includeSubDomains, preload := true, false
+	if s.HSTS != nil {
+		maxAge = int32OrDefault(s.HSTS.MaxAge, maxAge)
+		includeSubDomains = s.HSTS.IncludeSubDomains == nil || *s.HSTS.IncludeSubDomains
+		preload = s.HSTS.Preload
+	}
+
+	value := fmt.Sprintf("max-age=%d", maxAge)
+	if includeSubDomains {
+		value += "; includeSubDomains"
+	}
+	if preload {
+		value += "; preload"
+	}
+	return value
+}
+
+// IsCORSEnabled 检查是否为 API 路径启用跨域
+func (s *SecurityHeadersConfig) IsCORSEnabled() bool {
+	return s.CORS != nil && len(s.CORS.AllowedOrigins) > 0
+}
+
+// GetAllowedMethods 获取跨域允许的请求方法
+func (c *CORSConfig) GetAllowedMethods() string {
+	if c.AllowedMethods == "" {
+		return "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	}
+	return c.AllowedMethods
+}
+
+// GetAllowedHeaders 获取跨域允许的请求头
+func (c *CORSConfig) GetAllowedHeaders() string {
+	if c.AllowedHeaders == "" {
+		return "Authorization, Content-Type, X-Requested-With"
+	}
+	return c.AllowedHeaders
+}
+
+// GetMaxAge 获取预检请求缓存时间
+func (c *CORSConfig) GetMaxAge() int32 {
+	return int32OrDefault(c.MaxAge, 86400)
+}
+
+// 外部认证代理的部署方式
+const (
+	// AuthModeSidecar oauth2-proxy 作为 Web Pod 的 sidecar
+	AuthModeSidecar = "sidecar"
+	// AuthModeDeployment oauth2-proxy 作为独立的 Deployment
+	AuthModeDeployment = "deployment"
+	// AuthModeExternal 使用已有的认证代理
+	AuthModeExternal = "external"
+)
+
+// GetAccessControl 获取访问控制配置(未配置时返回空配置)
+func (w *WebConfig) GetAccessControl() *AccessControlConfig {
+	if w.AccessControl == nil {
+		return &AccessControlConfig{}
+	}
+	return w.AccessControl
+}
+
+// HasRules 检查是否配置了全局 IP 规则
+func (a *AccessControlConfig) HasRules() bool {
+	return len(a.Allow) > 0 || len(a.Deny) > 0
+}
+
+// GetPathRule 获取指定路径的访问规则，未配置时返回 nil
+func (a *AccessControlConfig) GetPathRule(path string) *PathAccessRule {
+	for i := range a.Paths {
+		if a.Paths[i].Path == path {
+			return &a.Paths[i]
+		}
+	}
+	return nil
+}
+
+// IsClientIPVisibleToNginx 检查 Nginx 能否获取客户端真实 IP
+// Ingress/Gateway 模式下请求来自网关，需要配置 nginx.realIP 才能按客户端 IP 控制访问；
+// NodePort/LoadBalancer 模式下 Cluster 策略会对请求做 SNAT，需要使用 Local 策略或配置 nginx.realIP
+func (k *KubeNova) IsClientIPVisibleToNginx() bool {
+	if k.Spec.Web.GetNginxConfig().IsRealIPEnabled() {
+		return true
+	}
+	switch k.Spec.Web.ExposeType {
+	case "nodeport", "loadbalancer":
+		return k.GetWebExternalTrafficPolicy() == corev1.ServiceExternalTrafficPolicyTypeLocal
+	}
+	return false
+}
+
+// GetWebExternalTrafficPolicy 获取 NodePort/LoadBalancer 模式下 Web Service 的外部流量策略
+// LoadBalancer 使用配置的策略；NodePort 在按客户端 IP 控制访问且未配置 nginx.realIP 时使用 Local 以保留客户端源 IP
+func (k *KubeNova) GetWebExternalTrafficPolicy() corev1.ServiceExternalTrafficPolicy {
+	switch k.Spec.Web.ExposeType {
+	case "loadbalancer":
+		if lb := k.Spec.Web.LoadBalancer; lb != nil && lb.ExternalTrafficPolicy == string(corev1.ServiceExternalTrafficPolicyTypeLocal) {
+			return corev1.ServiceExternalTrafficPolicyTypeLocal
+		}
+	case "nodeport":
+		access := k.Spec.Web.GetAccessControl()
+		if (access.HasRules() || len(access.Paths) > 0) && !k.Spec.Web.GetNginxConfig().IsRealIPEnabled() {
+			return corev1.ServiceExternalTrafficPolicyTypeLocal
+		}
+	}
+	return corev1.ServiceExternalTrafficPolicyTypeCluster
+}
+
+// IsExternalAuthEnabled 检查是否启用外部认证
+func (w *WebConfig) IsExternalAuthEnabled() bool {
+	return w.AccessControl != nil && w.AccessControl.Auth != nil
+}
+
+// GetAuthMode 获取认证代理的部署方式
+func (a *ExternalAuthConfig) GetAuthMode() string {
+	if a.Mode == "" {
+		return AuthModeSidecar
+	}
+	return a.Mode
+}
+
+// GetAuthResponseHeaders 获取透传给后端 API 的认证响应头
+func (a *ExternalAuthConfig) GetAuthResponseHeaders() []string {
+	if len(a.ResponseHeaders) == 0 {
+		return []string{"X-Auth-Request-User", "X-Auth-Request-Email"}
 	}
+	return a.ResponseHeaders
 }
 
-// IsServiceEnabled 检查服务是否启用
-func (s *ServiceConfig) IsServiceEnabled() bool {
-	if s == nil {
-		return true
+// GetOAuth2Proxy 获取 oauth2-proxy 配置(未配置时返回空配置)
+func (a *ExternalAuthConfig) GetOAuth2Proxy() *OAuth2ProxyConfig {
+	if a.OAuth2Proxy == nil {
+		return &OAuth2ProxyConfig{}
 	}
-	if s.Enabled == nil {
-		return true
+	return a.OAuth2Proxy
+}
+
+// GetImage 获取 oauth2-proxy 镜像
+func (o *OAuth2ProxyConfig) GetImage() string {
+	if o.Image == "" {
+		return "quay.io/oauth2-proxy/oauth2-proxy:v7.6.0"
 	}
-	return *s.Enabled
+	return o.Image
 }
 
-// GetReplicas 获取副本数
-func (s *ServiceConfig) GetReplicas() int32 {
-	if s == nil || s.Replicas <= 0 {
-		return 2
+// GetProvider 获取认证提供方
+func (o *OAuth2ProxyConfig) GetProvider() string {
+	if o.Provider == "" {
+		return "oidc"
 	}
-	return s.Replicas
+	return o.Provider
 }
 
-// GetResources 获取资源配置
-func (s *ServiceConfig) GetResources() *corev1.ResourceRequirements {
-	if s == nil || s.Resources == nil {
-		return nil
+// GetEmailDomains 获取允许登录的邮箱域名
+func (o *OAuth2ProxyConfig) GetEmailDomains() []string {
+	if len(o.EmailDomains) == 0 {
+		return []string{"*"}
 	}
-	return s.Resources
+	return o.EmailDomains
 }
 
-// IsTelemetryEnabled 检查链路追踪是否启用
-func (k *KubeNova) IsTelemetryEnabled() bool {
-	return k.Spec.Telemetry != nil && k.Spec.Telemetry.Enabled
+// GetReplicas 获取 deployment 模式的副本数
+func (o *OAuth2ProxyConfig) GetReplicas() int32 {
+	return int32OrDefault(o.Replicas, 1)
 }
 
-// ValidateTelemetryConfig 验证链路追踪配置
-func (t *TelemetryConfig) ValidateTelemetryConfig() error {
-	if !t.Enabled {
+// GetLogsVolume 获取 Nginx 日志目录的卷配置
+func (w *WebConfig) GetLogsVolume() *VolumeConfig {
+	if w.Volumes == nil {
 		return nil
 	}
-	if t.JaegerEndpoint == "" {
-		return fmt.Errorf("链路追踪已启用但未配置 Jaeger 端点地址")
-	}
-	return nil
+	return w.Volumes.Logs
 }
 
-// ValidateMinIOTLS 验证 MinIO TLS 配置
-func (tls *MinIOTLSConfig) ValidateMinIOTLS() error {
-	if !tls.Enabled {
+// GetCacheVolume 获取 Nginx 缓存目录的卷配置
+func (w *WebConfig) GetCacheVolume() *VolumeConfig {
+	if w.Volumes == nil {
 		return nil
 	}
-	if tls.SecretName == "" {
-		return fmt.Errorf("MinIO TLS 已启用但未指定证书 Secret 名称")
-	}
-	return nil
+	return w.Volumes.Cache
 }
 
-// ValidateWebConfig 验证 Web 配置
-func (w *WebConfig) ValidateWebConfig() error {
-	switch w.ExposeType {
-	case "ingress":
-		if w.Ingress == nil {
-			return fmt.Errorf("暴露方式为 ingress 但未配置 Ingress")
-		}
-		if w.Ingress.Host == "" {
-			return fmt.Errorf("ingress 配置缺少域名")
-		}
-		if w.Ingress.TLS != nil && w.Ingress.TLS.Enabled && w.Ingress.TLS.SecretName == "" {
-			return fmt.Errorf("ingress TLS 已启用但未指定证书 Secret 名称")
-		}
-	case "nodeport":
-		// NodePort 可以为 nil，这种情况下使用自动分配的端口
-		// 只有当 HTTPS 启用时才需要验证证书配置
-		if w.NodePort != nil && w.NodePort.HTTPS != nil && w.NodePort.HTTPS.Enabled && w.NodePort.HTTPS.SecretName == "" {
-			return fmt.Errorf("NodePort HTTPS 已启用但未指定证书 Secret 名称")
-		}
-	default:
-		return fmt.Errorf("不支持的暴露方式: %s", w.ExposeType)
-	}
-	return nil
+// IsNginxReloadMode 检查 Nginx 配置变更是否通过 reload 生效(不重启 Pod)
+func (w *WebConfig) IsNginxReloadMode() bool {
+	return w.NginxConfigReload != nil && w.NginxConfigReload.Mode == NginxReloadModeReload
 }
 
-// GetCachePassword 获取 Redis 密码
-func (c *CacheConfig) GetCachePassword() string {
-	return c.Password
+// GetNginxReloadInterval 获取 reloader 检查配置变化的间隔(秒)
+func (w *WebConfig) GetNginxReloadInterval() int32 {
+	if w.NginxConfigReload == nil || w.NginxConfigReload.IntervalSeconds <= 0 {
+		return 5
+	}
+	return w.NginxConfigReload.IntervalSeconds
 }
 
-// GetDatabaseConnectionString 获取数据库连接字符串(用于内部)
-func (d *DatabaseConfig) GetDatabaseConnectionString() string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=10s",
-		d.User, d.Password, d.Host, d.Port, d.Database)
+// IsNginxConfigTestEnabled 检查是否使用 nginx -t 校验自定义配置
+func (w *WebConfig) IsNginxConfigTestEnabled() bool {
+	return w.CustomNginxConfigMap != "" && w.NginxConfigTest != nil && w.NginxConfigTest.Enabled
 }
 
-// GetStorageEndpoint 获取存储端点(带协议)
-func (s *StorageConfig) GetStorageEndpoint() string {
-	if s.TLS != nil && s.TLS.Enabled {
-		return "https://" + s.Endpoint
+// GetNginxConfigTestTimeout 获取 nginx -t 校验 Job 的超时时间(秒)
+func (w *WebConfig) GetNginxConfigTestTimeout() int64 {
+	if w.NginxConfigTest == nil || w.NginxConfigTest.TimeoutSeconds <= 0 {
+		return 120
 	}
-	return "http://" + s.Endpoint
+	return int64(w.NginxConfigTest.TimeoutSeconds)
 }
 
-// GetCacheEndpoint 获取缓存端点
-func (c *CacheConfig) GetCacheEndpoint() string {
-	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+// GetNginxConfig 获取 Nginx 配置(未配置时返回空配置，各字段使用默认值)
+func (w *WebConfig) GetNginxConfig() *NginxConfig {
+	if w.Nginx == nil {
+		return &NginxConfig{}
+	}
+	return w.Nginx
 }
 
-// GetDatabaseEndpoint 获取数据库端点
-func (d *DatabaseConfig) GetDatabaseEndpoint() string {
-	return fmt.Sprintf("%s:%d", d.Host, d.Port)
+// GetWorkerProcesses 获取 worker 进程数
+func (n *NginxConfig) GetWorkerProcesses() string {
+	if n.WorkerProcesses == "" {
+		return "auto"
+	}
+	return n.WorkerProcesses
 }
 
-// GetWebReplicas 获取 Web 副本数
-func (w *WebConfig) GetWebReplicas() int32 {
-	if w.Replicas <= 0 {
-		return 3
+// GetWorkerConnections 获取每个 worker 的最大连接数
+func (n *NginxConfig) GetWorkerConnections() int32 {
+	if n.WorkerConnections <= 0 {
+		return 4096
 	}
-	return w.Replicas
+	return n.WorkerConnections
 }
 
-// IsMinIOProxyEnabled 检查 MinIO 代理是否启用
-func (k *KubeNova) IsMinIOProxyEnabled() bool {
-	return k.Spec.Web.MinIOProxy != nil && k.Spec.Web.MinIOProxy.Enabled
+// GetWorkerRlimitNofile 获取 worker 最大打开文件数
+func (n *NginxConfig) GetWorkerRlimitNofile() int32 {
+	if n.WorkerRlimitNofile <= 0 {
+		return 65535
+	}
+	return n.WorkerRlimitNofile
 }
 
-// GetMinIOProxyPath 获取 MinIO 代理路径
-func (k *KubeNova) GetMinIOProxyPath() string {
-	if k.Spec.Web.MinIOProxy == nil || k.Spec.Web.MinIOProxy.PathPrefix == "" {
-		return "/storage"
+// GetClientMaxBodySize 获取请求体大小上限
+func (n *NginxConfig) GetClientMaxBodySize() string {
+	if n.ClientMaxBodySize == "" {
+		return "1024m"
 	}
-	return k.Spec.Web.MinIOProxy.PathPrefix
+	return n.ClientMaxBodySize
 }
 
-// GetMinIOProxyEndpoint 获取用户配置的 MinIO 代理端点
-func (k *KubeNova) GetMinIOProxyEndpoint() string {
-	if k.Spec.Web.MinIOProxy != nil && k.Spec.Web.MinIOProxy.ProxyEndpoint != "" {
-		return k.Spec.Web.MinIOProxy.ProxyEndpoint
+// GetMaxConnsPerIP 获取单个客户端 IP 的最大并发连接数，0 表示不限制
+func (n *NginxConfig) GetMaxConnsPerIP() int32 {
+	return int32OrDefault(n.MaxConnsPerIP, 20)
+}
+
+// GetGeneralRate 获取全局限流速率
+func (n *NginxConfig) GetGeneralRate() string {
+	if n.RateLimit == nil || n.RateLimit.GeneralRate == "" {
+		return "100r/s"
 	}
-	return ""
+	return n.RateLimit.GeneralRate
 }
 
-// GetMinIOEndpointForBackend 获取后端服务使用的 MinIO 端点
-// - 未启用代理：minio-service:9000
-// - Ingress 模式：www.example.com/storage
-// - NodePort 模式：<NODE_IP>:<NODE_PORT>/storage
-func (k *KubeNova) GetMinIOEndpointForBackend() string {
-	// 如果未启用代理，直接返回实际 MinIO 地址
-	if !k.IsMinIOProxyEnabled() {
-		return k.Spec.Storage.Endpoint
+// GetGeneralBurst 获取全局限流突发请求数
+func (n *NginxConfig) GetGeneralBurst() int32 {
+	if n.RateLimit == nil {
+		return 200
 	}
+	return int32OrDefault(n.RateLimit.GeneralBurst, 200)
+}
 
-	// 如果用户手动配置了代理端点，使用用户配置
-	if k.Spec.Web.MinIOProxy.ProxyEndpoint != "" {
-		return k.Spec.Web.MinIOProxy.ProxyEndpoint
+// GetAPIRate 获取 API 限流速率
+func (n *NginxConfig) GetAPIRate() string {
+	if n.RateLimit == nil || n.RateLimit.APIRate == "" {
+		return "50r/s"
 	}
+	return n.RateLimit.APIRate
+}
 
-	// 自动推断代理端点
-	pathPrefix := k.GetMinIOProxyPath()
+// GetAPIBurst 获取 API 限流突发请求数
+func (n *NginxConfig) GetAPIBurst() int32 {
+	if n.RateLimit == nil {
+		return 20
+	}
+	return int32OrDefault(n.RateLimit.APIBurst, 20)
+}
 
-	if k.Spec.Web.ExposeType == "ingress" && k.Spec.Web.Ingress != nil {
-		// Ingress 模式：返回域名 + pathPrefix
-		// 例如：www.example.com/storage
-		host := k.Spec.Web.Ingress.Host
-		// 移除前导斜杠
-		if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
-			pathPrefix = pathPrefix[1:]
-		}
-		return fmt.Sprintf("%s/%s", host, pathPrefix)
+// GetWebSocketBurst 获取 WebSocket 限流突发请求数
+func (n *NginxConfig) GetWebSocketBurst() int32 {
+	if n.RateLimit == nil {
+		return 10
 	}
+	return int32OrDefault(n.RateLimit.WebSocketBurst, 10)
+}
 
-	// NodePort 模式：返回占位符，实际地址在 Status 中更新
-	// 例如：<NODE_IP>:<NODE_PORT>/storage
-	// 移除前导斜杠
-	if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
-		pathPrefix = pathPrefix[1:]
+// IsGzipEnabled 检查是否启用 Gzip 压缩(默认启用)
+func (n *NginxConfig) IsGzipEnabled() bool {
+	return n.Gzip == nil || n.Gzip.Enabled == nil || *n.Gzip.Enabled
+}
+
+// GetGzipCompLevel 获取 Gzip 压缩级别
+func (n *NginxConfig) GetGzipCompLevel() int32 {
+	if n.Gzip == nil || n.Gzip.CompLevel <= 0 {
+		return 6
 	}
-	return fmt.Sprintf("<NODE_IP>:<NODE_PORT>/%s", pathPrefix)
+	return n.Gzip.CompLevel
 }
 
-// GetMinIOEndpointForBackendWithNodeInfo 获取后端服务使用的 MinIO 端点(带 Node 信息)
-// 用于 controller 在运行时生成实际地址
-func (k *KubeNova) GetMinIOEndpointForBackendWithNodeInfo(nodeIP string, nodePort int32) string {
-	// 如果未启用代理，直接返回实际 MinIO 地址
-	if !k.IsMinIOProxyEnabled() {
-		return k.Spec.Storage.Endpoint
+// GetGzipMinLength 获取启用压缩的最小响应长度
+func (n *NginxConfig) GetGzipMinLength() int32 {
+	if n.Gzip == nil {
+		return 1000
 	}
+	return int32OrDefault(n.Gzip.MinLength, 1000)
+}
 
-	// 如果用户手动配置了代理端点，使用用户配置
-	if k.Spec.Web.MinIOProxy.ProxyEndpoint != "" {
-		return k.Spec.Web.MinIOProxy.ProxyEndpoint
+// GetKeepaliveTimeout 获取长连接超时时间
+func (n *NginxConfig) GetKeepaliveTimeout() string {
+	if n.Keepalive == nil || n.Keepalive.Timeout == "" {
+		return "65"
 	}
+	return n.Keepalive.Timeout
+}
 
-	// 自动推断代理端点
-	pathPrefix := k.GetMinIOProxyPath()
+// GetKeepaliveRequests 获取单个长连接最大请求数
+func (n *NginxConfig) GetKeepaliveRequests() int32 {
+	if n.Keepalive == nil || n.Keepalive.Requests <= 0 {
+		return 100
+	}
+	return n.Keepalive.Requests
+}
 
-	if k.Spec.Web.ExposeType == "ingress" && k.Spec.Web.Ingress != nil {
-		protocol := "http"
-		if k.Spec.Web.Ingress.TLS != nil && k.Spec.Web.Ingress.TLS.Enabled {
-			protocol = "https"
-		}
-		host := k.Spec.Web.Ingress.Host
-		if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
-			pathPrefix = pathPrefix[1:]
-		}
-		return fmt.Sprintf("%s://%s/%s", protocol, host, pathPrefix)
+// GetAccessLogFormat 获取访问日志格式
+func (n *NginxConfig) GetAccessLogFormat() string {
+	if n.AccessLogFormat == "" {
+		return "main"
 	}
+	return n.AccessLogFormat
+}
 
-	// NodePort 模式：使用实际的 Node IP 和 NodePort，并判断 HTTPS
-	protocol := "http"
-	if k.Spec.Web.NodePort != nil && k.Spec.Web.NodePort.HTTPS != nil && k.Spec.Web.NodePort.HTTPS.Enabled {
-		protocol = "https"
+// IsRealIPEnabled 检查是否配置了受信任代理
+func (n *NginxConfig) IsRealIPEnabled() bool {
+	return n.RealIP != nil && len(n.RealIP.TrustedProxies) > 0
+}
+
+// GetRealIPHeader 获取携带客户端 IP 的请求头
+func (n *NginxConfig) GetRealIPHeader() string {
+	if n.RealIP == nil || n.RealIP.Header == "" {
+		return "X-Forwarded-For"
 	}
-	if nodeIP == "" {
-		nodeIP = "<NODE_IP>"
+	return n.RealIP.Header
+}
+
+// IsRealIPRecursive 检查是否递归排除受信任代理地址(默认启用)
+func (n *NginxConfig) IsRealIPRecursive() bool {
+	return n.RealIP == nil || n.RealIP.Recursive == nil || *n.RealIP.Recursive
+}
+
+// GetNginxSnippets 获取 Nginx 自定义片段配置(未配置时返回空配置)
+func (n *NginxConfig) GetNginxSnippets() *NginxSnippetsConfig {
+	if n.Snippets == nil {
+		return &NginxSnippetsConfig{}
 	}
-	if nodePort == 0 {
-		if protocol == "https" {
-			nodePort = 30443 // HTTPS 默认值
-		} else {
-			nodePort = 30080 // HTTP 默认值
+	return n.Snippets
+}
+
+// GetLocationSnippet 获取内置 location 的片段，同一路径配置多次时按顺序拼接
+func (s *NginxSnippetsConfig) GetLocationSnippet(path string) string {
+	var snippets []string
+	for _, location := range s.Locations {
+		if location.Path == path {
+			snippets = append(snippets, location.Snippet)
 		}
 	}
-	if len(pathPrefix) > 0 && pathPrefix[0] == '/' {
-		pathPrefix = pathPrefix[1:]
+	return strings.Join(snippets, "\n")
+}
+
+// GetKeepalive 获取 upstream 保持的空闲长连接数
+func (u *NginxUpstream) GetKeepalive() int32 {
+	return int32OrDefault(u.Keepalive, 32)
+}
+
+// GetNginxLocationPaths 获取生成配置中的内置 location 路径
+func (k *KubeNova) GetNginxLocationPaths() []string {
+	paths := []string{"/ws/v1/pod", "/ws/v1/site-messages", "/portal", "/manager", "/workload", "/console", "/"}
+	if k.IsMinIOProxyEnabled() {
+		paths = append(paths, k.GetMinIOProxyPath())
 	}
-	return fmt.Sprintf("%s://%s:%d/%s", protocol, nodeIP, nodePort, pathPrefix)
+	return paths
+}
+
+// int32OrDefault 获取可选整数值，未设置时返回默认值
+func int32OrDefault(value *int32, defaultValue int32) int32 {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
 }
 
 // GetMaxOpenConns 获取最大打开连接数
@@ -936,3 +3299,206 @@ func (d *DatabaseConfig) GetConnMaxLifetime() string {
 	}
 	return d.ConnMaxLifetime
 }
+
+// IsMaintenanceEnabled 检查是否启用维护模式
+func (k *KubeNova) IsMaintenanceEnabled() bool {
+	return k.Spec.Maintenance != nil && k.Spec.Maintenance.Enabled
+}
+
+// IsReconcilePaused 检查是否暂停协调(paused 模式)
+func (k *KubeNova) IsReconcilePaused() bool {
+	return k.IsMaintenanceEnabled() && k.Spec.Maintenance.Mode == MaintenanceModePaused
+}
+
+// IsMaintenancePageEnabled 检查是否启用维护页面(maintenancePage 模式)
+// Mode 为空时按默认值 maintenancePage 处理
+func (k *KubeNova) IsMaintenancePageEnabled() bool {
+	if !k.IsMaintenanceEnabled() {
+		return false
+	}
+	return k.Spec.Maintenance.Mode == "" || k.Spec.Maintenance.Mode == MaintenanceModePage
+}
+
+// IsBackendScaledDown 检查维护页面模式下是否将后端服务缩容到 0
+func (k *KubeNova) IsBackendScaledDown() bool {
+	return k.IsMaintenancePageEnabled() && k.Spec.Maintenance.ScaleDownBackend
+}
+
+// GetMaintenanceMessage 获取维护页面提示信息
+func (k *KubeNova) GetMaintenanceMessage() string {
+	if k.Spec.Maintenance == nil || k.Spec.Maintenance.Message == "" {
+		return "系统维护中，请稍后访问"
+	}
+	return k.Spec.Maintenance.Message
+}
+
+// GetDeletionPolicy 获取删除策略
+func (k *KubeNova) GetDeletionPolicy() string {
+	if k.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return k.Spec.DeletionPolicy
+}
+
+// IsSecretBackupOnDeleteEnabled 检查删除前是否备份 kube-nova-secret
+func (k *KubeNova) IsSecretBackupOnDeleteEnabled() bool {
+	return k.Spec.DeletionOptions != nil && k.Spec.DeletionOptions.BackupSecret
+}
+
+// GetPodTerminationTimeout 获取等待 Pod 退出的超时时间
+func (k *KubeNova) GetPodTerminationTimeout() time.Duration {
+	if k.Spec.DeletionOptions != nil && k.Spec.DeletionOptions.PodTerminationTimeout != "" {
+		if d, err := time.ParseDuration(k.Spec.DeletionOptions.PodTerminationTimeout); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 5 * time.Minute
+}
+
+// GetTargetNamespace 获取部署目标命名空间
+func (k *KubeNova) GetTargetNamespace() string {
+	if k.Spec.TargetNamespace != "" {
+		return k.Spec.TargetNamespace
+	}
+	return k.Namespace
+}
+
+// IsCrossNamespace 检查是否部署到其他命名空间
+func (k *KubeNova) IsCrossNamespace() bool {
+	return k.GetTargetNamespace() != k.Namespace
+}
+
+// ShouldCreateTargetNamespace 检查是否自动创建目标命名空间
+func (k *KubeNova) ShouldCreateTargetNamespace() bool {
+	return k.Spec.TargetNamespaceOptions != nil && k.Spec.TargetNamespaceOptions.Create
+}
+
+// 告警接收配置的生成方式
+const (
+	// AlertingModeAlertmanagerConfig 生成 AlertmanagerConfig
+	AlertingModeAlertmanagerConfig = "alertmanagerConfig"
+	// AlertingModeSecret 生成 receiver 配置片段 Secret
+	AlertingModeSecret = "secret"
+)
+
+// IsAlertingEnabled 检查是否启用告警接入
+func (k *KubeNova) IsAlertingEnabled() bool {
+	return k.Spec.Alerting != nil && k.Spec.Alerting.Enabled
+}
+
+// GetAlertingMode 获取告警接收配置的生成方式
+func (a *AlertingConfig) GetAlertingMode() string {
+	if a.Mode == "" {
+		return AlertingModeAlertmanagerConfig
+	}
+	return a.Mode
+}
+
+// GetWebhookPath 获取 manager-api 接收告警的路径
+func (a *AlertingConfig) GetWebhookPath() string {
+	if a.WebhookPath == "" {
+		return "/manager/v1/alertmanager/webhook"
+	}
+	return a.WebhookPath
+}
+
+// GetReceiverName 获取 receiver 名称
+func (a *AlertingConfig) GetReceiverName() string {
+	if a.ReceiverName == "" {
+		return "kube-nova"
+	}
+	return a.ReceiverName
+}
+
+// IsSendResolved 检查是否发送告警恢复通知
+func (a *AlertingConfig) IsSendResolved() bool {
+	return a.SendResolved == nil || *a.SendResolved
+}
+
+// GetMatchType 获取匹配方式
+func (m *AlertMatcher) GetMatchType() string {
+	if m.MatchType == "" {
+		return "="
+	}
+	return m.MatchType
+}
+
+// IsInternalTLSEnabled 检查是否启用内部 TLS
+func (k *KubeNova) IsInternalTLSEnabled() bool {
+	return k.Spec.InternalTLS != nil && k.Spec.InternalTLS.Enabled
+}
+
+// GetInternalCAValidity 获取内部 CA 证书有效期
+func (k *KubeNova) GetInternalCAValidity() time.Duration {
+	if k.Spec.InternalTLS != nil {
+		return parseDurationOrDefault(k.Spec.InternalTLS.CAValidity, 10*365*24*time.Hour)
+	}
+	return 10 * 365 * 24 * time.Hour
+}
+
+// GetInternalCertValidity 获取内部服务证书有效期
+func (k *KubeNova) GetInternalCertValidity() time.Duration {
+	if k.Spec.InternalTLS != nil {
+		return parseDurationOrDefault(k.Spec.InternalTLS.CertValidity, 365*24*time.Hour)
+	}
+	return 365 * 24 * time.Hour
+}
+
+// IsJWTAccessSecretGenerated 检查访问令牌密钥是否由 Operator 自动生成
+func (k *KubeNova) IsJWTAccessSecretGenerated() bool {
+	return k.Spec.Services.JWT.AccessSecret == ""
+}
+
+// IsJWTRefreshSecretGenerated 检查刷新令牌密钥是否由 Operator 自动生成
+func (k *KubeNova) IsJWTRefreshSecretGenerated() bool {
+	return k.Spec.Services.JWT.RefreshSecret == ""
+}
+
+// IsJWTSecretGenerated 检查是否有 JWT 密钥由 Operator 自动生成
+func (k *KubeNova) IsJWTSecretGenerated() bool {
+	return k.IsJWTAccessSecretGenerated() || k.IsJWTRefreshSecretGenerated()
+}
+
+// IsJWTRotationEnabled 检查是否启用 JWT 密钥轮换
+func (k *KubeNova) IsJWTRotationEnabled() bool {
+	rotation := k.Spec.Services.JWT.Rotation
+	return rotation != nil && rotation.Enabled && k.IsJWTAccessSecretGenerated() && k.IsJWTRefreshSecretGenerated()
+}
+
+// GetJWTRotationInterval 获取 JWT 密钥轮换周期
+func (k *KubeNova) GetJWTRotationInterval() time.Duration {
+	if rotation := k.Spec.Services.JWT.Rotation; rotation != nil {
+		return parseDurationOrDefault(rotation.Interval, 30*24*time.Hour)
+	}
+	return 30 * 24 * time.Hour
+}
+
+// GetJWTRotationGracePeriod 获取旧 JWT 密钥的保留时间
+func (k *KubeNova) GetJWTRotationGracePeriod() time.Duration {
+	refreshExpire := time.Duration(k.Spec.Services.JWT.RefreshExpire) * time.Second
+	if refreshExpire <= 0 {
+		refreshExpire = 7 * 24 * time.Hour
+	}
+	if rotation := k.Spec.Services.JWT.Rotation; rotation != nil {
+		return parseDurationOrDefault(rotation.GracePeriod, refreshExpire)
+	}
+	return refreshExpire
+}
+
+// GetInternalCertRenewBefore 获取内部证书到期前的轮换时间
+func (k *KubeNova) GetInternalCertRenewBefore() time.Duration {
+	if k.Spec.InternalTLS != nil {
+		return parseDurationOrDefault(k.Spec.InternalTLS.RenewBefore, 30*24*time.Hour)
+	}
+	return 30 * 24 * time.Hour
+}
+
+// parseDurationOrDefault 解析时长，为空或无效时返回默认值
+func parseDurationOrDefault(value string, defaultValue time.Duration) time.Duration {
+	if value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultValue
+}
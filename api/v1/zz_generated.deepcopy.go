@@ -26,6 +26,43 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlConfig) DeepCopyInto(out *AccessControlConfig) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]PathAccessRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ExternalAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlConfig.
+func (in *AccessControlConfig) DeepCopy() *AccessControlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessInfo) DeepCopyInto(out *AccessInfo) {
 	*out = *in
@@ -48,9 +85,111 @@ func (in *AccessInfo) DeepCopy() *AccessInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertMatcher) DeepCopyInto(out *AlertMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertMatcher.
+func (in *AlertMatcher) DeepCopy() *AlertMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingConfig) DeepCopyInto(out *AlertingConfig) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(AlertingRouteConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingConfig.
+func (in *AlertingConfig) DeepCopy() *AlertingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingRouteConfig) DeepCopyInto(out *AlertingRouteConfig) {
+	*out = *in
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]AlertMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupBy != nil {
+		in, out := &in.GroupBy, &out.GroupBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingRouteConfig.
+func (in *AlertingRouteConfig) DeepCopy() *AlertingRouteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingRouteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CORSConfig) DeepCopyInto(out *CORSConfig) {
+	*out = *in
+	if in.AllowedOrigins != nil {
+		in, out := &in.AllowedOrigins, &out.AllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORSConfig.
+func (in *CORSConfig) DeepCopy() *CORSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CORSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CacheConfig) DeepCopyInto(out *CacheConfig) {
 	*out = *in
+	if in.Instance != nil {
+		in, out := &in.Instance, &out.Instance
+		*out = new(ManagedInstanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheConfig.
@@ -63,6 +202,45 @@ func (in *CacheConfig) DeepCopy() *CacheConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertIssuerRef) DeepCopyInto(out *CertIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertIssuerRef.
+func (in *CertIssuerRef) DeepCopy() *CertIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	if in.SANs != nil {
+		in, out := &in.SANs, &out.SANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 	*out = *in
@@ -135,9 +313,39 @@ func (in *ComponentStatusMap) DeepCopy() *ComponentStatusMap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentVolumesConfig) DeepCopyInto(out *ComponentVolumesConfig) {
+	*out = *in
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(VolumeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(VolumeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVolumesConfig.
+func (in *ComponentVolumesConfig) DeepCopy() *ComponentVolumesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentVolumesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseConfig) DeepCopyInto(out *DatabaseConfig) {
 	*out = *in
+	if in.Instance != nil {
+		in, out := &in.Instance, &out.Instance
+		*out = new(ManagedInstanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConfig.
@@ -151,32 +359,155 @@ func (in *DatabaseConfig) DeepCopy() *DatabaseConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageRegistryConfig) DeepCopyInto(out *ImageRegistryConfig) {
+func (in *DeletionOptions) DeepCopyInto(out *DeletionOptions) {
 	*out = *in
-	if in.PullSecrets != nil {
-		in, out := &in.PullSecrets, &out.PullSecrets
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionOptions.
+func (in *DeletionOptions) DeepCopy() *DeletionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DemoConfig) DeepCopyInto(out *DemoConfig) {
+	*out = *in
+	in.Seed.DeepCopyInto(&out.Seed)
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DemoConfig.
+func (in *DemoConfig) DeepCopy() *DemoConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DemoConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DemoSeedConfig) DeepCopyInto(out *DemoSeedConfig) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(DemoSeedConfigMapRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DemoSeedConfig.
+func (in *DemoSeedConfig) DeepCopy() *DemoSeedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DemoSeedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DemoSeedConfigMapRef) DeepCopyInto(out *DemoSeedConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DemoSeedConfigMapRef.
+func (in *DemoSeedConfigMapRef) DeepCopy() *DemoSeedConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DemoSeedConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DemoStatus) DeepCopyInto(out *DemoStatus) {
+	*out = *in
+	if in.LastResetTime != nil {
+		in, out := &in.LastResetTime, &out.LastResetTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DemoStatus.
+func (in *DemoStatus) DeepCopy() *DemoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DemoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAuthConfig) DeepCopyInto(out *ExternalAuthConfig) {
+	*out = *in
+	if in.OAuth2Proxy != nil {
+		in, out := &in.OAuth2Proxy, &out.OAuth2Proxy
+		*out = new(OAuth2ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePaths != nil {
+		in, out := &in.ExcludePaths, &out.ExcludePaths
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryConfig.
-func (in *ImageRegistryConfig) DeepCopy() *ImageRegistryConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAuthConfig.
+func (in *ExternalAuthConfig) DeepCopy() *ExternalAuthConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageRegistryConfig)
+	out := new(ExternalAuthConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
+func (in *GatewayConfig) DeepCopyInto(out *GatewayConfig) {
 	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Listener != nil {
+		in, out := &in.Listener, &out.Listener
+		*out = new(GatewayListenerConfig)
+		**out = **in
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
-		*out = new(IngressTLSConfig)
-		**out = **in
+		*out = new(GatewayTLSConfig)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
@@ -187,71 +518,280 @@ func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConfig.
-func (in *IngressConfig) DeepCopy() *IngressConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfig.
+func (in *GatewayConfig) DeepCopy() *GatewayConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressConfig)
+	out := new(GatewayConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressTLSConfig) DeepCopyInto(out *IngressTLSConfig) {
+func (in *GatewayListenerConfig) DeepCopyInto(out *GatewayListenerConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTLSConfig.
-func (in *IngressTLSConfig) DeepCopy() *IngressTLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayListenerConfig.
+func (in *GatewayListenerConfig) DeepCopy() *GatewayListenerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressTLSConfig)
+	out := new(GatewayListenerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JWTConfig) DeepCopyInto(out *JWTConfig) {
+func (in *GatewayParentRef) DeepCopyInto(out *GatewayParentRef) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTConfig.
-func (in *JWTConfig) DeepCopy() *JWTConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentRef.
+func (in *GatewayParentRef) DeepCopy() *GatewayParentRef {
 	if in == nil {
 		return nil
 	}
-	out := new(JWTConfig)
+	out := new(GatewayParentRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeNova) DeepCopyInto(out *KubeNova) {
+func (in *GatewayTLSConfig) DeepCopyInto(out *GatewayTLSConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.CertificateRefs != nil {
+		in, out := &in.CertificateRefs, &out.CertificateRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeNova.
-func (in *KubeNova) DeepCopy() *KubeNova {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSConfig.
+func (in *GatewayTLSConfig) DeepCopy() *GatewayTLSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeNova)
+	out := new(GatewayTLSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeNova) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HSTSConfig) DeepCopyInto(out *HSTSConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IncludeSubDomains != nil {
+		in, out := &in.IncludeSubDomains, &out.IncludeSubDomains
+		*out = new(bool)
+		**out = **in
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HSTSConfig.
+func (in *HSTSConfig) DeepCopy() *HSTSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HSTSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryConfig) DeepCopyInto(out *ImageRegistryConfig) {
+	*out = *in
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryConfig.
+func (in *ImageRegistryConfig) DeepCopy() *ImageRegistryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(IngressTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConfig.
+func (in *IngressConfig) DeepCopy() *IngressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressTLSConfig) DeepCopyInto(out *IngressTLSConfig) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertIssuerRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTLSConfig.
+func (in *IngressTLSConfig) DeepCopy() *IngressTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalTLSConfig) DeepCopyInto(out *InternalTLSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalTLSConfig.
+func (in *InternalTLSConfig) DeepCopy() *InternalTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTConfig) DeepCopyInto(out *JWTConfig) {
+	*out = *in
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(JWTRotationConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTConfig.
+func (in *JWTConfig) DeepCopy() *JWTConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTRotationConfig) DeepCopyInto(out *JWTRotationConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTRotationConfig.
+func (in *JWTRotationConfig) DeepCopy() *JWTRotationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTRotationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTStatus) DeepCopyInto(out *JWTStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousSecretExpireTime != nil {
+		in, out := &in.PreviousSecretExpireTime, &out.PreviousSecretExpireTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTStatus.
+func (in *JWTStatus) DeepCopy() *JWTStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeNova) DeepCopyInto(out *KubeNova) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeNova.
+func (in *KubeNova) DeepCopy() *KubeNova {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeNova)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeNova) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -273,154 +813,785 @@ func (in *KubeNovaList) DeepCopy() *KubeNovaList {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeNovaList)
+	out := new(KubeNovaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeNovaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeNovaSpec) DeepCopyInto(out *KubeNovaSpec) {
+	*out = *in
+	if in.ImageRegistry != nil {
+		in, out := &in.ImageRegistry, &out.ImageRegistry
+		*out = new(ImageRegistryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Database.DeepCopyInto(&out.Database)
+	in.Cache.DeepCopyInto(&out.Cache)
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Telemetry != nil {
+		in, out := &in.Telemetry, &out.Telemetry
+		*out = new(TelemetryConfig)
+		**out = **in
+	}
+	in.Services.DeepCopyInto(&out.Services)
+	in.Web.DeepCopyInto(&out.Web)
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceConfig)
+		**out = **in
+	}
+	if in.DeletionOptions != nil {
+		in, out := &in.DeletionOptions, &out.DeletionOptions
+		*out = new(DeletionOptions)
+		**out = **in
+	}
+	if in.TargetNamespaceOptions != nil {
+		in, out := &in.TargetNamespaceOptions, &out.TargetNamespaceOptions
+		*out = new(TargetNamespaceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InternalTLS != nil {
+		in, out := &in.InternalTLS, &out.InternalTLS
+		*out = new(InternalTLSConfig)
+		**out = **in
+	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(AlertingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeNovaSpec.
+func (in *KubeNovaSpec) DeepCopy() *KubeNovaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeNovaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeNovaStatus) DeepCopyInto(out *KubeNovaStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ComponentStatus.DeepCopyInto(&out.ComponentStatus)
+	if in.AccessInfo != nil {
+		in, out := &in.AccessInfo, &out.AccessInfo
+		*out = new(AccessInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagedDependencies != nil {
+		in, out := &in.ManagedDependencies, &out.ManagedDependencies
+		*out = make([]ManagedDependencyStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Demo != nil {
+		in, out := &in.Demo, &out.Demo
+		*out = new(DemoStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeNovaStatus.
+func (in *KubeNovaStatus) DeepCopy() *KubeNovaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeNovaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleConfig) DeepCopyInto(out *LifecycleConfig) {
+	*out = *in
+	if in.PreStopSleepSeconds != nil {
+		in, out := &in.PreStopSleepSeconds, &out.PreStopSleepSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleConfig.
+func (in *LifecycleConfig) DeepCopy() *LifecycleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerConfig) DeepCopyInto(out *LoadBalancerConfig) {
+	*out = *in
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerConfig.
+func (in *LoadBalancerConfig) DeepCopy() *LoadBalancerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceConfig) DeepCopyInto(out *MaintenanceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceConfig.
+func (in *MaintenanceConfig) DeepCopy() *MaintenanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedDependencyStatus) DeepCopyInto(out *ManagedDependencyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDependencyStatus.
+func (in *ManagedDependencyStatus) DeepCopy() *ManagedDependencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedDependencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedInstanceConfig) DeepCopyInto(out *ManagedInstanceConfig) {
+	*out = *in
+	if in.StorageSize != nil {
+		in, out := &in.StorageSize, &out.StorageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedInstanceConfig.
+func (in *ManagedInstanceConfig) DeepCopy() *ManagedInstanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedInstanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MinIOProxyConfig) DeepCopyInto(out *MinIOProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinIOProxyConfig.
+func (in *MinIOProxyConfig) DeepCopy() *MinIOProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MinIOProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MinIOTLSConfig) DeepCopyInto(out *MinIOTLSConfig) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertIssuerRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinIOTLSConfig.
+func (in *MinIOTLSConfig) DeepCopy() *MinIOTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MinIOTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxConfig) DeepCopyInto(out *NginxConfig) {
+	*out = *in
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(NginxRateLimitConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConnsPerIP != nil {
+		in, out := &in.MaxConnsPerIP, &out.MaxConnsPerIP
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Gzip != nil {
+		in, out := &in.Gzip, &out.Gzip
+		*out = new(NginxGzipConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keepalive != nil {
+		in, out := &in.Keepalive, &out.Keepalive
+		*out = new(NginxKeepaliveConfig)
+		**out = **in
+	}
+	if in.RealIP != nil {
+		in, out := &in.RealIP, &out.RealIP
+		*out = new(NginxRealIPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Snippets != nil {
+		in, out := &in.Snippets, &out.Snippets
+		*out = new(NginxSnippetsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxConfig.
+func (in *NginxConfig) DeepCopy() *NginxConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxConfigReloadConfig) DeepCopyInto(out *NginxConfigReloadConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxConfigReloadConfig.
+func (in *NginxConfigReloadConfig) DeepCopy() *NginxConfigReloadConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxConfigReloadConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxConfigTestConfig) DeepCopyInto(out *NginxConfigTestConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxConfigTestConfig.
+func (in *NginxConfigTestConfig) DeepCopy() *NginxConfigTestConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxConfigTestConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxExtraLocation) DeepCopyInto(out *NginxExtraLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxExtraLocation.
+func (in *NginxExtraLocation) DeepCopy() *NginxExtraLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxExtraLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxGzipConfig) DeepCopyInto(out *NginxGzipConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinLength != nil {
+		in, out := &in.MinLength, &out.MinLength
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxGzipConfig.
+func (in *NginxGzipConfig) DeepCopy() *NginxGzipConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxGzipConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxKeepaliveConfig) DeepCopyInto(out *NginxKeepaliveConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxKeepaliveConfig.
+func (in *NginxKeepaliveConfig) DeepCopy() *NginxKeepaliveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxKeepaliveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxLocationSnippet) DeepCopyInto(out *NginxLocationSnippet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxLocationSnippet.
+func (in *NginxLocationSnippet) DeepCopy() *NginxLocationSnippet {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxLocationSnippet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxRateLimitConfig) DeepCopyInto(out *NginxRateLimitConfig) {
+	*out = *in
+	if in.GeneralBurst != nil {
+		in, out := &in.GeneralBurst, &out.GeneralBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.APIBurst != nil {
+		in, out := &in.APIBurst, &out.APIBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WebSocketBurst != nil {
+		in, out := &in.WebSocketBurst, &out.WebSocketBurst
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxRateLimitConfig.
+func (in *NginxRateLimitConfig) DeepCopy() *NginxRateLimitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxRateLimitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxRealIPConfig) DeepCopyInto(out *NginxRealIPConfig) {
+	*out = *in
+	if in.TrustedProxies != nil {
+		in, out := &in.TrustedProxies, &out.TrustedProxies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Recursive != nil {
+		in, out := &in.Recursive, &out.Recursive
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxRealIPConfig.
+func (in *NginxRealIPConfig) DeepCopy() *NginxRealIPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxRealIPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxSnippetsConfig) DeepCopyInto(out *NginxSnippetsConfig) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]NginxLocationSnippet, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upstreams != nil {
+		in, out := &in.Upstreams, &out.Upstreams
+		*out = make([]NginxUpstream, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraLocations != nil {
+		in, out := &in.ExtraLocations, &out.ExtraLocations
+		*out = make([]NginxExtraLocation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxSnippetsConfig.
+func (in *NginxSnippetsConfig) DeepCopy() *NginxSnippetsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxSnippetsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxUpstream) DeepCopyInto(out *NginxUpstream) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Keepalive != nil {
+		in, out := &in.Keepalive, &out.Keepalive
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxUpstream.
+func (in *NginxUpstream) DeepCopy() *NginxUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(NginxUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePortConfig) DeepCopyInto(out *NodePortConfig) {
+	*out = *in
+	if in.HTTPS != nil {
+		in, out := &in.HTTPS, &out.HTTPS
+		*out = new(NodePortHTTPSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePortConfig.
+func (in *NodePortConfig) DeepCopy() *NodePortConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePortConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePortHTTPSConfig) DeepCopyInto(out *NodePortHTTPSConfig) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertIssuerRef)
+		**out = **in
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePortHTTPSConfig.
+func (in *NodePortHTTPSConfig) DeepCopy() *NodePortHTTPSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePortHTTPSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeNovaList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeNovaSpec) DeepCopyInto(out *KubeNovaSpec) {
+func (in *OAuth2ProxyConfig) DeepCopyInto(out *OAuth2ProxyConfig) {
 	*out = *in
-	if in.ImageRegistry != nil {
-		in, out := &in.ImageRegistry, &out.ImageRegistry
-		*out = new(ImageRegistryConfig)
-		(*in).DeepCopyInto(*out)
+	if in.EmailDomains != nil {
+		in, out := &in.EmailDomains, &out.EmailDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out.Database = in.Database
-	out.Cache = in.Cache
-	in.Storage.DeepCopyInto(&out.Storage)
-	if in.Telemetry != nil {
-		in, out := &in.Telemetry, &out.Telemetry
-		*out = new(TelemetryConfig)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
 		**out = **in
 	}
-	in.Services.DeepCopyInto(&out.Services)
-	in.Web.DeepCopyInto(&out.Web)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeNovaSpec.
-func (in *KubeNovaSpec) DeepCopy() *KubeNovaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ProxyConfig.
+func (in *OAuth2ProxyConfig) DeepCopy() *OAuth2ProxyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeNovaSpec)
+	out := new(OAuth2ProxyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeNovaStatus) DeepCopyInto(out *KubeNovaStatus) {
+func (in *PathAccessRule) DeepCopyInto(out *PathAccessRule) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.ComponentStatus.DeepCopyInto(&out.ComponentStatus)
-	if in.AccessInfo != nil {
-		in, out := &in.AccessInfo, &out.AccessInfo
-		*out = new(AccessInfo)
-		(*in).DeepCopyInto(*out)
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeNovaStatus.
-func (in *KubeNovaStatus) DeepCopy() *KubeNovaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PathAccessRule.
+func (in *PathAccessRule) DeepCopy() *PathAccessRule {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeNovaStatus)
+	out := new(PathAccessRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MinIOProxyConfig) DeepCopyInto(out *MinIOProxyConfig) {
+func (in *PortalConfig) DeepCopyInto(out *PortalConfig) {
 	*out = *in
+	if in.Demo != nil {
+		in, out := &in.Demo, &out.Demo
+		*out = new(DemoConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinIOProxyConfig.
-func (in *MinIOProxyConfig) DeepCopy() *MinIOProxyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfig.
+func (in *PortalConfig) DeepCopy() *PortalConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(MinIOProxyConfig)
+	out := new(PortalConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MinIOTLSConfig) DeepCopyInto(out *MinIOTLSConfig) {
+func (in *ProbeOverride) DeepCopyInto(out *ProbeOverride) {
 	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinIOTLSConfig.
-func (in *MinIOTLSConfig) DeepCopy() *MinIOTLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeOverride.
+func (in *ProbeOverride) DeepCopy() *ProbeOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(MinIOTLSConfig)
+	out := new(ProbeOverride)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePortConfig) DeepCopyInto(out *NodePortConfig) {
+func (in *ProbesConfig) DeepCopyInto(out *ProbesConfig) {
 	*out = *in
-	if in.HTTPS != nil {
-		in, out := &in.HTTPS, &out.HTTPS
-		*out = new(NodePortHTTPSConfig)
-		**out = **in
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(ProbeOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(ProbeOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ProbeOverride)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePortConfig.
-func (in *NodePortConfig) DeepCopy() *NodePortConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesConfig.
+func (in *ProbesConfig) DeepCopy() *ProbesConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePortConfig)
+	out := new(ProbesConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePortHTTPSConfig) DeepCopyInto(out *NodePortHTTPSConfig) {
+func (in *RPCClientConfig) DeepCopyInto(out *RPCClientConfig) {
 	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NonBlock != nil {
+		in, out := &in.NonBlock, &out.NonBlock
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePortHTTPSConfig.
-func (in *NodePortHTTPSConfig) DeepCopy() *NodePortHTTPSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RPCClientConfig.
+func (in *RPCClientConfig) DeepCopy() *RPCClientConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePortHTTPSConfig)
+	out := new(RPCClientConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortalConfig) DeepCopyInto(out *PortalConfig) {
+func (in *SecurityHeadersConfig) DeepCopyInto(out *SecurityHeadersConfig) {
 	*out = *in
+	if in.FrameAncestors != nil {
+		in, out := &in.FrameAncestors, &out.FrameAncestors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.XSSProtection != nil {
+		in, out := &in.XSSProtection, &out.XSSProtection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HSTS != nil {
+		in, out := &in.HSTS, &out.HSTS
+		*out = new(HSTSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(CORSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomHeaders != nil {
+		in, out := &in.CustomHeaders, &out.CustomHeaders
+		*out = make([]HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalConfig.
-func (in *PortalConfig) DeepCopy() *PortalConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityHeadersConfig.
+func (in *SecurityHeadersConfig) DeepCopy() *SecurityHeadersConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PortalConfig)
+	out := new(SecurityHeadersConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -445,6 +1616,82 @@ func (in *ServiceConfig) DeepCopyInto(out *ServiceConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RPCClients != nil {
+		in, out := &in.RPCClients, &out.RPCClients
+		*out = make([]RPCClientConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GRPCHealthProbe != nil {
+		in, out := &in.GRPCHealthProbe, &out.GRPCHealthProbe
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(ProbesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(LifecycleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraContainers != nil {
+		in, out := &in.ExtraContainers, &out.ExtraContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = new(ComponentVolumesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceConfig.
@@ -460,11 +1707,11 @@ func (in *ServiceConfig) DeepCopy() *ServiceConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServicesConfig) DeepCopyInto(out *ServicesConfig) {
 	*out = *in
-	out.JWT = in.JWT
+	in.JWT.DeepCopyInto(&out.JWT)
 	if in.Portal != nil {
 		in, out := &in.Portal, &out.Portal
 		*out = new(PortalConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PortalAPI != nil {
 		in, out := &in.PortalAPI, &out.PortalAPI
@@ -516,10 +1763,15 @@ func (in *ServicesConfig) DeepCopy() *ServicesConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
 	*out = *in
+	if in.Instance != nil {
+		in, out := &in.Instance, &out.Instance
+		*out = new(ManagedInstanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(MinIOTLSConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -533,6 +1785,35 @@ func (in *StorageConfig) DeepCopy() *StorageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetNamespaceOptions) DeepCopyInto(out *TargetNamespaceOptions) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetNamespaceOptions.
+func (in *TargetNamespaceOptions) DeepCopy() *TargetNamespaceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetNamespaceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TelemetryConfig) DeepCopyInto(out *TelemetryConfig) {
 	*out = *in
@@ -548,6 +1829,31 @@ func (in *TelemetryConfig) DeepCopy() *TelemetryConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeConfig) DeepCopyInto(out *VolumeConfig) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeConfig.
+func (in *VolumeConfig) DeepCopy() *VolumeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebConfig) DeepCopyInto(out *WebConfig) {
 	*out = *in
@@ -561,6 +1867,16 @@ func (in *WebConfig) DeepCopyInto(out *WebConfig) {
 		*out = new(IngressConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(LoadBalancerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodePort != nil {
 		in, out := &in.NodePort, &out.NodePort
 		*out = new(NodePortConfig)
@@ -571,6 +1887,95 @@ func (in *WebConfig) DeepCopyInto(out *WebConfig) {
 		*out = new(MinIOProxyConfig)
 		**out = **in
 	}
+	if in.Nginx != nil {
+		in, out := &in.Nginx, &out.Nginx
+		*out = new(NginxConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NginxConfigTest != nil {
+		in, out := &in.NginxConfigTest, &out.NginxConfigTest
+		*out = new(NginxConfigTestConfig)
+		**out = **in
+	}
+	if in.NginxConfigReload != nil {
+		in, out := &in.NginxConfigReload, &out.NginxConfigReload
+		*out = new(NginxConfigReloadConfig)
+		**out = **in
+	}
+	if in.SecurityHeaders != nil {
+		in, out := &in.SecurityHeaders, &out.SecurityHeaders
+		*out = new(SecurityHeadersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessControl != nil {
+		in, out := &in.AccessControl, &out.AccessControl
+		*out = new(AccessControlConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(ProbesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(LifecycleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraContainers != nil {
+		in, out := &in.ExtraContainers, &out.ExtraContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = new(ComponentVolumesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebConfig.
@@ -0,0 +1,382 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// OAuth2ProxyName deployment 模式下 oauth2-proxy 的 Deployment 和 Service 名称
+	OAuth2ProxyName = "kube-nova-oauth2-proxy"
+
+	// oauth2ProxyPort oauth2-proxy 监听端口
+	oauth2ProxyPort = 4180
+
+	// authSignInLocation 认证失败时跳转登录的命名 location
+	authSignInLocation = "@kubenova_auth_signin"
+
+	// IngressAllowListAnnotation Ingress IP 白名单注解
+	IngressAllowListAnnotation = "nginx.ingress.kubernetes.io/whitelist-source-range"
+
+	// IngressDenyListAnnotation Ingress IP 黑名单注解
+	IngressDenyListAnnotation = "nginx.ingress.kubernetes.io/denylist-source-range"
+)
+
+// accessRule 一组 allow/deny 规则
+type accessRule struct {
+	allow []string
+	deny  []string
+}
+
+// buildAccessRules 构建 allow/deny 规则，Nginx 按顺序匹配第一条命中的规则
+// 每组规则内 deny 优先于 allow，前面的规则组优先；任意一组配置了 allow 时最后拒绝其他地址
+func buildAccessRules(indent int, rules ...accessRule) string {
+	pad := strings.Repeat(" ", indent)
+
+	var b strings.Builder
+	b.WriteString("\n" + pad + "# Access control\n")
+	hasAllow := false
+	for _, rule := range rules {
+		for _, cidr := range rule.deny {
+			fmt.Fprintf(&b, "%sdeny %s;\n", pad, cidr)
+		}
+		for _, cidr := range rule.allow {
+			fmt.Fprintf(&b, "%sallow %s;\n", pad, cidr)
+		}
+		hasAllow = hasAllow || len(rule.allow) > 0
+	}
+	if hasAllow {
+		b.WriteString(pad + "deny all;\n")
+	}
+	return b.String()
+}
+
+// buildOpenAccess 构建不受访问控制和外部认证限制的指令
+// 用于探针、错误页和维护页，避免 server 级别的 deny all 或 auth_request 拦截
+func buildOpenAccess(kn *kubenovav1.KubeNova, indent int) string {
+	pad := strings.Repeat(" ", indent)
+
+	var config string
+	if kn.Spec.Web.GetAccessControl().HasRules() && kn.IsClientIPVisibleToNginx() {
+		config += pad + "allow all;\n"
+	}
+	if isExternalAuthActive(kn) {
+		config += pad + "auth_request off;\n"
+	}
+	return config
+}
+
+// isExternalAuthActive 检查是否渲染外部认证(维护模式下所有路径直接返回 503，不需要认证)
+func isExternalAuthActive(kn *kubenovav1.KubeNova) bool {
+	return kn.Spec.Web.IsExternalAuthEnabled() && !kn.IsMaintenancePageEnabled()
+}
+
+// buildServerAccessControl 构建 server 级别的访问控制和外部认证指令
+func buildServerAccessControl(kn *kubenovav1.KubeNova) string {
+	access := kn.Spec.Web.GetAccessControl()
+
+	var config string
+	if access.HasRules() && kn.IsClientIPVisibleToNginx() {
+		config += buildAccessRules(4, accessRule{allow: access.Allow, deny: access.Deny})
+	}
+
+	if isExternalAuthActive(kn) {
+		config += `
+    # External authentication
+    auth_request /oauth2/auth;
+`
+		for _, header := range access.Auth.GetAuthResponseHeaders() {
+			config += fmt.Sprintf("    auth_request_set %s $upstream_http_%s;\n",
+				authHeaderVariable(header), headerVariableSuffix(header))
+		}
+		config += fmt.Sprintf("    error_page 401 = %s;\n", authSignInLocation)
+	}
+	return config
+}
+
+// buildAuthLocations 构建外部认证使用的 location 块
+func buildAuthLocations(kn *kubenovav1.KubeNova) string {
+	if !isExternalAuthActive(kn) {
+		return ""
+	}
+	authURL := getAuthProxyURL(kn)
+
+	return fmt.Sprintf(`
+    # External authentication subrequest
+    location = /oauth2/auth {
+        internal;
+        auth_request off;
+        proxy_pass %[1]s;
+        proxy_pass_request_body off;
+        proxy_set_header Content-Length "";
+        proxy_set_header Host $host;
+        proxy_set_header X-Real-IP $remote_addr;
+        proxy_set_header X-Forwarded-Proto $scheme;
+        proxy_set_header X-Forwarded-Host $host;
+        proxy_set_header X-Forwarded-Uri $request_uri;
+    }
+
+    # External authentication endpoints (sign in, callback, sign out)
+    location /oauth2/ {
+        auth_request off;
+        proxy_pass %[1]s;
+        proxy_set_header Host $host;
+        proxy_set_header X-Real-IP $remote_addr;
+        proxy_set_header X-Forwarded-Proto $scheme;
+        proxy_set_header X-Forwarded-Host $host;
+        proxy_set_header X-Auth-Request-Redirect $request_uri;
+    }
+
+    # Redirect unauthenticated requests to sign in
+    location %[2]s {
+        return 302 /oauth2/start?rd=$scheme://$host$request_uri;
+    }
+`, authURL, authSignInLocation)
+}
+
+// buildLocationAccessControl 构建内置 location 的访问控制和认证指令
+// location 中出现 allow/deny 时不再继承 server 级别的规则，因此路径规则之后需要重复全局规则
+func buildLocationAccessControl(kn *kubenovav1.KubeNova, path string) string {
+	access := kn.Spec.Web.GetAccessControl()
+
+	var config string
+	if rule := access.GetPathRule(path); rule != nil && kn.IsClientIPVisibleToNginx() {
+		config += buildAccessRules(8,
+			accessRule{allow: rule.Allow, deny: rule.Deny},
+			accessRule{allow: access.Allow, deny: access.Deny})
+	}
+
+	if !isExternalAuthActive(kn) {
+		return config
+	}
+	if slices.Contains(access.Auth.ExcludePaths, path) {
+		config += `
+        # External authentication disabled
+        auth_request off;
+`
+		// 清除客户端伪造的认证信息
+		for _, header := range access.Auth.GetAuthResponseHeaders() {
+			config += fmt.Sprintf("        proxy_set_header %s \"\";\n", header)
+		}
+		return config
+	}
+
+	// 静态页面和 MinIO 不需要认证信息
+	if path == "/" || (kn.IsMinIOProxyEnabled() && path == kn.GetMinIOProxyPath()) {
+		return config
+	}
+	config += `
+        # Authenticated user
+`
+	for _, header := range access.Auth.GetAuthResponseHeaders() {
+		config += fmt.Sprintf("        proxy_set_header %s %s;\n", header, authHeaderVariable(header))
+	}
+	return config
+}
+
+// authHeaderVariable 认证响应头对应的 Nginx 变量
+func authHeaderVariable(header string) string {
+	return "$auth_" + headerVariableSuffix(header)
+}
+
+// headerVariableSuffix 响应头名称转换为 Nginx 变量后缀(例如：X-Auth-Request-User -> x_auth_request_user)
+func headerVariableSuffix(header string) string {
+	return strings.ToLower(strings.ReplaceAll(header, "-", "_"))
+}
+
+// getAuthProxyURL 获取认证代理地址
+func getAuthProxyURL(kn *kubenovav1.KubeNova) string {
+	auth := kn.Spec.Web.AccessControl.Auth
+	switch auth.GetAuthMode() {
+	case kubenovav1.AuthModeExternal:
+		return strings.TrimRight(auth.URL, "/")
+	case kubenovav1.AuthModeDeployment:
+		return fmt.Sprintf("http://%s:%d", OAuth2ProxyName, oauth2ProxyPort)
+	default:
+		return fmt.Sprintf("http://127.0.0.1:%d", oauth2ProxyPort)
+	}
+}
+
+// getIngressAccessAnnotations 获取 Ingress 的 IP 黑白名单注解
+func getIngressAccessAnnotations(kn *kubenovav1.KubeNova) map[string]string {
+	access := kn.Spec.Web.GetAccessControl()
+
+	annotations := make(map[string]string)
+	if len(access.Allow) > 0 {
+		annotations[IngressAllowListAnnotation] = strings.Join(access.Allow, ",")
+	}
+	if len(access.Deny) > 0 {
+		annotations[IngressDenyListAnnotation] = strings.Join(access.Deny, ",")
+	}
+	return annotations
+}
+
+// buildOAuth2ProxyContainer 构建 oauth2-proxy 容器
+// client-id、client-secret、cookie-secret 从用户提供的 Secret 读取
+func buildOAuth2ProxyContainer(kn *kubenovav1.KubeNova) corev1.Container {
+	proxy := kn.Spec.Web.AccessControl.Auth.GetOAuth2Proxy()
+
+	cookieSecure := kn.IsWebHostTLSEnabled() || kn.IsNodePortHTTPSEnabled()
+	args := []string{
+		fmt.Sprintf("--http-address=0.0.0.0:%d", oauth2ProxyPort),
+		"--provider=" + proxy.GetProvider(),
+		"--upstream=static://202",
+		"--reverse-proxy=true",
+		"--set-xauthrequest=true",
+		"--skip-provider-button=true",
+		fmt.Sprintf("--cookie-secure=%t", cookieSecure),
+	}
+	if proxy.IssuerURL != "" {
+		args = append(args, "--oidc-issuer-url="+proxy.IssuerURL)
+	}
+	if proxy.RedirectURL != "" {
+		args = append(args, "--redirect-url="+proxy.RedirectURL)
+	}
+	for _, domain := range proxy.GetEmailDomains() {
+		args = append(args, "--email-domain="+domain)
+	}
+	args = append(args, proxy.ExtraArgs...)
+
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: proxy.SecretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("200m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+	}
+	if proxy.Resources != nil {
+		resources = *proxy.Resources
+	}
+
+	return corev1.Container{
+		Name:            "oauth2-proxy",
+		Image:           proxy.GetImage(),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args:            args,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "oauth2-proxy",
+				ContainerPort: oauth2ProxyPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Env: []corev1.EnvVar{
+			secretEnv("OAUTH2_PROXY_CLIENT_ID", "client-id"),
+			secretEnv("OAUTH2_PROXY_CLIENT_SECRET", "client-secret"),
+			secretEnv("OAUTH2_PROXY_COOKIE_SECRET", "cookie-secret"),
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/ping",
+					Port: intstr.FromInt(oauth2ProxyPort),
+				},
+			},
+			PeriodSeconds:  10,
+			TimeoutSeconds: 3,
+		},
+		Resources:       resources,
+		SecurityContext: getSecurityContext(),
+	}
+}
+
+// BuildOAuth2ProxyResources 构建 deployment 模式下的 oauth2-proxy Deployment 和 Service
+// 非 deployment 模式返回 nil
+func BuildOAuth2ProxyResources(kn *kubenovav1.KubeNova, namespace string) (*appsv1.Deployment, *corev1.Service) {
+	if !kn.Spec.Web.IsExternalAuthEnabled() ||
+		kn.Spec.Web.AccessControl.Auth.GetAuthMode() != kubenovav1.AuthModeDeployment {
+		return nil, nil
+	}
+
+	labels := getCommonLabels(kn)
+	labels["app"] = OAuth2ProxyName
+	labels["app.kubernetes.io/component"] = "oauth2-proxy"
+
+	replicas := kn.Spec.Web.AccessControl.Auth.GetOAuth2Proxy().GetReplicas()
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      OAuth2ProxyName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": OAuth2ProxyName,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{buildOAuth2ProxyContainer(kn)},
+				},
+			},
+		},
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      OAuth2ProxyName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app": OAuth2ProxyName,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       oauth2ProxyPort,
+					TargetPort: intstr.FromInt(oauth2ProxyPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	return deployment, service
+}
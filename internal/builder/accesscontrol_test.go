@@ -0,0 +1,445 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/nginxconf"
+)
+
+// newAccessControlKubeNova 构建指定暴露方式和访问控制配置的 KubeNova
+func newAccessControlKubeNova(exposeType string, access *kubenovav1.AccessControlConfig) *kubenovav1.KubeNova {
+	return &kubenovav1.KubeNova{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-nova", Namespace: "kube-nova"},
+		Spec: kubenovav1.KubeNovaSpec{
+			Web: kubenovav1.WebConfig{
+				ExposeType:    exposeType,
+				AccessControl: access,
+			},
+		},
+	}
+}
+
+// directiveLines 提取配置中去除缩进和注释后的指令行
+func directiveLines(config string) []string {
+	var lines []string
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestBuildAccessRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		allow []string
+		deny  []string
+		want  []string
+	}{
+		{
+			name:  "allow only",
+			allow: []string{"10.0.0.0/8"},
+			want:  []string{"allow 10.0.0.0/8;", "deny all;"},
+		},
+		{
+			name: "deny only",
+			deny: []string{"192.168.1.10", "172.16.0.0/12"},
+			want: []string{"deny 192.168.1.10;", "deny 172.16.0.0/12;"},
+		},
+		{
+			name:  "deny before allow",
+			allow: []string{"10.0.0.0/8"},
+			deny:  []string{"10.0.0.1"},
+			want:  []string{"deny 10.0.0.1;", "allow 10.0.0.0/8;", "deny all;"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := directiveLines(buildAccessRules(4, accessRule{allow: tt.allow, deny: tt.deny}))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("buildAccessRules() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if config := buildAccessRules(8, accessRule{deny: []string{"10.0.0.1"}}); !strings.Contains(config, "\n        deny 10.0.0.1;\n") {
+		t.Errorf("buildAccessRules() indent = %q, want 8 spaces", config)
+	}
+}
+
+func TestBuildLocationAccessRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		access *kubenovav1.AccessControlConfig
+		want   []string
+	}{
+		{
+			name:   "no path rule inherits server rules",
+			access: &kubenovav1.AccessControlConfig{Allow: []string{"10.0.0.0/8"}},
+		},
+		{
+			name: "path deny keeps global allow",
+			access: &kubenovav1.AccessControlConfig{
+				Allow: []string{"10.0.0.0/8"},
+				Paths: []kubenovav1.PathAccessRule{{Path: "/portal", Deny: []string{"10.0.0.5"}}},
+			},
+			want: []string{"deny 10.0.0.5;", "allow 10.0.0.0/8;", "deny all;"},
+		},
+		{
+			name: "path allow before global deny",
+			access: &kubenovav1.AccessControlConfig{
+				Deny:  []string{"192.168.0.0/16"},
+				Paths: []kubenovav1.PathAccessRule{{Path: "/portal", Allow: []string{"192.168.1.0/24"}}},
+			},
+			want: []string{"allow 192.168.1.0/24;", "deny 192.168.0.0/16;", "deny all;"},
+		},
+		{
+			name: "path deny without allow",
+			access: &kubenovav1.AccessControlConfig{
+				Deny:  []string{"172.16.0.0/12"},
+				Paths: []kubenovav1.PathAccessRule{{Path: "/portal", Deny: []string{"10.0.0.5"}}},
+			},
+			want: []string{"deny 10.0.0.5;", "deny 172.16.0.0/12;"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := directiveLines(buildLocationAccessControl(newAccessControlKubeNova("nodeport", tt.access), "/portal"))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("buildLocationAccessControl() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// locationBlock 提取 default.conf 中指定 location 块的指令行
+func locationBlock(t *testing.T, config, header string) []string {
+	t.Helper()
+	start := strings.Index(config, "\n    "+header+" {\n")
+	if start < 0 {
+		t.Fatalf("config missing %q", header)
+	}
+	block := config[start+len(header)+8:]
+	return directiveLines(block[:strings.Index(block, "\n    }\n")])
+}
+
+// accessDirectives 过滤出 allow/deny/auth_request 指令
+func accessDirectives(lines []string) []string {
+	var result []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "allow ") || strings.HasPrefix(line, "deny ") || strings.HasPrefix(line, "auth_request ") {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+func TestBuildDefaultConfAccessControl(t *testing.T) {
+	kn := newAccessControlKubeNova("nodeport", &kubenovav1.AccessControlConfig{
+		Allow: []string{"10.0.0.0/8"},
+		Paths: []kubenovav1.PathAccessRule{{Path: "/storage", Deny: []string{"10.0.0.5"}}},
+		Auth:  &kubenovav1.ExternalAuthConfig{},
+	})
+	kn.Spec.Web.MinIOProxy = &kubenovav1.MinIOProxyConfig{Enabled: true}
+	kn.Spec.Storage.Endpoint = "minio:9000"
+
+	config := buildDefaultConf(kn, "kube-nova")
+	if err := nginxconf.Validate(buildNginxConf(kn), config); err != nil {
+		t.Fatalf("rendered config is invalid: %v", err)
+	}
+
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "location /storage/", want: []string{"deny 10.0.0.5;", "allow 10.0.0.0/8;", "deny all;"}},
+		{header: "location /portal"},
+		{header: "location /health", want: []string{"allow all;", "auth_request off;"}},
+		{header: "location = /50x.html", want: []string{"allow all;", "auth_request off;"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got := accessDirectives(locationBlock(t, config, tt.header))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("%s access directives = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMaintenanceAccessControl(t *testing.T) {
+	kn := newAccessControlKubeNova("nodeport", &kubenovav1.AccessControlConfig{Allow: []string{"10.0.0.0/8"}})
+	kn.Spec.Maintenance = &kubenovav1.MaintenanceConfig{Enabled: true}
+
+	config := buildDefaultConf(kn, "kube-nova")
+	for _, header := range []string{"location /health", "location ~ ^/(ws|portal|manager|workload|console)(/|$)", "location /"} {
+		if got := accessDirectives(locationBlock(t, config, header)); strings.Join(got, ",") != "allow all;" {
+			t.Errorf("%s access directives = %q, want allow all", header, got)
+		}
+	}
+}
+
+func TestGetWebExternalTrafficPolicy(t *testing.T) {
+	rules := &kubenovav1.AccessControlConfig{Deny: []string{"10.0.0.1"}}
+	realIP := &kubenovav1.NginxConfig{RealIP: &kubenovav1.NginxRealIPConfig{TrustedProxies: []string{"10.0.0.0/8"}}}
+
+	tests := []struct {
+		name        string
+		kn          *kubenovav1.KubeNova
+		want        corev1.ServiceExternalTrafficPolicy
+		wantVisible bool
+	}{
+		{
+			name: "nodeport without rules",
+			kn:   newAccessControlKubeNova("nodeport", nil),
+			want: corev1.ServiceExternalTrafficPolicyTypeCluster,
+		},
+		{
+			name:        "nodeport with rules",
+			kn:          newAccessControlKubeNova("nodeport", rules),
+			want:        corev1.ServiceExternalTrafficPolicyTypeLocal,
+			wantVisible: true,
+		},
+		{
+			name: "nodeport with rules and realIP",
+			kn: func() *kubenovav1.KubeNova {
+				kn := newAccessControlKubeNova("nodeport", rules)
+				kn.Spec.Web.Nginx = realIP
+				return kn
+			}(),
+			want:        corev1.ServiceExternalTrafficPolicyTypeCluster,
+			wantVisible: true,
+		},
+		{
+			name: "loadbalancer cluster",
+			kn:   newAccessControlKubeNova("loadbalancer", rules),
+			want: corev1.ServiceExternalTrafficPolicyTypeCluster,
+		},
+		{
+			name: "loadbalancer local",
+			kn: func() *kubenovav1.KubeNova {
+				kn := newAccessControlKubeNova("loadbalancer", rules)
+				kn.Spec.Web.LoadBalancer = &kubenovav1.LoadBalancerConfig{ExternalTrafficPolicy: "Local"}
+				return kn
+			}(),
+			want:        corev1.ServiceExternalTrafficPolicyTypeLocal,
+			wantVisible: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := buildWebService(tt.kn, "kube-nova")
+			if service.Spec.ExternalTrafficPolicy != tt.want {
+				t.Errorf("externalTrafficPolicy = %s, want %s", service.Spec.ExternalTrafficPolicy, tt.want)
+			}
+			if got := tt.kn.IsClientIPVisibleToNginx(); got != tt.wantVisible {
+				t.Errorf("IsClientIPVisibleToNginx() = %v, want %v", got, tt.wantVisible)
+			}
+		})
+	}
+}
+
+func TestBuildServerAccessControl(t *testing.T) {
+	rules := &kubenovav1.AccessControlConfig{Allow: []string{"10.0.0.0/8"}}
+
+	tests := []struct {
+		name       string
+		kn         *kubenovav1.KubeNova
+		wantRules  bool
+		wantAuth   bool
+		wantHeader string
+	}{
+		{
+			name:      "nodeport renders rules",
+			kn:        newAccessControlKubeNova("nodeport", rules),
+			wantRules: true,
+		},
+		{
+			name: "ingress without realIP skips rules",
+			kn:   newAccessControlKubeNova("ingress", rules),
+		},
+		{
+			name: "ingress with realIP renders rules",
+			kn: func() *kubenovav1.KubeNova {
+				kn := newAccessControlKubeNova("ingress", rules)
+				kn.Spec.Web.Nginx = &kubenovav1.NginxConfig{
+					RealIP: &kubenovav1.NginxRealIPConfig{TrustedProxies: []string{"10.0.0.0/8"}},
+				}
+				return kn
+			}(),
+			wantRules: true,
+		},
+		{
+			name: "external auth",
+			kn: newAccessControlKubeNova("nodeport", &kubenovav1.AccessControlConfig{
+				Auth: &kubenovav1.ExternalAuthConfig{ResponseHeaders: []string{"X-Auth-Request-Groups"}},
+			}),
+			wantAuth:   true,
+			wantHeader: "auth_request_set $auth_x_auth_request_groups $upstream_http_x_auth_request_groups;",
+		},
+		{
+			name: "maintenance disables external auth",
+			kn: func() *kubenovav1.KubeNova {
+				kn := newAccessControlKubeNova("nodeport", &kubenovav1.AccessControlConfig{Auth: &kubenovav1.ExternalAuthConfig{}})
+				kn.Spec.Maintenance = &kubenovav1.MaintenanceConfig{Enabled: true}
+				return kn
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := buildServerAccessControl(tt.kn)
+			if got := strings.Contains(config, "allow 10.0.0.0/8;"); got != tt.wantRules {
+				t.Errorf("rules rendered = %v, want %v\n%s", got, tt.wantRules, config)
+			}
+			if got := strings.Contains(config, "auth_request /oauth2/auth;"); got != tt.wantAuth {
+				t.Errorf("auth_request rendered = %v, want %v\n%s", got, tt.wantAuth, config)
+			}
+			if tt.wantHeader != "" && !strings.Contains(config, tt.wantHeader) {
+				t.Errorf("config missing %q\n%s", tt.wantHeader, config)
+			}
+		})
+	}
+}
+
+func TestBuildLocationAccessControlAuth(t *testing.T) {
+	kn := newAccessControlKubeNova("nodeport", &kubenovav1.AccessControlConfig{
+		Auth: &kubenovav1.ExternalAuthConfig{ExcludePaths: []string{"/console"}},
+	})
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "authenticated api",
+			path: "/portal",
+			want: []string{
+				"proxy_set_header X-Auth-Request-User $auth_x_auth_request_user;",
+				"proxy_set_header X-Auth-Request-Email $auth_x_auth_request_email;",
+			},
+		},
+		{
+			name: "excluded path clears forged headers",
+			path: "/console",
+			want: []string{"auth_request off;", `proxy_set_header X-Auth-Request-User "";`},
+		},
+		{
+			name:    "static page",
+			path:    "/",
+			notWant: []string{"proxy_set_header", "auth_request off;"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := buildLocationAccessControl(kn, tt.path)
+			for _, want := range tt.want {
+				if !strings.Contains(config, want) {
+					t.Errorf("config missing %q\n%s", want, config)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(config, notWant) {
+					t.Errorf("config contains %q\n%s", notWant, config)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAuthProxyURL(t *testing.T) {
+	tests := []struct {
+		name string
+		auth *kubenovav1.ExternalAuthConfig
+		want string
+	}{
+		{name: "sidecar", auth: &kubenovav1.ExternalAuthConfig{}, want: "http://127.0.0.1:4180"},
+		{name: "deployment", auth: &kubenovav1.ExternalAuthConfig{Mode: kubenovav1.AuthModeDeployment}, want: "http://kube-nova-oauth2-proxy:4180"},
+		{
+			name: "external trims trailing slash",
+			auth: &kubenovav1.ExternalAuthConfig{Mode: kubenovav1.AuthModeExternal, URL: "https://auth.example.com/"},
+			want: "https://auth.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kn := newAccessControlKubeNova("nodeport", &kubenovav1.AccessControlConfig{Auth: tt.auth})
+			if got := getAuthProxyURL(kn); got != tt.want {
+				t.Errorf("getAuthProxyURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetIngressAccessAnnotations(t *testing.T) {
+	kn := newAccessControlKubeNova("ingress", &kubenovav1.AccessControlConfig{
+		Allow: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		Deny:  []string{"10.0.0.1"},
+	})
+	annotations := getIngressAccessAnnotations(kn)
+	if got := annotations[IngressAllowListAnnotation]; got != "10.0.0.0/8,192.168.0.0/16" {
+		t.Errorf("allow annotation = %q", got)
+	}
+	if got := annotations[IngressDenyListAnnotation]; got != "10.0.0.1" {
+		t.Errorf("deny annotation = %q", got)
+	}
+
+	if annotations := getIngressAccessAnnotations(newAccessControlKubeNova("ingress", nil)); len(annotations) != 0 {
+		t.Errorf("annotations without rules = %v, want empty", annotations)
+	}
+}
+
+func TestBuildOAuth2ProxyResources(t *testing.T) {
+	sidecar := newAccessControlKubeNova("nodeport", &kubenovav1.AccessControlConfig{Auth: &kubenovav1.ExternalAuthConfig{}})
+	if deployment, service := BuildOAuth2ProxyResources(sidecar, "kube-nova"); deployment != nil || service != nil {
+		t.Errorf("sidecar mode returned deployment or service")
+	}
+
+	kn := newAccessControlKubeNova("nodeport", &kubenovav1.AccessControlConfig{
+		Auth: &kubenovav1.ExternalAuthConfig{
+			Mode:        kubenovav1.AuthModeDeployment,
+			OAuth2Proxy: &kubenovav1.OAuth2ProxyConfig{SecretName: "oauth2-proxy"},
+		},
+	})
+	deployment, service := BuildOAuth2ProxyResources(kn, "kube-nova")
+	if deployment == nil || service == nil {
+		t.Fatalf("deployment mode returned nil deployment or service")
+	}
+	if deployment.Name != OAuth2ProxyName || service.Name != OAuth2ProxyName {
+		t.Errorf("names = %s, %s, want %s", deployment.Name, service.Name, OAuth2ProxyName)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	for _, env := range container.Env {
+		if env.ValueFrom.SecretKeyRef.Name != "oauth2-proxy" {
+			t.Errorf("env %s secret = %s, want oauth2-proxy", env.Name, env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	if !strings.Contains(strings.Join(container.Args, " "), "--cookie-secure=false") {
+		t.Errorf("args = %v, want --cookie-secure=false without TLS", container.Args)
+	}
+}
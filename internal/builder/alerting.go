@@ -0,0 +1,228 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+// AlertmanagerConfigGVK Prometheus Operator AlertmanagerConfig 资源类型
+var AlertmanagerConfigGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1alpha1", Kind: "AlertmanagerConfig"}
+
+const (
+	// AlertingResourceName 生成的 AlertmanagerConfig 和 receiver Secret 名称
+	AlertingResourceName = "kube-nova-alertmanager"
+	// AlertingReceiverKey receiver Secret 中配置片段的 key
+	AlertingReceiverKey = "receiver.yaml"
+	// AlertmanagerWebhookTokenKey kube-nova-secret 中 Webhook Token 的 key
+	AlertmanagerWebhookTokenKey = "ALERTMANAGER_WEBHOOK_TOKEN"
+
+	// alertingTargetService 接收告警的服务
+	alertingTargetService = "manager-api"
+	// alertingTargetPort 接收告警的服务端口
+	alertingTargetPort = 8811
+)
+
+// GetAlertingWebhookURL 获取 manager-api 接收告警的地址
+// 启用内部 TLS 时 manager-api 只提供 https
+func GetAlertingWebhookURL(kn *kubenovav1.KubeNova, namespace string) string {
+	scheme := "http"
+	if kn.IsInternalTLSEnabled() {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%d%s", scheme, alertingTargetService, namespace, alertingTargetPort, kn.Spec.Alerting.GetWebhookPath())
+}
+
+// getAlertingLabels 获取告警资源的标签
+func getAlertingLabels(kn *kubenovav1.KubeNova) map[string]string {
+	labels := make(map[string]string, len(kn.Spec.Alerting.Labels)+4)
+	for k, v := range kn.Spec.Alerting.Labels {
+		labels[k] = v
+	}
+	labels["app.kubernetes.io/name"] = "kube-nova"
+	labels["app.kubernetes.io/instance"] = kn.Name
+	labels["app.kubernetes.io/managed-by"] = "kube-nova-operator"
+	labels["app.kubernetes.io/component"] = "alerting"
+	return labels
+}
+
+// BuildAlertmanagerConfig 构建 AlertmanagerConfig
+// Bearer Token 引用 kube-nova-secret，Prometheus Operator 默认只路由带有相同 namespace 标签的告警，
+// 接收其他命名空间的告警需要在 Alertmanager 上配置 alertmanagerConfigMatcherStrategy
+func BuildAlertmanagerConfig(kn *kubenovav1.KubeNova, namespace string) *unstructured.Unstructured {
+	if !kn.IsAlertingEnabled() || kn.Spec.Alerting.GetAlertingMode() != kubenovav1.AlertingModeAlertmanagerConfig {
+		return nil
+	}
+	alerting := kn.Spec.Alerting
+
+	httpConfig := map[string]interface{}{
+		"authorization": map[string]interface{}{
+			"type": "Bearer",
+			"credentials": map[string]interface{}{
+				"name": "kube-nova-secret",
+				"key":  AlertmanagerWebhookTokenKey,
+			},
+		},
+	}
+	if kn.IsInternalTLSEnabled() {
+		httpConfig["tlsConfig"] = map[string]interface{}{
+			"ca": map[string]interface{}{
+				"secret": map[string]interface{}{
+					"name": InternalTLSSecretName(alertingTargetService),
+					"key":  "ca.crt",
+				},
+			},
+			"serverName": alertingTargetService,
+		}
+	}
+
+	route := map[string]interface{}{
+		"receiver": alerting.GetReceiverName(),
+	}
+	if r := alerting.Route; r != nil {
+		if len(r.Matchers) > 0 {
+			matchers := make([]interface{}, 0, len(r.Matchers))
+			for _, m := range r.Matchers {
+				matchers = append(matchers, map[string]interface{}{
+					"name":      m.Name,
+					"value":     m.Value,
+					"matchType": m.GetMatchType(),
+				})
+			}
+			route["matchers"] = matchers
+		}
+		if len(r.GroupBy) > 0 {
+			groupBy := make([]interface{}, 0, len(r.GroupBy))
+			for _, label := range r.GroupBy {
+				groupBy = append(groupBy, label)
+			}
+			route["groupBy"] = groupBy
+		}
+		if r.GroupWait != "" {
+			route["groupWait"] = r.GroupWait
+		}
+		if r.GroupInterval != "" {
+			route["groupInterval"] = r.GroupInterval
+		}
+		if r.RepeatInterval != "" {
+			route["repeatInterval"] = r.RepeatInterval
+		}
+	}
+
+	config := &unstructured.Unstructured{}
+	config.SetGroupVersionKind(AlertmanagerConfigGVK)
+	config.SetName(AlertingResourceName)
+	config.SetNamespace(namespace)
+	config.SetLabels(getAlertingLabels(kn))
+	config.Object["spec"] = map[string]interface{}{
+		"route": route,
+		"receivers": []interface{}{
+			map[string]interface{}{
+				"name": alerting.GetReceiverName(),
+				"webhookConfigs": []interface{}{
+					map[string]interface{}{
+						"url":          GetAlertingWebhookURL(kn, namespace),
+						"sendResolved": alerting.IsSendResolved(),
+						"httpConfig":   httpConfig,
+					},
+				},
+			},
+		},
+	}
+
+	return config
+}
+
+// BuildAlertmanagerReceiverSecret 构建 receiver 配置片段 Secret
+// 供未使用 Prometheus Operator 的 Alertmanager 手动合并到 alertmanager.yml，
+// Token 和 CA 证书直接内联在配置中
+func BuildAlertmanagerReceiverSecret(kn *kubenovav1.KubeNova, namespace, token string, caCert []byte) *corev1.Secret {
+	if !kn.IsAlertingEnabled() || kn.Spec.Alerting.GetAlertingMode() != kubenovav1.AlertingModeSecret {
+		return nil
+	}
+	alerting := kn.Spec.Alerting
+
+	var b strings.Builder
+	b.WriteString("# Generated by kube-nova-operator, merge into alertmanager.yml\n")
+	b.WriteString("route:\n")
+	fmt.Fprintf(&b, "  receiver: %s\n", strconv.Quote(alerting.GetReceiverName()))
+	if r := alerting.Route; r != nil {
+		if len(r.Matchers) > 0 {
+			b.WriteString("  matchers:\n")
+			for _, m := range r.Matchers {
+				fmt.Fprintf(&b, "    - %s\n", strconv.Quote(fmt.Sprintf("%s%s%s", m.Name, m.GetMatchType(), strconv.Quote(m.Value))))
+			}
+		}
+		if len(r.GroupBy) > 0 {
+			quoted := make([]string, 0, len(r.GroupBy))
+			for _, label := range r.GroupBy {
+				quoted = append(quoted, strconv.Quote(label))
+			}
+			fmt.Fprintf(&b, "  group_by: [%s]\n", strings.Join(quoted, ", "))
+		}
+		if r.GroupWait != "" {
+			fmt.Fprintf(&b, "  group_wait: %s\n", r.GroupWait)
+		}
+		if r.GroupInterval != "" {
+			fmt.Fprintf(&b, "  group_interval: %s\n", r.GroupInterval)
+		}
+		if r.RepeatInterval != "" {
+			fmt.Fprintf(&b, "  repeat_interval: %s\n", r.RepeatInterval)
+		}
+	}
+
+	b.WriteString("receivers:\n")
+	fmt.Fprintf(&b, "  - name: %s\n", strconv.Quote(alerting.GetReceiverName()))
+	b.WriteString("    webhook_configs:\n")
+	fmt.Fprintf(&b, "      - url: %s\n", strconv.Quote(GetAlertingWebhookURL(kn, namespace)))
+	fmt.Fprintf(&b, "        send_resolved: %t\n", alerting.IsSendResolved())
+	b.WriteString("        http_config:\n")
+	b.WriteString("          authorization:\n")
+	b.WriteString("            type: Bearer\n")
+	fmt.Fprintf(&b, "            credentials: %s\n", strconv.Quote(token))
+	if kn.IsInternalTLSEnabled() {
+		b.WriteString("          tls_config:\n")
+		fmt.Fprintf(&b, "            server_name: %s\n", alertingTargetService)
+		if len(caCert) > 0 {
+			b.WriteString("            ca: |\n")
+			for _, line := range strings.Split(strings.TrimRight(string(caCert), "\n"), "\n") {
+				fmt.Fprintf(&b, "              %s\n", line)
+			}
+		}
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AlertingResourceName,
+			Namespace: namespace,
+			Labels:    getAlertingLabels(kn),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			AlertingReceiverKey: []byte(b.String()),
+		},
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+// CertificateGVK cert-manager Certificate 资源类型
+var CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// BuildCertificates 构建需要由 cert-manager 签发的证书
+// secretLabels 写入证书 Secret 的标签，用于 Secret 变化时触发协调
+// nodeIP 用于 NodePort HTTPS 证书的 IP SAN
+func BuildCertificates(kn *kubenovav1.KubeNova, namespace, nodeIP string, secretLabels map[string]string) []*unstructured.Unstructured {
+	var certificates []*unstructured.Unstructured
+
+	// Web 证书(Ingress TLS 或 NodePort HTTPS)
+	if issuerRef := kn.GetWebTLSIssuerRef(); issuerRef != nil {
+		var dnsNames, ipAddresses []string
+		if kn.Spec.Web.ExposeType == "ingress" {
+			dnsNames = []string{kn.Spec.Web.Ingress.Host}
+		} else {
+			dnsNames = append(dnsNames,
+				"kube-nova-web",
+				fmt.Sprintf("kube-nova-web.%s.svc", namespace),
+				fmt.Sprintf("kube-nova-web.%s.svc.cluster.local", namespace),
+			)
+			dnsNames = append(dnsNames, kn.Spec.Web.NodePort.HTTPS.DNSNames...)
+			if nodeIP != "" {
+				ipAddresses = []string{nodeIP}
+			}
+		}
+		certificates = append(certificates, buildCertificate(kn, namespace, "kube-nova-web-tls",
+			kn.GetWebTLSSecretName(), issuerRef, dnsNames, ipAddresses, secretLabels))
+	}
+
+	// MinIO 证书
+	if kn.IsMinIOCertIssued() {
+		var dnsNames, ipAddresses []string
+		host := getEndpointHost(kn.Spec.Storage.Endpoint)
+		if net.ParseIP(host) != nil {
+			ipAddresses = []string{host}
+		} else if host != "" {
+			dnsNames = []string{host}
+		}
+		certificates = append(certificates, buildCertificate(kn, namespace, "kube-nova-minio-tls",
+			kn.GetMinIOTLSSecretName(), kn.Spec.Storage.TLS.IssuerRef, dnsNames, ipAddresses, secretLabels))
+	}
+
+	return certificates
+}
+
+// buildCertificate 构建单个 Certificate
+func buildCertificate(kn *kubenovav1.KubeNova, namespace, name, secretName string, issuerRef *kubenovav1.CertIssuerRef,
+	dnsNames, ipAddresses []string, secretLabels map[string]string) *unstructured.Unstructured {
+	kind := issuerRef.Kind
+	if kind == "" {
+		kind = "Issuer"
+	}
+	group := issuerRef.Group
+	if group == "" {
+		group = "cert-manager.io"
+	}
+
+	spec := map[string]interface{}{
+		"secretName": secretName,
+		"issuerRef": map[string]interface{}{
+			"name":  issuerRef.Name,
+			"kind":  kind,
+			"group": group,
+		},
+	}
+	if len(dnsNames) > 0 {
+		spec["commonName"] = dnsNames[0]
+		spec["dnsNames"] = toInterfaceSlice(dnsNames)
+	}
+	if len(ipAddresses) > 0 {
+		spec["ipAddresses"] = toInterfaceSlice(ipAddresses)
+	}
+	if len(secretLabels) > 0 {
+		labels := make(map[string]interface{}, len(secretLabels))
+		for k, v := range secretLabels {
+			labels[k] = v
+		}
+		spec["secretTemplate"] = map[string]interface{}{
+			"labels": labels,
+		}
+	}
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGVK)
+	certificate.SetName(name)
+	certificate.SetNamespace(namespace)
+	certificate.SetLabels(getCommonLabels(kn))
+	certificate.Object["spec"] = spec
+
+	return certificate
+}
+
+// getEndpointHost 从 host:port 或 URL 形式的端点中提取主机名
+func getEndpointHost(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		if u, err := url.Parse(endpoint); err == nil {
+			return u.Hostname()
+		}
+	}
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host
+	}
+	return endpoint
+}
+
+// toInterfaceSlice 将字符串切片转换为 unstructured 可用的切片
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
@@ -17,12 +17,23 @@ limitations under the License.
 package builder
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// SpecChecksumAnnotation 记录期望 spec checksum 的注解
+	SpecChecksumAnnotation = "kubenova.io/spec-checksum"
 )
 
 // int64Ptr 返回 int64 指针
@@ -40,6 +51,48 @@ func int32Ptr(i int32) *int32 {
 	return &i
 }
 
+// buildLocaleEnv 构建时区和语言环境变量
+func buildLocaleEnv(kn *kubenovav1.KubeNova, timezone string) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{
+			Name:  "TZ",
+			Value: timezone,
+		},
+	}
+	if kn.Spec.Locale != "" {
+		env = append(env, corev1.EnvVar{Name: "LANG", Value: kn.Spec.Locale})
+	}
+	return env
+}
+
+// mergeEnvVars 合并环境变量，与已有变量同名时原位替换，其余按顺序追加
+func mergeEnvVars(base, overrides []corev1.EnvVar) []corev1.EnvVar {
+	merged := make([]corev1.EnvVar, 0, len(base)+len(overrides))
+	merged = append(merged, base...)
+	index := make(map[string]int, len(merged))
+	for i, env := range merged {
+		index[env.Name] = i
+	}
+	for _, env := range overrides {
+		if i, ok := index[env.Name]; ok {
+			merged[i] = env
+			continue
+		}
+		index[env.Name] = len(merged)
+		merged = append(merged, env)
+	}
+	return merged
+}
+
+// GenerateRandomToken 生成指定字节数的随机 Token(十六进制编码)
+func GenerateRandomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成随机 Token 失败: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 // CalculateConfigMapChecksum 计算 ConfigMap 的 checksum
 func CalculateConfigMapChecksum(cm *corev1.ConfigMap) string {
 	if cm == nil || cm.Data == nil {
@@ -94,6 +147,21 @@ func CalculateSecretChecksum(secret *corev1.Secret) string {
 	return fmt.Sprintf("%x", hash)
 }
 
+// CalculateSecretKeysChecksum 计算 Secret 中指定键的 checksum，Secret 中不存在的键忽略
+func CalculateSecretKeysChecksum(secret *corev1.Secret, keys []string) string {
+	if secret == nil || secret.Data == nil {
+		return ""
+	}
+
+	data := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		if v, ok := secret.Data[k]; ok {
+			data[k] = v
+		}
+	}
+	return CalculateSecretChecksum(&corev1.Secret{Data: data})
+}
+
 // CalculateConfigMapListChecksum 计算多个 ConfigMap 的总 checksum
 func CalculateConfigMapListChecksum(configMaps []*corev1.ConfigMap) string {
 	var checksums []string
@@ -106,3 +174,14 @@ func CalculateConfigMapListChecksum(configMaps []*corev1.ConfigMap) string {
 	hash := sha256.Sum256([]byte(combined))
 	return fmt.Sprintf("%x", hash)
 }
+
+// CalculateSpecChecksum 计算 unstructured 资源 spec 的 checksum
+// 用于判断第三方 CRD 资源是否需要更新，避免与服务端默认值比较
+func CalculateSpecChecksum(obj *unstructured.Unstructured) string {
+	data, err := json.Marshal(obj.Object["spec"])
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return fmt.Sprintf("%x", hash)
+}
@@ -61,10 +61,10 @@ Telemetry:
 
 Log:
   ServiceName: portal-api
-  Mode: console
+  Mode: ${LOG_MODE}
   Encoding: plain
-  TimeFormat: "2006-01-02 15:04:05"
-  Path: logs
+  TimeFormat: "${LOG_TIME_FORMAT}"
+  Path: /app/logs
   Level: info
   MaxContentLength: 1024
   Compress: false
@@ -83,12 +83,9 @@ Cache:
   NonBlock: ${REDIS_NONBLOCK}
   PingTimeout: ${REDIS_PING_TIMEOUT}
 
-PortalRpc:
-  Target: k8s://${POD_NAMESPACE}/portal-rpc:30010
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-`
+` +
+		buildRPCClientConfig(kn, kn.Spec.Services.PortalAPI, "PortalRpc", "portal-rpc", 30010)
+	config += buildInternalTLSServerConfig(kn, false)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -127,10 +124,10 @@ Telemetry:
 
 Log:
   ServiceName: portal-rpc
-  Mode: console
+  Mode: ${LOG_MODE}
   Encoding: plain
-  TimeFormat: "2006-01-02 15:04:05"
-  Path: logs
+  TimeFormat: "${LOG_TIME_FORMAT}"
+  Path: /app/logs
   Level: info
   MaxContentLength: 1024
   Compress: false
@@ -176,11 +173,14 @@ StorageConf:
 
 AuthConfig:
   AccessSecret: ${JWT_ACCESS_SECRET}
+  PreviousAccessSecret: ${JWT_ACCESS_SECRET_PREVIOUS}
   AccessExpire: ${JWT_ACCESS_EXPIRE}
   RefreshSecret: ${JWT_REFRESH_SECRET}
+  PreviousRefreshSecret: ${JWT_REFRESH_SECRET_PREVIOUS}
   RefreshExpire: ${JWT_REFRESH_EXPIRE}
   RefreshAfter: ${JWT_REFRESH_AFTER}
 `
+	config += buildInternalTLSServerConfig(kn, true)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -221,10 +221,10 @@ Telemetry:
 
 Log:
   ServiceName: manager-api
-  Mode: console
+  Mode: ${LOG_MODE}
   Encoding: plain
-  TimeFormat: "2006-01-02 15:04:05"
-  Path: logs
+  TimeFormat: "${LOG_TIME_FORMAT}"
+  Path: /app/logs
   Level: info
   MaxContentLength: 1024
   Compress: false
@@ -243,18 +243,10 @@ Cache:
   NonBlock: ${REDIS_NONBLOCK}
   PingTimeout: ${REDIS_PING_TIMEOUT}
 
-ManagerRpc:
-  Target: k8s://${POD_NAMESPACE}/manager-rpc:30011
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-
-PortalRpc:
-  Target: k8s://${POD_NAMESPACE}/portal-rpc:30010
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-`
+` +
+		buildRPCClientConfig(kn, kn.Spec.Services.ManagerAPI, "ManagerRpc", "manager-rpc", 30011) + "\n" +
+		buildRPCClientConfig(kn, kn.Spec.Services.ManagerAPI, "PortalRpc", "portal-rpc", 30010)
+	config += buildInternalTLSServerConfig(kn, false)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -290,10 +282,10 @@ Telemetry:
 
 Log:
   ServiceName: manager-rpc
-  Mode: console
+  Mode: ${LOG_MODE}
   Encoding: plain
-  TimeFormat: "2006-01-02 15:04:05"
-  Path: logs
+  TimeFormat: "${LOG_TIME_FORMAT}"
+  Path: /app/logs
   Level: info
   MaxContentLength: 1024
   Compress: false
@@ -326,12 +318,9 @@ DBCache:
     NonBlock: ${REDIS_NONBLOCK}
     PingTimeout: ${REDIS_PING_TIMEOUT}
 
-PortalRpc:
-  Target: k8s://${POD_NAMESPACE}/portal-rpc:30010
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-`
+` +
+		buildRPCClientConfig(kn, kn.Spec.Services.ManagerRPC, "PortalRpc", "portal-rpc", 30010)
+	config += buildInternalTLSServerConfig(kn, true)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -369,10 +358,10 @@ Telemetry:
 
 Log:
   ServiceName: workload-api
-  Mode: console
+  Mode: ${LOG_MODE}
   Encoding: plain
-  TimeFormat: "2006-01-02 15:04:05"
-  Path: logs
+  TimeFormat: "${LOG_TIME_FORMAT}"
+  Path: /app/logs
   Level: info
   MaxContentLength: 1024
   Compress: false
@@ -391,18 +380,10 @@ Cache:
   NonBlock: ${REDIS_NONBLOCK}
   PingTimeout: ${REDIS_PING_TIMEOUT}
 
-ManagerRpc:
-  Target: k8s://${POD_NAMESPACE}/manager-rpc:30011
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-
-PortalRpc:
-  Target: k8s://${POD_NAMESPACE}/portal-rpc:30010
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-`
+` +
+		buildRPCClientConfig(kn, kn.Spec.Services.WorkloadAPI, "ManagerRpc", "manager-rpc", 30011) + "\n" +
+		buildRPCClientConfig(kn, kn.Spec.Services.WorkloadAPI, "PortalRpc", "portal-rpc", 30010)
+	config += buildInternalTLSServerConfig(kn, false)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -441,10 +422,10 @@ Telemetry:
 
 Log:
   ServiceName: console-api
-  Mode: console
+  Mode: ${LOG_MODE}
   Encoding: plain
-  TimeFormat: "2006-01-02 15:04:05"
-  Path: logs
+  TimeFormat: "${LOG_TIME_FORMAT}"
+  Path: /app/logs
   Level: info
   MaxContentLength: 1024
   Compress: false
@@ -463,24 +444,11 @@ Cache:
   NonBlock: ${REDIS_NONBLOCK}
   PingTimeout: ${REDIS_PING_TIMEOUT}
 
-ManagerRpc:
-  Target: k8s://${POD_NAMESPACE}/manager-rpc:30011
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-
-ConsoleRpc:
-  Target: k8s://${POD_NAMESPACE}/console-rpc:30018
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-
-PortalRpc:
-  Target: k8s://${POD_NAMESPACE}/portal-rpc:30010
-  Optional: true
-  NonBlock: true
-  Timeout: ${DEFAULT_TIMEOUT}
-`
+` +
+		buildRPCClientConfig(kn, kn.Spec.Services.ConsoleAPI, "ManagerRpc", "manager-rpc", 30011) + "\n" +
+		buildRPCClientConfig(kn, kn.Spec.Services.ConsoleAPI, "ConsoleRpc", "console-rpc", 30018) + "\n" +
+		buildRPCClientConfig(kn, kn.Spec.Services.ConsoleAPI, "PortalRpc", "portal-rpc", 30010)
+	config += buildInternalTLSServerConfig(kn, false)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -516,10 +484,10 @@ Telemetry:
 
 Log:
   ServiceName: console-rpc
-  Mode: console
+  Mode: ${LOG_MODE}
   Encoding: plain
-  TimeFormat: "2006-01-02 15:04:05"
-  Path: logs
+  TimeFormat: "${LOG_TIME_FORMAT}"
+  Path: /app/logs
   Level: info
   MaxContentLength: 1024
   Compress: false
@@ -552,6 +520,7 @@ DBCache:
     NonBlock: ${REDIS_NONBLOCK}
     PingTimeout: ${REDIS_PING_TIMEOUT}
 `
+	config += buildInternalTLSServerConfig(kn, true)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
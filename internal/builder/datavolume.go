@@ -0,0 +1,121 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+// podNameSubPathExpr 已有 PVC 中每个 Pod 使用的子目录，依赖容器中的 POD_NAME 环境变量
+const podNameSubPathExpr = "$(POD_NAME)"
+
+// cacheSubPath 已有 PVC 中缓存使用的子目录，与日志的 Pod 名称子目录互不影响
+const cacheSubPath = "cache"
+
+// buildDataVolume 构建日志或缓存目录的卷，未配置时使用 defaultSize 容量的 emptyDir
+func buildDataVolume(name string, cfg *kubenovav1.VolumeConfig, defaultSize resource.Quantity, labels map[string]string) corev1.Volume {
+	volume := corev1.Volume{Name: name}
+
+	switch {
+	case cfg != nil && cfg.Type == kubenovav1.VolumeTypeEphemeral:
+		size := defaultSize
+		if cfg.Size != nil {
+			size = cfg.Size.DeepCopy()
+		}
+		volume.Ephemeral = &corev1.EphemeralVolumeSource{
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					StorageClassName: cfg.StorageClassName,
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: size,
+						},
+					},
+				},
+			},
+		}
+	case cfg != nil && cfg.Type == kubenovav1.VolumeTypePersistentVolumeClaim:
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: cfg.ClaimName,
+		}
+	default:
+		emptyDir := &corev1.EmptyDirVolumeSource{SizeLimit: &defaultSize}
+		if cfg != nil {
+			emptyDir.Medium = cfg.Medium
+			if cfg.Size != nil {
+				size := cfg.Size.DeepCopy()
+				emptyDir.SizeLimit = &size
+			}
+		}
+		volume.EmptyDir = emptyDir
+	}
+	return volume
+}
+
+// buildLogsVolumeMount 构建日志目录的挂载
+// 已有 PVC 由多个副本共用，每个 Pod 写入以 Pod 名称命名的子目录，避免文件互相覆盖；
+// Pod 名称在滚动更新后变化，旧 Pod 的目录保留在 PVC 中，由用户自行归档和清理
+func buildLogsVolumeMount(mountPath string, cfg *kubenovav1.VolumeConfig) corev1.VolumeMount {
+	mount := corev1.VolumeMount{
+		Name:      "logs",
+		MountPath: mountPath,
+	}
+	if cfg != nil && cfg.Type == kubenovav1.VolumeTypePersistentVolumeClaim {
+		mount.SubPathExpr = podNameSubPathExpr
+	}
+	return mount
+}
+
+// buildCacheVolumeMount 构建缓存目录的挂载
+// 已有 PVC 使用固定的 cache 子目录，Pod 重建后继续使用原有缓存(校验时限制为单副本)
+func buildCacheVolumeMount(mountPath string, cfg *kubenovav1.VolumeConfig) corev1.VolumeMount {
+	mount := corev1.VolumeMount{
+		Name:      "cache",
+		MountPath: mountPath,
+	}
+	if cfg != nil && cfg.Type == kubenovav1.VolumeTypePersistentVolumeClaim {
+		mount.SubPath = cacheSubPath
+	}
+	return mount
+}
+
+// buildPodNameEnv 挂载使用 Pod 名称子目录时构建 POD_NAME 环境变量
+func buildPodNameEnv(mounts []corev1.VolumeMount) []corev1.EnvVar {
+	for _, m := range mounts {
+		if m.SubPathExpr == podNameSubPathExpr {
+			return []corev1.EnvVar{
+				{
+					Name: "POD_NAME",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "metadata.name",
+						},
+					},
+				},
+			}
+		}
+	}
+	return nil
+}
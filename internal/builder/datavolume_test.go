@@ -0,0 +1,148 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+func TestBuildDataVolume(t *testing.T) {
+	defaultSize := resource.MustParse("1Gi")
+	customSize := resource.MustParse("5Gi")
+	storageClass := "fast"
+	labels := map[string]string{"app": "portal-api"}
+
+	tests := []struct {
+		name  string
+		cfg   *kubenovav1.VolumeConfig
+		check func(t *testing.T, volume corev1.Volume)
+	}{
+		{
+			name: "default emptyDir",
+			check: func(t *testing.T, volume corev1.Volume) {
+				if volume.EmptyDir == nil || volume.EmptyDir.SizeLimit.Cmp(defaultSize) != 0 {
+					t.Errorf("emptyDir = %+v, want sizeLimit %s", volume.EmptyDir, defaultSize.String())
+				}
+			},
+		},
+		{
+			name: "emptyDir with medium and size",
+			cfg:  &kubenovav1.VolumeConfig{Medium: corev1.StorageMediumMemory, Size: &customSize},
+			check: func(t *testing.T, volume corev1.Volume) {
+				if volume.EmptyDir == nil || volume.EmptyDir.Medium != corev1.StorageMediumMemory ||
+					volume.EmptyDir.SizeLimit.Cmp(customSize) != 0 {
+					t.Errorf("emptyDir = %+v, want memory medium with %s", volume.EmptyDir, customSize.String())
+				}
+			},
+		},
+		{
+			name: "ephemeral",
+			cfg:  &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypeEphemeral, StorageClassName: &storageClass},
+			check: func(t *testing.T, volume corev1.Volume) {
+				if volume.Ephemeral == nil {
+					t.Fatalf("ephemeral volume = nil")
+				}
+				template := volume.Ephemeral.VolumeClaimTemplate
+				if got := template.Spec.Resources.Requests[corev1.ResourceStorage]; got.Cmp(defaultSize) != 0 {
+					t.Errorf("storage request = %s, want %s", got.String(), defaultSize.String())
+				}
+				if *template.Spec.StorageClassName != storageClass || template.Labels["app"] != "portal-api" {
+					t.Errorf("claim template = %+v", template)
+				}
+			},
+		},
+		{
+			name: "existing claim",
+			cfg:  &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypePersistentVolumeClaim, ClaimName: "logs"},
+			check: func(t *testing.T, volume corev1.Volume) {
+				if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != "logs" {
+					t.Errorf("persistentVolumeClaim = %+v, want logs", volume.PersistentVolumeClaim)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volume := buildDataVolume("logs", tt.cfg, defaultSize, labels)
+			if volume.Name != "logs" {
+				t.Errorf("volume name = %s, want logs", volume.Name)
+			}
+			tt.check(t, volume)
+		})
+	}
+}
+
+func TestBuildLogsVolumeMount(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *kubenovav1.VolumeConfig
+		wantSubPath string
+	}{
+		{name: "default"},
+		{name: "ephemeral", cfg: &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypeEphemeral}},
+		{
+			name:        "existing claim",
+			cfg:         &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypePersistentVolumeClaim, ClaimName: "logs"},
+			wantSubPath: podNameSubPathExpr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mount := buildLogsVolumeMount("/app/logs", tt.cfg)
+			if mount.Name != "logs" || mount.MountPath != "/app/logs" || mount.SubPathExpr != tt.wantSubPath {
+				t.Errorf("mount = %+v, want subPathExpr %q", mount, tt.wantSubPath)
+			}
+			env := buildPodNameEnv([]corev1.VolumeMount{mount})
+			if wantEnv := tt.wantSubPath != ""; (len(env) == 1) != wantEnv {
+				t.Errorf("POD_NAME env = %+v, want present %v", env, wantEnv)
+			}
+		})
+	}
+}
+
+func TestBuildCacheVolumeMount(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *kubenovav1.VolumeConfig
+		wantSubPath string
+	}{
+		{name: "default"},
+		{name: "ephemeral", cfg: &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypeEphemeral}},
+		{
+			name:        "existing claim",
+			cfg:         &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypePersistentVolumeClaim, ClaimName: "cache"},
+			wantSubPath: cacheSubPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mount := buildCacheVolumeMount("/app/cache", tt.cfg)
+			if mount.Name != "cache" || mount.MountPath != "/app/cache" ||
+				mount.SubPath != tt.wantSubPath || mount.SubPathExpr != "" {
+				t.Errorf("mount = %+v, want subPath %q", mount, tt.wantSubPath)
+			}
+			if env := buildPodNameEnv([]corev1.VolumeMount{mount}); len(env) != 0 {
+				t.Errorf("POD_NAME env = %+v, want none", env)
+			}
+		})
+	}
+}
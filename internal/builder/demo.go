@@ -0,0 +1,258 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// DemoResetCronJobName 演示环境数据重置 CronJob 名称
+	DemoResetCronJobName = "kube-nova-demo-reset"
+	// DemoResetComponent 演示环境数据重置的组件标签
+	DemoResetComponent = "demo-reset"
+
+	// defaultMySQLClientImage 导入 SQL 默认使用的镜像
+	defaultMySQLClientImage = "mysql:8.0"
+	// defaultMinIOClientImage 下载和同步对象默认使用的镜像
+	defaultMinIOClientImage = "minio/mc:RELEASE.2025-04-16T18-13-26Z"
+
+	// demoSeedDir 种子 SQL 文件所在目录
+	demoSeedDir = "/seed"
+	// demoMinIOCADir mc 信任的 CA 证书目录(镜像以 root 运行)
+	demoMinIOCADir = "/root/.mc/certs/CAs"
+)
+
+// demoMinIOAliasScript 使用 kube-nova-secret 中的 MinIO 连接配置设置 mc 别名
+const demoMinIOAliasScript = `scheme=http
+[ "$MINIO_USE_SSL" = "true" ] && scheme=https
+mc alias set seed "$scheme://$MINIO_ENDPOINT" "$MINIO_ACCESS_KEY" "$MINIO_SECRET_KEY" >/dev/null
+`
+
+// demoFetchSeedScript 从种子存储桶下载 SQL 文件
+const demoFetchSeedScript = `set -e
+` + demoMinIOAliasScript + `mc cp "seed/$SEED_BUCKET/$SEED_SQL_OBJECT" "$SEED_FILE"
+`
+
+// demoRestoreDatabaseScript 删除并重建数据库后导入种子 SQL
+// 使用业务数据库用户执行，需要 DROP 和 CREATE 权限；后端服务不停止，重置期间访问数据库的请求会失败
+// 数据库名称用反引号包裹，反引号通过 printf 生成，避免 shell 命令替换
+const demoRestoreDatabaseScript = `set -e
+export MYSQL_PWD="$MYSQL_PASSWORD"
+q=$(printf '\140')
+db="${q}${MYSQL_DATABASE}${q}"
+echo "dropping and recreating database $MYSQL_DATABASE as $MYSQL_USER, backend requests fail until the seed is imported"
+mysql -h "$MYSQL_HOST" -P "$MYSQL_PORT" -u "$MYSQL_USER" \
+  -e "DROP DATABASE IF EXISTS $db; CREATE DATABASE $db CHARACTER SET utf8mb4"
+case "$SEED_FILE" in
+  *.gz) gzip -dc "$SEED_FILE" | mysql -h "$MYSQL_HOST" -P "$MYSQL_PORT" -u "$MYSQL_USER" "$MYSQL_DATABASE" ;;
+  *) mysql -h "$MYSQL_HOST" -P "$MYSQL_PORT" -u "$MYSQL_USER" "$MYSQL_DATABASE" < "$SEED_FILE" ;;
+esac
+echo "database restored from seed"
+`
+
+// demoRestoreObjectsScript 将种子对象镜像到平台存储桶，删除多余对象
+const demoRestoreObjectsScript = `set -e
+` + demoMinIOAliasScript + `mc mirror --overwrite --remove "seed/$SEED_BUCKET/$SEED_OBJECTS_PREFIX" "seed/$MINIO_BUCKET"
+echo "bucket restored from seed"
+`
+
+// BuildDemoResetCronJob 构建演示环境数据重置 CronJob
+// 步骤依次为下载种子 SQL(可选)、重建数据库、同步存储桶，除最后一步外均作为 init 容器按顺序执行；
+// 连接信息来自 kube-nova-secret，种子位置通过环境变量传入脚本
+func BuildDemoResetCronJob(kn *kubenovav1.KubeNova, namespace string) *batchv1.CronJob {
+	demo := kn.GetDemoReset()
+	seed := demo.Seed
+	registry := kn.GetImageRegistry()
+
+	labels := getCommonLabels(kn)
+	labels["app.kubernetes.io/component"] = DemoResetComponent
+
+	mysqlImage := demo.MySQLClientImage
+	if mysqlImage == "" {
+		mysqlImage = getMirroredImage(kn, defaultMySQLClientImage)
+	}
+	mcImage := demo.MinIOClientImage
+	if mcImage == "" {
+		mcImage = getMirroredImage(kn, defaultMinIOClientImage)
+	}
+
+	env := buildLocaleEnv(kn, kn.GetTimezone())
+	for _, e := range []corev1.EnvVar{
+		{Name: "SEED_BUCKET", Value: seed.Bucket},
+		{Name: "SEED_SQL_OBJECT", Value: seed.SQLObject},
+		{Name: "SEED_OBJECTS_PREFIX", Value: seed.ObjectsPrefix},
+		{Name: "SEED_FILE", Value: getDemoSeedFile(&seed)},
+	} {
+		if e.Value != "" {
+			env = append(env, e)
+		}
+	}
+	envFrom := []corev1.EnvFromSource{
+		{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "kube-nova-secret",
+				},
+			},
+		},
+	}
+
+	var volumes []corev1.Volume
+	var seedMounts, caMounts []corev1.VolumeMount
+	if seed.ConfigMap != nil || seed.SQLObject != "" {
+		volumes = append(volumes, getDemoSeedVolume(&seed))
+		seedMounts = append(seedMounts, corev1.VolumeMount{Name: "seed", MountPath: demoSeedDir})
+	}
+	if name := kn.GetMinIOTLSSecretName(); name != "" && (seed.SQLObject != "" || seed.ObjectsPrefix != "") {
+		certKey := "public.crt"
+		if kn.IsMinIOCertIssued() {
+			certKey = corev1.TLSCertKey
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: "minio-ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: name,
+					Optional:   boolPtr(false),
+					Items: []corev1.KeyToPath{
+						{
+							Key:  certKey,
+							Path: "public.crt",
+						},
+					},
+				},
+			},
+		})
+		caMounts = append(caMounts, corev1.VolumeMount{Name: "minio-ca", MountPath: demoMinIOCADir, ReadOnly: true})
+	}
+
+	newStep := func(name, image, script string, mounts []corev1.VolumeMount) corev1.Container {
+		return corev1.Container{
+			Name:         name,
+			Image:        image,
+			Command:      []string{"/bin/sh", "-c", script},
+			EnvFrom:      envFrom,
+			Env:          env,
+			VolumeMounts: mounts,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+			},
+		}
+	}
+
+	var steps []corev1.Container
+	if seed.SQLObject != "" {
+		steps = append(steps, newStep("fetch-seed", mcImage, demoFetchSeedScript, append(slices.Clone(seedMounts), caMounts...)))
+	}
+	if seed.ConfigMap != nil || seed.SQLObject != "" {
+		steps = append(steps, newStep("restore-database", mysqlImage, demoRestoreDatabaseScript, seedMounts))
+	}
+	if seed.ObjectsPrefix != "" {
+		steps = append(steps, newStep("restore-objects", mcImage, demoRestoreObjectsScript, caMounts))
+	}
+
+	podSpec := corev1.PodSpec{
+		InitContainers: steps[:len(steps)-1],
+		Containers:     steps[len(steps)-1:],
+		Volumes:        volumes,
+		RestartPolicy:  corev1.RestartPolicyNever,
+	}
+	for _, secret := range registry.PullSecrets {
+		podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+
+	timezone := kn.GetTimezone()
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DemoResetCronJobName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   demo.ResetSchedule,
+			TimeZone:                   &timezone,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			Suspend:                    boolPtr(demo.Suspend),
+			SuccessfulJobsHistoryLimit: int32Ptr(3),
+			FailedJobsHistoryLimit:     int32Ptr(3),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit:          int32Ptr(1),
+					ActiveDeadlineSeconds: int64Ptr(demo.GetActiveDeadlineSeconds()),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: labels,
+						},
+						Spec: podSpec,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getDemoSeedFile 获取种子 SQL 在容器中的路径，保留原始扩展名用于判断是否压缩
+func getDemoSeedFile(seed *kubenovav1.DemoSeedConfig) string {
+	switch {
+	case seed.ConfigMap != nil:
+		return demoSeedDir + "/" + seed.ConfigMap.GetKey()
+	case strings.HasSuffix(seed.SQLObject, ".gz"):
+		return demoSeedDir + "/seed.sql.gz"
+	case seed.SQLObject != "":
+		return demoSeedDir + "/seed.sql"
+	}
+	return ""
+}
+
+// getDemoSeedVolume 获取种子 SQL 所在的卷：ConfigMap 或下载文件用的临时目录
+func getDemoSeedVolume(seed *kubenovav1.DemoSeedConfig) corev1.Volume {
+	if seed.ConfigMap != nil {
+		return corev1.Volume{
+			Name: "seed",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: seed.ConfigMap.Name,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  seed.ConfigMap.GetKey(),
+							Path: seed.ConfigMap.GetKey(),
+						},
+					},
+				},
+			},
+		}
+	}
+	return corev1.Volume{
+		Name: "seed",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
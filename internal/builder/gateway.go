@@ -0,0 +1,219 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+// Gateway API 资源使用 unstructured 构建，避免 Operator 强依赖 Gateway API CRD
+var (
+	// HTTPRouteGVK HTTPRoute 资源类型
+	HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	// GatewayGVK Gateway 资源类型
+	GatewayGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+)
+
+const (
+	// gatewayRequestTimeout 普通请求超时，与 Ingress proxy-read-timeout 保持一致
+	gatewayRequestTimeout = "600s"
+	// gatewayWebSocketTimeout WebSocket 长连接不设置超时
+	gatewayWebSocketTimeout = "0s"
+)
+
+// gatewayWebSocketPaths WebSocket 路径，需要单独的路由规则关闭超时
+var gatewayWebSocketPaths = []string{"/ws/v1/pod", "/ws/v1/site-messages"}
+
+// buildWebHTTPRoute 构建 Web HTTPRoute
+func buildWebHTTPRoute(kn *kubenovav1.KubeNova, namespace string) *unstructured.Unstructured {
+	gw := kn.Spec.Web.Gateway
+	if gw == nil {
+		return nil
+	}
+
+	parentRefs := make([]interface{}, 0, len(gw.ParentRefs)+1)
+	if kn.IsGatewayListenerEnabled() {
+		parentRefs = append(parentRefs, map[string]interface{}{
+			"name": "kube-nova-web",
+		})
+	}
+	for _, ref := range gw.ParentRefs {
+		parentRef := map[string]interface{}{
+			"name": ref.Name,
+		}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	hostnames := make([]interface{}, 0, len(gw.Hostnames))
+	for _, host := range gw.Hostnames {
+		hostnames = append(hostnames, host)
+	}
+
+	wsMatches := make([]interface{}, 0, len(gatewayWebSocketPaths))
+	for _, path := range gatewayWebSocketPaths {
+		wsMatches = append(wsMatches, buildHTTPRoutePathMatch(path))
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(HTTPRouteGVK)
+	route.SetName("kube-nova-web")
+	route.SetNamespace(namespace)
+	route.SetLabels(getWebLabels(kn))
+	if len(gw.Annotations) > 0 {
+		route.SetAnnotations(gw.Annotations)
+	}
+	route.Object["spec"] = map[string]interface{}{
+		"parentRefs": parentRefs,
+		"hostnames":  hostnames,
+		"rules": []interface{}{
+			// WebSocket 路由：长连接不设置超时
+			map[string]interface{}{
+				"matches":     wsMatches,
+				"backendRefs": buildHTTPRouteBackendRefs(),
+				"timeouts": map[string]interface{}{
+					"request":        gatewayWebSocketTimeout,
+					"backendRequest": gatewayWebSocketTimeout,
+				},
+			},
+			// 其他请求全部转发给 Web Nginx
+			map[string]interface{}{
+				"matches": []interface{}{
+					buildHTTPRoutePathMatch("/"),
+				},
+				"backendRefs": buildHTTPRouteBackendRefs(),
+				"timeouts": map[string]interface{}{
+					"request": gatewayRequestTimeout,
+				},
+			},
+		},
+	}
+
+	return route
+}
+
+// buildWebGateway 构建 Web Gateway(仅在启用监听器时创建)
+func buildWebGateway(kn *kubenovav1.KubeNova, namespace string) *unstructured.Unstructured {
+	if !kn.IsGatewayListenerEnabled() {
+		return nil
+	}
+
+	gw := kn.Spec.Web.Gateway
+	listener := gw.Listener
+
+	httpPort := listener.HTTPPort
+	if httpPort == 0 {
+		httpPort = 80
+	}
+	httpsPort := listener.HTTPSPort
+	if httpsPort == 0 {
+		httpsPort = 443
+	}
+
+	// 监听器只能绑定单个域名，多域名时不限制监听器域名，由 HTTPRoute 匹配
+	withHostname := func(l map[string]interface{}) map[string]interface{} {
+		if len(gw.Hostnames) == 1 {
+			l["hostname"] = gw.Hostnames[0]
+		}
+		return l
+	}
+
+	listeners := []interface{}{
+		withHostname(map[string]interface{}{
+			"name":     "http",
+			"protocol": "HTTP",
+			"port":     int64(httpPort),
+			"allowedRoutes": map[string]interface{}{
+				"namespaces": map[string]interface{}{"from": "Same"},
+			},
+		}),
+	}
+
+	if gw.TLS != nil && gw.TLS.Enabled {
+		certRefs := make([]interface{}, 0, len(gw.TLS.CertificateRefs))
+		for _, name := range gw.TLS.CertificateRefs {
+			certRefs = append(certRefs, map[string]interface{}{
+				"kind": "Secret",
+				"name": name,
+			})
+		}
+		listeners = append(listeners, withHostname(map[string]interface{}{
+			"name":     "https",
+			"protocol": "HTTPS",
+			"port":     int64(httpsPort),
+			"tls": map[string]interface{}{
+				"mode":            "Terminate",
+				"certificateRefs": certRefs,
+			},
+			"allowedRoutes": map[string]interface{}{
+				"namespaces": map[string]interface{}{"from": "Same"},
+			},
+		}))
+	}
+
+	gateway := &unstructured.Unstructured{}
+	gateway.SetGroupVersionKind(GatewayGVK)
+	gateway.SetName("kube-nova-web")
+	gateway.SetNamespace(namespace)
+	gateway.SetLabels(getWebLabels(kn))
+	gateway.Object["spec"] = map[string]interface{}{
+		"gatewayClassName": listener.GatewayClassName,
+		"listeners":        listeners,
+	}
+
+	return gateway
+}
+
+// buildHTTPRoutePathMatch 构建路径前缀匹配
+func buildHTTPRoutePathMatch(path string) map[string]interface{} {
+	return map[string]interface{}{
+		"path": map[string]interface{}{
+			"type":  "PathPrefix",
+			"value": path,
+		},
+	}
+}
+
+// buildHTTPRouteBackendRefs 构建指向 Web Service 的后端引用
+func buildHTTPRouteBackendRefs() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": "kube-nova-web",
+			"port": int64(80),
+		},
+	}
+}
+
+// getWebLabels 获取 Web 资源标签
+func getWebLabels(kn *kubenovav1.KubeNova) map[string]string {
+	return map[string]string{
+		"app":                          "kube-nova-web",
+		"tier":                         "frontend",
+		"app.kubernetes.io/name":       "kube-nova",
+		"app.kubernetes.io/instance":   kn.Name,
+		"app.kubernetes.io/component":  "web",
+		"app.kubernetes.io/managed-by": "kube-nova-operator",
+	}
+}
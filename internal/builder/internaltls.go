@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// InternalCASecretName 内部 CA Secret 名称
+	InternalCASecretName = "kube-nova-internal-ca"
+
+	// InternalTLSMountPath 后端服务内部证书挂载路径
+	InternalTLSMountPath = "/app/etc/tls"
+
+	// webInternalCAMountPath Web Nginx 内部 CA 挂载路径
+	webInternalCAMountPath = "/etc/nginx/internal-ca"
+
+	// InternalTLSChecksumAnnotation 内部证书 checksum 注解
+	// 证书轮换后通过该注解触发滚动更新，使服务加载新证书
+	InternalTLSChecksumAnnotation = "kubenova.io/internal-tls-checksum"
+
+	// InternalCAPreviousKey CA Secret 中保存上一代 CA 证书的键(轮换过渡期使用)
+	InternalCAPreviousKey = "previous.crt"
+
+	// InternalCARotatedAtAnnotation CA Secret 上记录 CA 轮换时间的注解
+	// 存在时表示新信任链已发布，服务证书等待所有工作负载滚动更新后再由新 CA 签发
+	InternalCARotatedAtAnnotation = "kubenova.io/internal-ca-rotated-at"
+)
+
+// internalTLSServices 需要签发内部证书的服务
+var internalTLSServices = []string{
+	"portal-api", "portal-rpc",
+	"manager-api", "manager-rpc",
+	"workload-api",
+	"console-api", "console-rpc",
+}
+
+// InternalTLSServices 返回需要签发内部证书的服务列表
+func InternalTLSServices() []string {
+	return append([]string(nil), internalTLSServices...)
+}
+
+// InternalTLSSecretName 获取服务内部证书 Secret 名称
+func InternalTLSSecretName(service string) string {
+	return service + "-internal-tls"
+}
+
+// InternalTLSDNSNames 获取服务内部证书的 DNS 名称
+func InternalTLSDNSNames(service, namespace string) []string {
+	return []string{
+		service,
+		fmt.Sprintf("%s.%s", service, namespace),
+		fmt.Sprintf("%s.%s.svc", service, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+	}
+}
+
+// BuildInternalTLSSecret 构建内部证书 Secret
+func BuildInternalTLSSecret(kn *kubenovav1.KubeNova, namespace, name string, data map[string][]byte) *corev1.Secret {
+	labels := getCommonLabels(kn)
+	labels["app.kubernetes.io/component"] = "internal-tls"
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	}
+}
+
+// buildInternalTLSServerConfig 构建服务端 TLS 配置
+// API 服务使用 go-zero rest 的 CertFile/KeyFile，RPC 服务使用 Tls 配置块
+func buildInternalTLSServerConfig(kn *kubenovav1.KubeNova, rpc bool) string {
+	if !kn.IsInternalTLSEnabled() {
+		return ""
+	}
+	if rpc {
+		return fmt.Sprintf(`
+Tls:
+  CertFile: %[1]s/tls.crt
+  KeyFile: %[1]s/tls.key
+  CAFile: %[1]s/ca.crt
+`, InternalTLSMountPath)
+	}
+	return fmt.Sprintf(`
+CertFile: %[1]s/tls.crt
+KeyFile: %[1]s/tls.key
+`, InternalTLSMountPath)
+}
+
+// buildRPCClientConfig 构建 RPC 客户端配置块
+// caller 为调用方的服务配置，按目标服务覆盖超时、Optional/NonBlock 和服务发现方式
+func buildRPCClientConfig(kn *kubenovav1.KubeNova, caller *kubenovav1.ServiceConfig, key, service string, port int32) string {
+	client := caller.GetRPCClient(service)
+
+	timeout := "${DEFAULT_TIMEOUT}"
+	if client != nil && client.Timeout != nil {
+		timeout = fmt.Sprintf("%d", *client.Timeout)
+	}
+
+	config := fmt.Sprintf(`%s:
+  Target: %s
+  Optional: %t
+  NonBlock: %t
+  Timeout: %s
+`, key, buildRPCTarget(kn, client.GetDiscovery(), service, port), client.IsOptional(), client.IsNonBlock(), timeout)
+
+	if kn.IsInternalTLSEnabled() {
+		config += fmt.Sprintf(`  Tls:
+    CAFile: %s/ca.crt
+    ServerName: %s
+`, InternalTLSMountPath, service)
+	}
+
+	return config
+}
+
+// buildRPCTarget 构建 RPC 客户端的 Target 地址
+func buildRPCTarget(kn *kubenovav1.KubeNova, discovery, service string, port int32) string {
+	switch discovery {
+	case kubenovav1.RPCDiscoveryDNS:
+		host := HeadlessServiceName(service)
+		if kn.GetRPCServiceConfig(service).IsHeadless() {
+			host = service
+		}
+		return fmt.Sprintf("dns:///%s.${POD_NAMESPACE}.svc:%d", host, port)
+	case kubenovav1.RPCDiscoveryDirect:
+		return fmt.Sprintf("direct:///%s.${POD_NAMESPACE}.svc:%d", service, port)
+	default:
+		return fmt.Sprintf("k8s://${POD_NAMESPACE}/%s:%d", service, port)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"slices"
+	"testing"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/nginxconf"
+)
+
+func TestBuildLocationBlocksInternalTLS(t *testing.T) {
+	tests := []struct {
+		header  string
+		service string
+		want    string
+	}{
+		{header: "location /ws/v1/pod", service: "console-api", want: "proxy_pass https://console_api;"},
+		{header: "location /ws/v1/site-messages", service: "portal-api", want: "proxy_pass https://portal_api;"},
+		{header: "location /portal", service: "portal-api", want: "proxy_pass https://portal_api;"},
+		{header: "location /manager", service: "manager-api", want: "proxy_pass https://manager_api;"},
+		{header: "location /workload", service: "workload-api", want: "proxy_pass https://workload_api;"},
+		{header: "location /console", service: "console-api", want: "proxy_pass https://console_api;"},
+	}
+
+	kn := newAccessControlKubeNova("nodeport", nil)
+	kn.Spec.InternalTLS = &kubenovav1.InternalTLSConfig{Enabled: true}
+	config := buildDefaultConf(kn, "kube-nova")
+	if err := nginxconf.Validate(buildNginxConf(kn), config); err != nil {
+		t.Fatalf("rendered config is invalid: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			lines := locationBlock(t, config, tt.header)
+			for _, want := range []string{tt.want, "proxy_ssl_name " + tt.service + ";", "proxy_ssl_verify on;"} {
+				if !slices.Contains(lines, want) {
+					t.Errorf("%s directives = %q, want containing %q", tt.header, lines, want)
+				}
+			}
+		})
+	}
+
+	kn.Spec.InternalTLS = nil
+	lines := locationBlock(t, buildDefaultConf(kn, "kube-nova"), "location /portal")
+	if !slices.Contains(lines, "proxy_pass http://portal_api;") || slices.Contains(lines, "proxy_ssl_verify on;") {
+		t.Errorf("location /portal directives without internal TLS = %q", lines)
+	}
+}
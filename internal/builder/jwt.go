@@ -0,0 +1,114 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// JWTSecretName 自动生成的 JWT 密钥 Secret 名称
+	JWTSecretName = "kube-nova-jwt"
+
+	// JWTAccessSecretKey 访问令牌密钥
+	JWTAccessSecretKey = "access-secret"
+	// JWTRefreshSecretKey 刷新令牌密钥
+	JWTRefreshSecretKey = "refresh-secret"
+	// JWTAccessSecretPreviousKey 轮换前的访问令牌密钥
+	JWTAccessSecretPreviousKey = "access-secret-previous"
+	// JWTRefreshSecretPreviousKey 轮换前的刷新令牌密钥
+	JWTRefreshSecretPreviousKey = "refresh-secret-previous"
+
+	// JWTRotatedAtAnnotation 最近一次生成或轮换密钥的时间
+	JWTRotatedAtAnnotation = "kubenova.io/jwt-rotated-at"
+	// JWTPreviousExpiresAtAnnotation 旧密钥停止发布的时间
+	JWTPreviousExpiresAtAnnotation = "kubenova.io/jwt-previous-expires-at"
+
+	// JWTChecksumAnnotation JWT 密钥 checksum 注解
+	// 密钥轮换后通过该注解触发 portal-rpc 滚动更新，使服务加载新密钥
+	JWTChecksumAnnotation = "kubenova.io/jwt-checksum"
+)
+
+// JWTSecretState 自动生成的 JWT 密钥及轮换时间
+type JWTSecretState struct {
+	Data map[string][]byte
+	// RotatedAt 最近一次生成或轮换密钥的时间
+	RotatedAt time.Time
+	// PreviousExpiresAt 旧密钥停止发布的时间，零值表示没有旧密钥
+	PreviousExpiresAt time.Time
+}
+
+// BuildJWTSecret 构建自动生成的 JWT 密钥 Secret
+// 轮换时间记录在注解中，Operator 重启后仍能按计划轮换
+func BuildJWTSecret(kn *kubenovav1.KubeNova, namespace string, state *JWTSecretState) *corev1.Secret {
+	annotations := map[string]string{
+		JWTRotatedAtAnnotation: state.RotatedAt.UTC().Format(time.RFC3339),
+	}
+	if !state.PreviousExpiresAt.IsZero() {
+		annotations[JWTPreviousExpiresAtAnnotation] = state.PreviousExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	labels := getCommonLabels(kn)
+	labels["app.kubernetes.io/component"] = "jwt"
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        JWTSecretName,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: state.Data,
+	}
+}
+
+// ParseJWTSecretState 从已有 Secret 中读取 JWT 密钥及轮换时间
+func ParseJWTSecretState(secret *corev1.Secret) *JWTSecretState {
+	state := &JWTSecretState{Data: make(map[string][]byte, len(secret.Data))}
+	for k, v := range secret.Data {
+		state.Data[k] = v
+	}
+	if t, err := time.Parse(time.RFC3339, secret.Annotations[JWTRotatedAtAnnotation]); err == nil {
+		state.RotatedAt = t
+	}
+	if t, err := time.Parse(time.RFC3339, secret.Annotations[JWTPreviousExpiresAtAnnotation]); err == nil {
+		state.PreviousExpiresAt = t
+	}
+	return state
+}
+
+// ApplyJWTSecret 将自动生成的 JWT 密钥写入 kube-nova-secret
+// 用户显式配置的密钥优先
+func ApplyJWTSecret(kn *kubenovav1.KubeNova, secret *corev1.Secret, state *JWTSecretState) {
+	if state == nil {
+		return
+	}
+	if kn.IsJWTAccessSecretGenerated() {
+		secret.Data["JWT_ACCESS_SECRET"] = []byte(string(state.Data[JWTAccessSecretKey]))
+		secret.Data["JWT_ACCESS_SECRET_PREVIOUS"] = []byte(string(state.Data[JWTAccessSecretPreviousKey]))
+	}
+	if kn.IsJWTRefreshSecretGenerated() {
+		secret.Data["JWT_REFRESH_SECRET"] = []byte(string(state.Data[JWTRefreshSecretKey]))
+		secret.Data["JWT_REFRESH_SECRET_PREVIOUS"] = []byte(string(state.Data[JWTRefreshSecretPreviousKey]))
+	}
+}
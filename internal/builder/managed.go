@@ -0,0 +1,418 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"path"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// ManagedCredentialsSecretName 托管依赖的凭据 Secret 名称
+	// 凭据生成后不再删除，关闭 managed 后保留的 PVC 重新启用时仍能使用原密码
+	ManagedCredentialsSecretName = "kube-nova-managed-credentials"
+
+	// ManagedMySQLRootPasswordKey MySQL root 密码
+	ManagedMySQLRootPasswordKey = "mysql-root-password"
+	// ManagedMySQLPasswordKey MySQL 业务用户密码
+	ManagedMySQLPasswordKey = "mysql-password"
+	// ManagedRedisPasswordKey Redis 密码
+	ManagedRedisPasswordKey = "redis-password"
+	// ManagedMinIORootUserKey MinIO root 用户
+	ManagedMinIORootUserKey = "minio-root-user"
+	// ManagedMinIORootPasswordKey MinIO root 密码
+	ManagedMinIORootPasswordKey = "minio-root-password"
+
+	// defaultMySQLImage 托管 MySQL 默认镜像
+	defaultMySQLImage = "mysql:8.0"
+	// defaultRedisImage 托管 Redis 默认镜像
+	defaultRedisImage = "redis:7.2"
+	// defaultMinIOImage 托管 MinIO 默认镜像
+	defaultMinIOImage = "minio/minio:RELEASE.2025-04-22T22-12-26Z"
+
+	// managedDataVolume 托管依赖数据卷名称
+	managedDataVolume = "data"
+)
+
+// ManagedDependencyNames 所有托管依赖的资源名称(用于关闭 managed 后清理)
+var ManagedDependencyNames = []string{
+	kubenovav1.ManagedMySQLName,
+	kubenovav1.ManagedRedisName,
+	kubenovav1.ManagedMinIOName,
+}
+
+// ManagedDependencyResources 托管依赖资源
+type ManagedDependencyResources struct {
+	// Name 依赖名称：mysql、redis 或 minio
+	Name string
+	// Endpoint 集群内访问地址
+	Endpoint    string
+	StatefulSet *appsv1.StatefulSet
+	Service     *corev1.Service
+}
+
+// ManagedCredentialKeys 获取已启用的托管依赖需要的凭据 key
+func ManagedCredentialKeys(kn *kubenovav1.KubeNova) []string {
+	var keys []string
+	if kn.Spec.Database.Managed {
+		keys = append(keys, ManagedMySQLRootPasswordKey, ManagedMySQLPasswordKey)
+	}
+	if kn.Spec.Cache.Managed {
+		keys = append(keys, ManagedRedisPasswordKey)
+	}
+	if kn.Spec.Storage.Managed {
+		keys = append(keys, ManagedMinIORootUserKey, ManagedMinIORootPasswordKey)
+	}
+	return keys
+}
+
+// BuildManagedCredentialsSecret 构建托管依赖的凭据 Secret
+func BuildManagedCredentialsSecret(kn *kubenovav1.KubeNova, namespace string, data map[string][]byte) *corev1.Secret {
+	labels := getCommonLabels(kn)
+	labels["app.kubernetes.io/component"] = "managed-dependencies"
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagedCredentialsSecretName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+// managedCredentialSecretKeys 托管依赖凭据写入 kube-nova-secret 时对应的键
+var managedCredentialSecretKeys = map[string]string{
+	ManagedMySQLPasswordKey:     "MYSQL_PASSWORD",
+	ManagedRedisPasswordKey:     "REDIS_PASSWORD",
+	ManagedMinIORootUserKey:     "MINIO_ACCESS_KEY",
+	ManagedMinIORootPasswordKey: "MINIO_SECRET_KEY",
+}
+
+// ApplyManagedCredentials 将托管依赖的凭据写入 kube-nova-secret
+// 已启用的托管依赖缺少任一凭据时返回错误，避免服务使用空密码连接
+func ApplyManagedCredentials(kn *kubenovav1.KubeNova, secret *corev1.Secret, credentials map[string][]byte) error {
+	keys := ManagedCredentialKeys(kn)
+	for _, key := range keys {
+		if len(credentials[key]) == 0 {
+			return fmt.Errorf("托管依赖凭据 %s 缺少 %s", ManagedCredentialsSecretName, key)
+		}
+	}
+	for _, key := range keys {
+		if secretKey, ok := managedCredentialSecretKeys[key]; ok {
+			secret.Data[secretKey] = credentials[key]
+		}
+	}
+	return nil
+}
+
+// BuildManagedDependencies 构建已启用的托管依赖
+func BuildManagedDependencies(kn *kubenovav1.KubeNova, namespace string) []*ManagedDependencyResources {
+	var deps []*ManagedDependencyResources
+	if kn.Spec.Database.Managed {
+		deps = append(deps, buildManagedMySQL(kn, namespace))
+	}
+	if kn.Spec.Cache.Managed {
+		deps = append(deps, buildManagedRedis(kn, namespace))
+	}
+	if kn.Spec.Storage.Managed {
+		deps = append(deps, buildManagedMinIO(kn, namespace))
+	}
+	return deps
+}
+
+// buildManagedMySQL 构建托管 MySQL
+// 只有正式实例启动后才会监听 TCP 端口(初始化阶段使用 --skip-networking)，探针检查端口即可
+func buildManagedMySQL(kn *kubenovav1.KubeNova, namespace string) *ManagedDependencyResources {
+	container := corev1.Container{
+		Name:  "mysql",
+		Image: getManagedImage(kn, kn.Spec.Database.Instance, defaultMySQLImage),
+		Args: []string{
+			"--character-set-server=utf8mb4",
+			"--collation-server=utf8mb4_unicode_ci",
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: "mysql", ContainerPort: 3306, Protocol: corev1.ProtocolTCP},
+		},
+		Env: []corev1.EnvVar{
+			managedSecretEnv("MYSQL_ROOT_PASSWORD", ManagedMySQLRootPasswordKey),
+			{Name: "MYSQL_DATABASE", Value: kn.GetDatabaseName()},
+			{Name: "MYSQL_USER", Value: kn.GetDatabaseUser()},
+			managedSecretEnv("MYSQL_PASSWORD", ManagedMySQLPasswordKey),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: managedDataVolume, MountPath: "/var/lib/mysql"},
+		},
+		ReadinessProbe: managedTCPProbe(3306, 10),
+		LivenessProbe:  managedTCPProbe(3306, 120),
+	}
+
+	return &ManagedDependencyResources{
+		Name:        "mysql",
+		Endpoint:    fmt.Sprintf("%s:%d", kn.GetDatabaseHost(), kn.GetDatabasePort()),
+		StatefulSet: buildManagedStatefulSet(kn, namespace, kubenovav1.ManagedMySQLName, "mysql", kn.Spec.Database.Instance, container),
+		Service:     buildManagedService(kn, namespace, kubenovav1.ManagedMySQLName, "mysql", container.Ports),
+	}
+}
+
+// buildManagedRedis 构建托管 Redis(开启 AOF 持久化)
+func buildManagedRedis(kn *kubenovav1.KubeNova, namespace string) *ManagedDependencyResources {
+	container := corev1.Container{
+		Name:  "redis",
+		Image: getManagedImage(kn, kn.Spec.Cache.Instance, defaultRedisImage),
+		Args: []string{
+			"--requirepass", "$(REDIS_PASSWORD)",
+			"--appendonly", "yes",
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: "redis", ContainerPort: 6379, Protocol: corev1.ProtocolTCP},
+		},
+		Env: []corev1.EnvVar{
+			managedSecretEnv("REDIS_PASSWORD", ManagedRedisPasswordKey),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: managedDataVolume, MountPath: "/data"},
+		},
+		ReadinessProbe: managedTCPProbe(6379, 5),
+		LivenessProbe:  managedTCPProbe(6379, 30),
+	}
+
+	return &ManagedDependencyResources{
+		Name:        "redis",
+		Endpoint:    fmt.Sprintf("%s:%d", kn.GetCacheHost(), kn.GetCachePort()),
+		StatefulSet: buildManagedStatefulSet(kn, namespace, kubenovav1.ManagedRedisName, "redis", kn.Spec.Cache.Instance, container),
+		Service:     buildManagedService(kn, namespace, kubenovav1.ManagedRedisName, "redis", container.Ports),
+	}
+}
+
+// buildManagedMinIO 构建托管 MinIO
+// 启动后通过 postStart 钩子使用镜像自带的 mc 创建存储桶
+func buildManagedMinIO(kn *kubenovav1.KubeNova, namespace string) *ManagedDependencyResources {
+	createBucket := fmt.Sprintf(`until mc alias set local http://127.0.0.1:9000 "$MINIO_ROOT_USER" "$MINIO_ROOT_PASSWORD" >/dev/null 2>&1; do sleep 2; done; mc mb --ignore-existing local/%s`,
+		kn.GetStorageBucket())
+
+	container := corev1.Container{
+		Name:  "minio",
+		Image: getManagedImage(kn, kn.Spec.Storage.Instance, defaultMinIOImage),
+		Args:  []string{"server", "/data", "--console-address", ":9001"},
+		Ports: []corev1.ContainerPort{
+			{Name: "api", ContainerPort: 9000, Protocol: corev1.ProtocolTCP},
+			{Name: "console", ContainerPort: 9001, Protocol: corev1.ProtocolTCP},
+		},
+		Env: []corev1.EnvVar{
+			managedSecretEnv("MINIO_ROOT_USER", ManagedMinIORootUserKey),
+			managedSecretEnv("MINIO_ROOT_PASSWORD", ManagedMinIORootPasswordKey),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: managedDataVolume, MountPath: "/data"},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/minio/health/ready",
+					Port: intstr.FromInt32(9000),
+				},
+			},
+			InitialDelaySeconds: 5,
+			PeriodSeconds:       10,
+		},
+		LivenessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/minio/health/live",
+					Port: intstr.FromInt32(9000),
+				},
+			},
+			InitialDelaySeconds: 30,
+			PeriodSeconds:       10,
+		},
+		Lifecycle: &corev1.Lifecycle{
+			PostStart: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"sh", "-c", createBucket},
+				},
+			},
+		},
+	}
+
+	return &ManagedDependencyResources{
+		Name:        "minio",
+		Endpoint:    kn.GetStorageAddress(),
+		StatefulSet: buildManagedStatefulSet(kn, namespace, kubenovav1.ManagedMinIOName, "minio", kn.Spec.Storage.Instance, container),
+		Service:     buildManagedService(kn, namespace, kubenovav1.ManagedMinIOName, "minio", container.Ports),
+	}
+}
+
+// getManagedLabels 获取托管依赖的标签
+func getManagedLabels(kn *kubenovav1.KubeNova, name, component string) map[string]string {
+	labels := getCommonLabels(kn)
+	labels["app"] = name
+	labels["app.kubernetes.io/component"] = component
+	return labels
+}
+
+// buildManagedStatefulSet 构建单副本 StatefulSet，数据卷由 volumeClaimTemplates 创建
+// 删除 StatefulSet 时保留 PVC，避免误删评估数据；PVC 带有实例标签，删除 KubeNova 时按 deletionPolicy 处理
+func buildManagedStatefulSet(kn *kubenovav1.KubeNova, namespace, name, component string, instance *kubenovav1.ManagedInstanceConfig, container corev1.Container) *appsv1.StatefulSet {
+	if instance != nil && instance.Resources != nil {
+		container.Resources = *instance.Resources
+	}
+
+	labels := getManagedLabels(kn, name, component)
+	claim := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   managedDataVolume,
+			Labels: labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: getManagedStorageSize(instance),
+				},
+			},
+		},
+	}
+	if instance != nil {
+		claim.Spec.StorageClassName = instance.StorageClassName
+	}
+
+	var imagePullSecrets []corev1.LocalObjectReference
+	for _, secret := range kn.GetImageRegistry().PullSecrets {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    int32Ptr(1),
+			ServiceName: name,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers:       []corev1.Container{container},
+					ImagePullSecrets: imagePullSecrets,
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{claim},
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+		},
+	}
+}
+
+// buildManagedService 构建托管依赖的 Service
+func buildManagedService(kn *kubenovav1.KubeNova, namespace, name, component string, containerPorts []corev1.ContainerPort) *corev1.Service {
+	ports := make([]corev1.ServicePort, 0, len(containerPorts))
+	for _, p := range containerPorts {
+		ports = append(ports, corev1.ServicePort{
+			Name:       p.Name,
+			Port:       p.ContainerPort,
+			TargetPort: intstr.FromString(p.Name),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    getManagedLabels(kn, name, component),
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{
+				"app": name,
+			},
+			Ports: ports,
+		},
+	}
+}
+
+// managedSecretEnv 构建引用凭据 Secret 的环境变量
+func managedSecretEnv(name, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: ManagedCredentialsSecretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// managedTCPProbe 构建检查端口是否可连接的探针
+func managedTCPProbe(port int32, initialDelaySeconds int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt32(port),
+			},
+		},
+		InitialDelaySeconds: initialDelaySeconds,
+		PeriodSeconds:       10,
+	}
+}
+
+// getManagedImage 获取托管依赖的镜像
+func getManagedImage(kn *kubenovav1.KubeNova, instance *kubenovav1.ManagedInstanceConfig, defaultImage string) string {
+	if instance != nil && instance.Image != "" {
+		return instance.Image
+	}
+	return getMirroredImage(kn, defaultImage)
+}
+
+// getMirroredImage 获取 Operator 内置的第三方默认镜像
+// 配置了 imageRegistry 时从该仓库拉取同名镜像，例如 minio/minio:TAG 对应 <registry>/<organization>/minio:TAG
+func getMirroredImage(kn *kubenovav1.KubeNova, image string) string {
+	if kn.Spec.ImageRegistry == nil {
+		return image
+	}
+	registry := kn.GetImageRegistry()
+	return fmt.Sprintf("%s/%s/%s", registry.Registry, registry.Organization, path.Base(image))
+}
+
+// getManagedStorageSize 获取托管依赖的数据卷大小
+func getManagedStorageSize(instance *kubenovav1.ManagedInstanceConfig) resource.Quantity {
+	if instance != nil && instance.StorageSize != nil && !instance.StorageSize.IsZero() {
+		return *instance.StorageSize
+	}
+	return resource.MustParse("10Gi")
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+func newManagedKubeNova(registry *kubenovav1.ImageRegistryConfig) *kubenovav1.KubeNova {
+	return &kubenovav1.KubeNova{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-nova", Namespace: "kube-nova"},
+		Spec: kubenovav1.KubeNovaSpec{
+			ImageRegistry: registry,
+			Database:      kubenovav1.DatabaseConfig{Managed: true},
+			Cache:         kubenovav1.CacheConfig{Managed: true},
+			Storage:       kubenovav1.StorageConfig{Managed: true},
+		},
+	}
+}
+
+func TestBuildManagedDependenciesImages(t *testing.T) {
+	mirror := &kubenovav1.ImageRegistryConfig{
+		Registry:     "harbor.example.com",
+		Organization: "mirror",
+		PullSecrets:  []string{"harbor"},
+	}
+
+	tests := []struct {
+		name        string
+		registry    *kubenovav1.ImageRegistryConfig
+		instance    *kubenovav1.ManagedInstanceConfig
+		want        map[string]string
+		wantSecrets []corev1.LocalObjectReference
+	}{
+		{
+			name: "default images",
+			want: map[string]string{
+				"mysql": defaultMySQLImage,
+				"redis": defaultRedisImage,
+				"minio": defaultMinIOImage,
+			},
+		},
+		{
+			name:     "mirrored images",
+			registry: mirror,
+			want: map[string]string{
+				"mysql": "harbor.example.com/mirror/mysql:8.0",
+				"redis": "harbor.example.com/mirror/redis:7.2",
+				"minio": "harbor.example.com/mirror/minio:RELEASE.2025-04-22T22-12-26Z",
+			},
+			wantSecrets: []corev1.LocalObjectReference{{Name: "harbor"}},
+		},
+		{
+			name:     "explicit image is not mirrored",
+			registry: mirror,
+			instance: &kubenovav1.ManagedInstanceConfig{Image: "docker.io/library/mysql:8.4"},
+			want: map[string]string{
+				"mysql": "docker.io/library/mysql:8.4",
+				"redis": "harbor.example.com/mirror/redis:7.2",
+				"minio": "harbor.example.com/mirror/minio:RELEASE.2025-04-22T22-12-26Z",
+			},
+			wantSecrets: []corev1.LocalObjectReference{{Name: "harbor"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kn := newManagedKubeNova(tt.registry)
+			kn.Spec.Database.Instance = tt.instance
+
+			deps := BuildManagedDependencies(kn, "kube-nova")
+			if len(deps) != 3 {
+				t.Fatalf("BuildManagedDependencies() returned %d dependencies, want 3", len(deps))
+			}
+			for _, dep := range deps {
+				podSpec := dep.StatefulSet.Spec.Template.Spec
+				if got := podSpec.Containers[0].Image; got != tt.want[dep.Name] {
+					t.Errorf("%s image = %s, want %s", dep.Name, got, tt.want[dep.Name])
+				}
+				if len(podSpec.ImagePullSecrets) != len(tt.wantSecrets) ||
+					(len(tt.wantSecrets) > 0 && podSpec.ImagePullSecrets[0] != tt.wantSecrets[0]) {
+					t.Errorf("%s imagePullSecrets = %+v, want %+v", dep.Name, podSpec.ImagePullSecrets, tt.wantSecrets)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildManagedStatefulSetClaimLabels(t *testing.T) {
+	kn := newManagedKubeNova(nil)
+
+	for _, dep := range BuildManagedDependencies(kn, "kube-nova") {
+		claims := dep.StatefulSet.Spec.VolumeClaimTemplates
+		if len(claims) != 1 {
+			t.Fatalf("%s volumeClaimTemplates = %d, want 1", dep.Name, len(claims))
+		}
+		labels := claims[0].Labels
+		if labels["app.kubernetes.io/instance"] != kn.Name ||
+			labels["app.kubernetes.io/managed-by"] != "kube-nova-operator" ||
+			labels["app"] != dep.StatefulSet.Name {
+			t.Errorf("%s claim labels = %v", dep.Name, labels)
+		}
+	}
+}
+
+func TestBuildDemoResetCronJobMirroredImages(t *testing.T) {
+	kn := newManagedKubeNova(&kubenovav1.ImageRegistryConfig{Registry: "harbor.example.com", Organization: "mirror"})
+	kn.Spec.Services.Portal = &kubenovav1.PortalConfig{
+		DemoMode: true,
+		Demo: &kubenovav1.DemoConfig{
+			Seed: kubenovav1.DemoSeedConfig{Bucket: "seed", SQLObject: "seed.sql.gz", ObjectsPrefix: "objects/"},
+		},
+	}
+
+	cronJob := BuildDemoResetCronJob(kn, "kube-nova")
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	images := make(map[string]bool)
+	for _, c := range append(podSpec.InitContainers, podSpec.Containers...) {
+		images[c.Image] = true
+	}
+	for _, want := range []string{
+		"harbor.example.com/mirror/mysql:8.0",
+		"harbor.example.com/mirror/mc:RELEASE.2025-04-16T18-13-26Z",
+	} {
+		if !images[want] {
+			t.Errorf("demo reset images = %v, want containing %s", images, want)
+		}
+	}
+}
+
+func TestApplyManagedCredentials(t *testing.T) {
+	kn := newManagedKubeNova(nil)
+	credentials := map[string][]byte{
+		ManagedMySQLRootPasswordKey: []byte("root"),
+		ManagedMySQLPasswordKey:     []byte("mysql"),
+		ManagedRedisPasswordKey:     []byte("redis"),
+		ManagedMinIORootUserKey:     []byte("minio-user"),
+		ManagedMinIORootPasswordKey: []byte("minio-password"),
+	}
+
+	secret := BuildSecret(kn, "kube-nova", "", 0)
+	if err := ApplyManagedCredentials(kn, secret, credentials); err != nil {
+		t.Fatalf("ApplyManagedCredentials() error = %v", err)
+	}
+	for key, want := range map[string]string{
+		"MYSQL_PASSWORD":   "mysql",
+		"REDIS_PASSWORD":   "redis",
+		"MINIO_ACCESS_KEY": "minio-user",
+		"MINIO_SECRET_KEY": "minio-password",
+	} {
+		if got := string(secret.Data[key]); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+
+	delete(credentials, ManagedRedisPasswordKey)
+	secret = BuildSecret(kn, "kube-nova", "", 0)
+	if err := ApplyManagedCredentials(kn, secret, credentials); err == nil {
+		t.Fatalf("ApplyManagedCredentials() error = nil, want missing %s", ManagedRedisPasswordKey)
+	}
+}
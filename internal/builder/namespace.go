@@ -0,0 +1,51 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+// BuildNamespace 构建目标命名空间
+func BuildNamespace(kn *kubenovav1.KubeNova) *corev1.Namespace {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "kube-nova",
+		"app.kubernetes.io/instance":   kn.Name,
+		"app.kubernetes.io/managed-by": "kube-nova-operator",
+	}
+	annotations := map[string]string{}
+
+	if opts := kn.Spec.TargetNamespaceOptions; opts != nil {
+		for k, v := range opts.Labels {
+			labels[k] = v
+		}
+		for k, v := range opts.Annotations {
+			annotations[k] = v
+		}
+	}
+
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kn.GetTargetNamespace(),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+}
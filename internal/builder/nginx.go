@@ -16,7 +16,10 @@ limitations under the License.
 package builder
 
 import (
+	"encoding/json"
 	"fmt"
+	"html"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,32 +29,34 @@ import (
 
 // buildNginxConfigMap 构建 Nginx ConfigMap
 func buildNginxConfigMap(kn *kubenovav1.KubeNova, namespace string) *corev1.ConfigMap {
-	nginxConf := buildNginxConf()
+	nginxConf := buildNginxConf(kn)
 	defaultConf := buildDefaultConf(kn, namespace)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "frontend-nginx-config",
+			Name:      NginxConfigMapName,
 			Namespace: namespace,
 			Labels:    getCommonLabels(kn),
 		},
-		Data: map[string]string{
+		Data: withNginxBootstrap(kn, map[string]string{
 			"nginx.conf":   nginxConf,
 			"default.conf": defaultConf,
-		},
+		}),
 	}
 }
 
 // buildNginxConf 构建 nginx.conf
-func buildNginxConf() string {
-	return `# Nginx main configuration file
-worker_processes auto;
-worker_rlimit_nofile 65535;
+func buildNginxConf(kn *kubenovav1.KubeNova) string {
+	nginx := kn.Spec.Web.GetNginxConfig()
+
+	config := fmt.Sprintf(`# Nginx main configuration file
+worker_processes %s;
+worker_rlimit_nofile %d;
 
 error_log /var/log/nginx/error.log warn;
 
 events {
-    worker_connections 4096;
+    worker_connections %d;
     use epoll;
     multi_accept on;
 }
@@ -59,7 +64,11 @@ events {
 http {
     include /etc/nginx/mime.types;
     default_type application/octet-stream;
+`, nginx.GetWorkerProcesses(), nginx.GetWorkerRlimitNofile(), nginx.GetWorkerConnections())
 
+	config += buildRealIPConf(nginx)
+
+	config += `
     # Logging format
     log_format main '$remote_addr - $remote_user [$time_local] "$request" '
                     '$status $body_bytes_sent "$http_referer" '
@@ -85,20 +94,22 @@ http {
                     '"upstream_header_time":"$upstream_header_time"'
                     '}';
 
-    access_log /var/log/nginx/access.log main;
+    ` + buildAccessLogDirective(nginx, "/var/log/nginx/access.log") + `
+`
 
+	config += fmt.Sprintf(`
     # Performance optimization
     sendfile on;
     tcp_nopush on;
     tcp_nodelay on;
-    keepalive_timeout 65;
-    keepalive_requests 100;
+    keepalive_timeout %s;
+    keepalive_requests %d;
     types_hash_max_size 2048;
     server_tokens off;
 
     # Buffer settings
     client_body_buffer_size 128k;
-    client_max_body_size 1024m;
+    client_max_body_size %s;
     client_header_buffer_size 1k;
     large_client_header_buffers 4 16k;
     output_buffers 1 32k;
@@ -108,20 +119,31 @@ http {
     client_header_timeout 15s;
     client_body_timeout 15s;
     send_timeout 15s;
+`, nginx.GetKeepaliveTimeout(), nginx.GetKeepaliveRequests(), nginx.GetClientMaxBodySize())
 
+	if nginx.IsGzipEnabled() {
+		config += fmt.Sprintf(`
     # Gzip compression
     gzip on;
     gzip_vary on;
     gzip_proxied any;
-    gzip_comp_level 6;
+    gzip_comp_level %d;
     gzip_types text/plain text/css text/xml text/javascript
                application/json application/javascript application/xml+rss
                application/rss+xml font/truetype font/opentype
                application/vnd.ms-fontobject image/svg+xml;
     gzip_disable "msie6";
-    gzip_min_length 1000;
+    gzip_min_length %d;
     gzip_buffers 16 8k;
+`, nginx.GetGzipCompLevel(), nginx.GetGzipMinLength())
+	} else {
+		config += `
+    # Gzip compression
+    gzip off;
+`
+	}
 
+	config += fmt.Sprintf(`
     # Proxy cache settings
     proxy_cache_path /var/cache/nginx/proxy_cache levels=1:2 keys_zone=api_cache:10m max_size=100m inactive=60m use_temp_path=off;
     proxy_cache_key "$scheme$request_method$host$request_uri";
@@ -129,14 +151,60 @@ http {
     proxy_cache_valid 404 1m;
 
     # Rate limiting
-    limit_req_zone $binary_remote_addr zone=general:10m rate=100r/s;
-    limit_req_zone $binary_remote_addr zone=api:10m rate=50r/s;
+    limit_req_zone $binary_remote_addr zone=general:10m rate=%s;
+    limit_req_zone $binary_remote_addr zone=api:10m rate=%s;
     limit_conn_zone $binary_remote_addr zone=addr:10m;
+`, nginx.GetGeneralRate(), nginx.GetAPIRate())
 
+	config += buildSnippet("Custom http snippet", nginx.GetNginxSnippets().HTTP, 4)
+
+	config += `
     # Include virtual host configs
     include /etc/nginx/conf.d/*.conf;
 }
 `
+
+	return config
+}
+
+// buildRealIPConf 构建真实客户端 IP 配置
+func buildRealIPConf(nginx *kubenovav1.NginxConfig) string {
+	if !nginx.IsRealIPEnabled() {
+		return ""
+	}
+
+	config := `
+    # Real client IP (trusted proxies)
+`
+	for _, proxy := range nginx.RealIP.TrustedProxies {
+		config += fmt.Sprintf("    set_real_ip_from %s;\n", proxy)
+	}
+	config += fmt.Sprintf("    real_ip_header %s;\n", nginx.GetRealIPHeader())
+	if nginx.IsRealIPRecursive() {
+		config += "    real_ip_recursive on;\n"
+	}
+	return config
+}
+
+// buildAccessLogDirective 构建 access_log 指令
+func buildAccessLogDirective(nginx *kubenovav1.NginxConfig, path string) string {
+	format := nginx.GetAccessLogFormat()
+	if format == "off" {
+		return "access_log off;"
+	}
+	return fmt.Sprintf("access_log %s %s;", path, format)
+}
+
+// buildServerLimitDirectives 构建 server 级别的限流和连接数限制指令
+func buildServerLimitDirectives(nginx *kubenovav1.NginxConfig) string {
+	config := fmt.Sprintf(`
+    # Rate limiting
+    limit_req zone=general burst=%d nodelay;
+`, nginx.GetGeneralBurst())
+	if maxConns := nginx.GetMaxConnsPerIP(); maxConns > 0 {
+		config += fmt.Sprintf("    limit_conn addr %d;\n", maxConns)
+	}
+	return config
 }
 
 // buildDefaultConf 构建 default.conf
@@ -168,7 +236,7 @@ upstream console_api {
 `
 
 	if kn.IsMinIOProxyEnabled() {
-		storageEndpoint := kn.Spec.Storage.Endpoint
+		storageEndpoint := kn.GetStorageAddress()
 
 		upstreamConfig := fmt.Sprintf("server %s max_fails=3 fail_timeout=30s;", storageEndpoint)
 
@@ -182,6 +250,12 @@ upstream minio_backend {
 `, upstreamConfig)
 	}
 
+	// 自定义 upstream
+	config += buildExtraUpstreams(kn.Spec.Web.GetNginxConfig().GetNginxSnippets())
+
+	// CORS 来源匹配
+	config += buildCORSMap(kn)
+
 	// HTTP 服务器配置
 	config += buildHTTPServerBlock(kn)
 
@@ -237,27 +311,27 @@ server {
     # Charset
     charset utf-8;
 
-    # Security headers
-    add_header X-Frame-Options "SAMEORIGIN" always;
-    add_header X-Content-Type-Options "nosniff" always;
-    add_header X-XSS-Protection "1; mode=block" always;
-    add_header Referrer-Policy "no-referrer-when-downgrade" always;
-    add_header Permissions-Policy "geolocation=(), microphone=(), camera=()" always;
-
-    # Rate limiting
-    limit_req zone=general burst=200 nodelay;
-    limit_conn addr 20;
-
+` + buildSecurityHeaders(kn, 4) + buildServerLimitDirectives(kn.Spec.Web.GetNginxConfig()) +
+		buildServerAccessControl(kn) +
+		buildSnippet("Custom server snippet", kn.Spec.Web.GetNginxConfig().GetNginxSnippets().Server, 4) + `
     # Health check endpoint
     location /health {
         access_log off;
-        return 200 "healthy\n";
+` + buildOpenAccess(kn, 8) + `        return 200 "healthy\n";
         add_header Content-Type text/plain;
-    }
+
+` + buildSecurityHeaders(kn, 8) + `    }
 `
 
-	// 添加所有 location 块
-	config += buildLocationBlocks(kn)
+	// 外部认证使用的 location 块
+	config += buildAuthLocations(kn)
+
+	// 添加所有 location 块(维护页面模式下只返回 503 维护页面)
+	if kn.IsMaintenancePageEnabled() {
+		config += buildMaintenanceLocationBlocks(kn)
+	} else {
+		config += buildLocationBlocks(kn)
+	}
 
 	config += `}
 `
@@ -288,28 +362,27 @@ server {
     # Charset
     charset utf-8;
 
-    # Security headers
-    add_header X-Frame-Options "SAMEORIGIN" always;
-    add_header X-Content-Type-Options "nosniff" always;
-    add_header X-XSS-Protection "1; mode=block" always;
-    add_header Referrer-Policy "no-referrer-when-downgrade" always;
-    add_header Permissions-Policy "geolocation=(), microphone=(), camera=()" always;
-    add_header Strict-Transport-Security "max-age=31536000; includeSubDomains" always;
-
-    # Rate limiting
-    limit_req zone=general burst=200 nodelay;
-    limit_conn addr 20;
-
+` + buildSecurityHeaders(kn, 4) + buildServerLimitDirectives(kn.Spec.Web.GetNginxConfig()) +
+		buildServerAccessControl(kn) +
+		buildSnippet("Custom server snippet", kn.Spec.Web.GetNginxConfig().GetNginxSnippets().Server, 4) + `
     # Health check endpoint
     location /health {
         access_log off;
-        return 200 "healthy\n";
+` + buildOpenAccess(kn, 8) + `        return 200 "healthy\n";
         add_header Content-Type text/plain;
-    }
+
+` + buildSecurityHeaders(kn, 8) + `    }
 `
 
-	// 添加所有 location 块
-	config += buildLocationBlocks(kn)
+	// 外部认证使用的 location 块
+	config += buildAuthLocations(kn)
+
+	// 添加所有 location 块(维护页面模式下只返回 503 维护页面)
+	if kn.IsMaintenancePageEnabled() {
+		config += buildMaintenanceLocationBlocks(kn)
+	} else {
+		config += buildLocationBlocks(kn)
+	}
 
 	config += `}
 `
@@ -318,10 +391,15 @@ server {
 
 // buildLocationBlocks 构建所有 location 块
 func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
+	nginx := kn.Spec.Web.GetNginxConfig()
+	wsLimit := fmt.Sprintf("limit_req zone=api burst=%d nodelay;", nginx.GetWebSocketBurst())
+	apiLimit := fmt.Sprintf("limit_req zone=api burst=%d nodelay;", nginx.GetAPIBurst())
+	snippets := nginx.GetNginxSnippets()
+
 	config := `
     # WebSocket proxy for console pod
     location /ws/v1/pod {
-        proxy_pass http://console_api;
+        ` + buildAPIProxyPass(kn, "console_api", "console-api") + `
 
         # WebSocket specific headers
         proxy_http_version 1.1;
@@ -346,12 +424,12 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         proxy_request_buffering off;
 
         # Rate limiting for WebSocket
-        limit_req zone=api burst=10 nodelay;
-    }
+        ` + wsLimit + `
+` + buildLocationAccessControl(kn, "/ws/v1/pod") + buildLocationSnippet(snippets, "/ws/v1/pod") + `    }
 
     # WebSocket proxy for portal site messages
     location /ws/v1/site-messages {
-        proxy_pass http://portal_api;
+        ` + buildAPIProxyPass(kn, "portal_api", "portal-api") + `
 
         # WebSocket specific headers
         proxy_http_version 1.1;
@@ -376,12 +454,12 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         proxy_request_buffering off;
 
         # Rate limiting for WebSocket
-        limit_req zone=api burst=10 nodelay;
-    }
+        ` + wsLimit + `
+` + buildLocationAccessControl(kn, "/ws/v1/site-messages") + buildLocationSnippet(snippets, "/ws/v1/site-messages") + `    }
 
     # Portal API proxy
     location /portal {
-        proxy_pass http://portal_api;
+        ` + buildAPIProxyPass(kn, "portal_api", "portal-api") + `
 
         # Proxy headers
         proxy_set_header Host $host;
@@ -411,12 +489,12 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         proxy_next_upstream_tries 2;
 
         # Rate limiting
-        limit_req zone=api burst=20 nodelay;
-    }
+        ` + apiLimit + `
+` + buildAPIHeaders(kn) + buildLocationAccessControl(kn, "/portal") + buildLocationSnippet(snippets, "/portal") + `    }
 
     # Manager API proxy
     location /manager {
-        proxy_pass http://manager_api;
+        ` + buildAPIProxyPass(kn, "manager_api", "manager-api") + `
 
         # Proxy headers
         proxy_set_header Host $host;
@@ -446,12 +524,12 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         proxy_next_upstream_tries 2;
 
         # Rate limiting
-        limit_req zone=api burst=20 nodelay;
-    }
+        ` + apiLimit + `
+` + buildAPIHeaders(kn) + buildLocationAccessControl(kn, "/manager") + buildLocationSnippet(snippets, "/manager") + `    }
 
     # Workload API proxy
     location /workload {
-        proxy_pass http://workload_api;
+        ` + buildAPIProxyPass(kn, "workload_api", "workload-api") + `
 
         # Proxy headers
         proxy_set_header Host $host;
@@ -481,12 +559,12 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         proxy_next_upstream_tries 2;
 
         # Rate limiting
-        limit_req zone=api burst=20 nodelay;
-    }
+        ` + apiLimit + `
+` + buildAPIHeaders(kn) + buildLocationAccessControl(kn, "/workload") + buildLocationSnippet(snippets, "/workload") + `    }
 
     # Console API proxy
     location /console {
-        proxy_pass http://console_api;
+        ` + buildAPIProxyPass(kn, "console_api", "console-api") + `
 
         # Proxy headers
         proxy_set_header Host $host;
@@ -514,8 +592,8 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         proxy_next_upstream_tries 2;
 
         # Rate limiting
-        limit_req zone=api burst=20 nodelay;
-    }
+        ` + apiLimit + `
+` + buildAPIHeaders(kn) + buildLocationAccessControl(kn, "/console") + buildLocationSnippet(snippets, "/console") + `    }
 `
 
 	if kn.IsMinIOProxyEnabled() {
@@ -562,9 +640,10 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         proxy_next_upstream error timeout http_502 http_503 http_504;
         proxy_next_upstream_tries 2;
         
-        access_log /var/log/nginx/minio_access.log main;
+        %s
         error_log /var/log/nginx/minio_error.log warn;
-`, proxyScheme, pathPrefix, pathPrefix, proxyScheme)
+`, proxyScheme, pathPrefix, pathPrefix, proxyScheme,
+			buildAccessLogDirective(nginx, "/var/log/nginx/minio_access.log"))
 
 		// 如果启用了 TLS，添加 SSL 验证配置
 		if kn.Spec.Storage.TLS != nil && kn.Spec.Storage.TLS.Enabled {
@@ -577,22 +656,28 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
 `
 		}
 
+		config += buildLocationAccessControl(kn, pathPrefix) + buildLocationSnippet(snippets, pathPrefix)
 		config += `    }
 `
 	}
 
+	// 自定义 location 放在静态资源正则 location 之前，保证正则匹配优先级
+	config += buildExtraLocations(snippets)
+
 	config += `
     # Static assets - cache for 1 year
     location ~* ^/(?!storage/).*\.(jpg|jpeg|png|gif|ico|svg|webp|woff|woff2|ttf|eot|otf)$ {
-		expires 1y;
-		add_header Cache-Control "public, immutable";
-		access_log off;
-
+        expires 1y;
+        add_header Cache-Control "public, immutable";
+        access_log off;
 
+` + buildSecurityHeaders(kn, 8) + `
         # CORS for fonts
         location ~* \.(woff|woff2|ttf|eot|otf)$ {
+            add_header Cache-Control "public, immutable";
             add_header Access-Control-Allow-Origin "*";
-        }
+
+` + buildSecurityHeaders(kn, 12) + `        }
     }
 
     # JavaScript and CSS - cache for 1 year with revalidation
@@ -600,13 +685,15 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         expires 1y;
         add_header Cache-Control "public, must-revalidate";
         access_log off;
-    }
+
+` + buildSecurityHeaders(kn, 8) + `    }
 
     # HTML files - no cache
     location ~* \.html$ {
         expires -1;
         add_header Cache-Control "no-store, no-cache, must-revalidate, proxy-revalidate, max-age=0";
-    }
+
+` + buildSecurityHeaders(kn, 8) + `    }
 
     # Favicon
     location = /favicon.ico {
@@ -635,11 +722,7 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
         # Cache control for HTML
         add_header Cache-Control "no-store, no-cache, must-revalidate, proxy-revalidate, max-age=0";
 
-        # Security headers
-        add_header X-Frame-Options "SAMEORIGIN" always;
-        add_header X-Content-Type-Options "nosniff" always;
-        add_header X-XSS-Protection "1; mode=block" always;
-    }
+` + buildSecurityHeaders(kn, 8) + buildLocationAccessControl(kn, "/") + buildLocationSnippet(snippets, "/") + `    }
 
     # Error pages
     error_page 404 /index.html;
@@ -648,8 +731,153 @@ func buildLocationBlocks(kn *kubenovav1.KubeNova) string {
     location = /50x.html {
         root /usr/share/nginx/html;
         internal;
-    }
+` + buildOpenAccess(kn, 8) + `    }
 `
 
 	return config
 }
+
+// buildAPIProxyPass 构建代理到后端 API 的 proxy_pass 指令
+// 启用内部 TLS 时使用 https 并按服务名校验后端证书
+func buildAPIProxyPass(kn *kubenovav1.KubeNova, upstream, service string) string {
+	if !kn.IsInternalTLSEnabled() {
+		return fmt.Sprintf("proxy_pass http://%s;", upstream)
+	}
+
+	return fmt.Sprintf(`proxy_pass https://%s;
+
+        # Internal TLS
+        proxy_ssl_name %s;
+        proxy_ssl_verify on;
+        proxy_ssl_verify_depth 2;
+        proxy_ssl_trusted_certificate %s/ca.crt;
+        proxy_ssl_session_reuse on;`, upstream, service, webInternalCAMountPath)
+}
+
+// buildSnippet 构建自定义片段，每行按指定空格数缩进
+func buildSnippet(title, snippet string, indent int) string {
+	snippet = strings.TrimSpace(snippet)
+	if snippet == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n%s# %s\n%s\n", strings.Repeat(" ", indent), title, indentSnippet(snippet, indent))
+}
+
+// buildLocationSnippet 构建内置 location 的自定义片段
+func buildLocationSnippet(snippets *kubenovav1.NginxSnippetsConfig, path string) string {
+	return buildSnippet("Custom location snippet", snippets.GetLocationSnippet(path), 8)
+}
+
+// buildExtraUpstreams 构建自定义 upstream
+func buildExtraUpstreams(snippets *kubenovav1.NginxSnippetsConfig) string {
+	var config string
+	for _, upstream := range snippets.Upstreams {
+		config += fmt.Sprintf(`
+# Custom upstream
+upstream %s {
+    least_conn;
+`, upstream.Name)
+		for _, server := range upstream.Servers {
+			config += fmt.Sprintf("    server %s max_fails=3 fail_timeout=30s;\n", server)
+		}
+		if keepalive := upstream.GetKeepalive(); keepalive > 0 {
+			config += fmt.Sprintf("    keepalive %d;\n", keepalive)
+		}
+		config += "}\n"
+	}
+	return config
+}
+
+// buildExtraLocations 构建自定义 location 块
+func buildExtraLocations(snippets *kubenovav1.NginxSnippetsConfig) string {
+	var config string
+	for _, location := range snippets.ExtraLocations {
+		config += fmt.Sprintf(`
+    # Custom location
+    location %s {
+`, location.Path)
+		if location.Upstream != "" {
+			config += fmt.Sprintf(`        proxy_pass http://%s;
+
+        # Proxy headers
+        proxy_set_header Host $host;
+        proxy_set_header X-Real-IP $remote_addr;
+        proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+        proxy_set_header X-Forwarded-Proto $scheme;
+        proxy_set_header X-Forwarded-Host $host;
+        proxy_set_header X-Forwarded-Port $server_port;
+
+        # HTTP version
+        proxy_http_version 1.1;
+        proxy_set_header Connection "";
+`, location.Upstream)
+		}
+		if snippet := strings.TrimSpace(location.Snippet); snippet != "" {
+			if location.Upstream != "" {
+				config += "\n"
+			}
+			config += indentSnippet(snippet, 8) + "\n"
+		}
+		config += "    }\n"
+	}
+	return config
+}
+
+// indentSnippet 为片段的每一行添加缩进
+func indentSnippet(snippet string, indent int) string {
+	prefix := strings.Repeat(" ", indent)
+	lines := strings.Split(snippet, "\n")
+	for i, line := range lines {
+		if line = strings.TrimRight(line, " \t\r"); line != "" {
+			line = prefix + line
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildMaintenanceLocationBlocks 构建维护模式 location 块
+// 页面和 API 路径统一返回 503，/health 仍然返回 200 以保证探针正常；维护页不受访问控制限制
+func buildMaintenanceLocationBlocks(kn *kubenovav1.KubeNova) string {
+	message := kn.GetMaintenanceMessage()
+	openAccess := buildOpenAccess(kn, 8)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"code":    503,
+		"message": message,
+	})
+	// nginx 会展开 return 文本中的变量，需要转义 $
+	jsonBody := strings.ReplaceAll(string(body), "$", `\u0024`)
+
+	securityHeaders := buildSecurityHeaders(kn, 8)
+
+	htmlMessage := strings.ReplaceAll(html.EscapeString(message), "$", "&#36;")
+	htmlBody := fmt.Sprintf(`<!DOCTYPE html><html><head><meta charset="utf-8"><title>503 Service Unavailable</title></head>`+
+		`<body style="font-family:sans-serif;text-align:center;padding-top:15%%"><h1>503</h1><p>%s</p></body></html>`, htmlMessage)
+
+	return fmt.Sprintf(`
+    # 维护模式 - API 与 WebSocket 返回 503
+    location ~ ^/(ws|portal|manager|workload|console)(/|$) {
+        default_type application/json;
+        add_header Retry-After 600 always;
+        add_header Cache-Control "no-store" always;
+
+%s%s        return 503 '%s';
+    }
+
+    # 维护模式 - 页面返回 503 维护页
+    location / {
+        default_type text/html;
+        add_header Retry-After 600 always;
+        add_header Cache-Control "no-store" always;
+
+%s%s        return 503 '%s';
+    }
+`, openAccess, securityHeaders, escapeNginxString(jsonBody), openAccess, securityHeaders, escapeNginxString(htmlBody))
+}
+
+// escapeNginxString 转义 nginx 单引号字符串中的特殊字符
+func escapeNginxString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "'", `\'`)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package builder
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// nginxBootstrapKey reload 模式下 /etc/nginx/nginx.conf 使用的 ConfigMap key
+	// 内容固定，只 include 目录挂载的主配置，SubPath 挂载不会更新也不影响
+	nginxBootstrapKey = "bootstrap.conf"
+
+	// nginxReloadConfigDir reload 模式下主配置的目录挂载路径
+	nginxReloadConfigDir = "/etc/nginx/kube-nova"
+
+	// nginxConfDir default.conf 所在目录
+	nginxConfDir = "/etc/nginx/conf.d"
+)
+
+// nginxBootstrapConf reload 模式下的 nginx.conf
+var nginxBootstrapConf = fmt.Sprintf(`# Nginx bootstrap configuration (reload mode)
+# The main configuration is mounted as a directory so ConfigMap updates
+# reach the pod and are applied by the reloader sidecar with nginx -s reload.
+include %s/nginx.conf;
+`, nginxReloadConfigDir)
+
+// withNginxBootstrap reload 模式下为 Nginx ConfigMap 添加 bootstrap 配置
+func withNginxBootstrap(kn *kubenovav1.KubeNova, data map[string]string) map[string]string {
+	if kn.Spec.Web.IsNginxReloadMode() {
+		data[nginxBootstrapKey] = nginxBootstrapConf
+	}
+	return data
+}
+
+// getNginxReloadVolumeMounts 获取 reload 模式下 Nginx 配置的挂载
+// 不使用 SubPath，ConfigMap 更新后 kubelet 会同步到 Pod 中
+func getNginxReloadVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      "nginx-config",
+			MountPath: "/etc/nginx/nginx.conf",
+			SubPath:   nginxBootstrapKey,
+		},
+		{
+			Name:      "nginx-config",
+			MountPath: nginxReloadConfigDir,
+		},
+		{
+			Name:      "nginx-conf-d",
+			MountPath: nginxConfDir,
+		},
+	}
+}
+
+// buildNginxConfDVolume 构建 reload 模式下 conf.d 目录的 Volume(只包含 default.conf)
+func buildNginxConfDVolume(configMapName string) corev1.Volume {
+	return corev1.Volume{
+		Name: "nginx-conf-d",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configMapName,
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  "default.conf",
+						Path: "default.conf",
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildNginxReloaderContainer 构建 reloader sidecar
+// 使用 Web 镜像和相同的挂载，配置变化时先执行 nginx -t，校验通过后 nginx -s reload
+// Pod 需要开启 shareProcessNamespace 才能向 Nginx master 进程发送信号
+func buildNginxReloaderContainer(kn *kubenovav1.KubeNova, web corev1.Container) corev1.Container {
+	script := fmt.Sprintf(`checksum() { cat %[1]s/nginx.conf %[2]s/default.conf 2>/dev/null | cksum; }
+last=$(checksum)
+while true; do
+  sleep %[3]d
+  current=$(checksum)
+  [ "$current" = "$last" ] && continue
+  last=$current
+  if nginx -t; then
+    nginx -s reload && echo "nginx configuration reloaded"
+  else
+    echo "nginx configuration test failed, keeping the running configuration"
+  fi
+done
+`, nginxReloadConfigDir, nginxConfDir, kn.Spec.Web.GetNginxReloadInterval())
+
+	return corev1.Container{
+		Name:            "nginx-reloader",
+		Image:           web.Image,
+		ImagePullPolicy: web.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", script},
+		Env:             buildPodNameEnv(web.VolumeMounts),
+		VolumeMounts:    web.VolumeMounts,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package builder
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// NginxConfigTestComponent nginx -t 校验 Job 的组件标签值
+	NginxConfigTestComponent = "web-config-test"
+
+	// nginxConfigTestJobPrefix nginx -t 校验 Job 名称前缀
+	nginxConfigTestJobPrefix = "kube-nova-web-config-test-"
+)
+
+// NginxConfigTestJobName 根据配置 checksum 生成 nginx -t 校验 Job 名称
+// 相同配置只校验一次，配置变化后使用新的 Job
+func NginxConfigTestJobName(cm *corev1.ConfigMap) string {
+	return nginxConfigTestJobPrefix + CalculateConfigMapChecksum(cm)[:10]
+}
+
+// BuildNginxConfigTestJob 构建 nginx -t 校验 Job
+// 使用 Web 镜像和与 Web 相同的挂载(包括证书)，Nginx 配置从用户自定义的 ConfigMap 挂载
+func BuildNginxConfigTestJob(kn *kubenovav1.KubeNova, namespace string, cm *corev1.ConfigMap) *batchv1.Job {
+	// 用户 ConfigMap 不包含 reload 模式的 bootstrap 配置，校验时始终使用 SubPath 挂载方式
+	kn = kn.DeepCopy()
+	kn.Spec.Web.NginxConfigReload = nil
+	// 校验不需要日志和缓存的持久化目录，也不挂载用户的额外卷，避免占用 PVC
+	kn.Spec.Web.Volumes = nil
+	kn.Spec.Web.ExtraVolumeMounts = nil
+
+	deployment := buildWebDeployment(kn, namespace)
+	web := deployment.Spec.Template.Spec.Containers[0]
+
+	labels := getCommonLabels(kn)
+	labels["app.kubernetes.io/component"] = NginxConfigTestComponent
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NginxConfigTestJobName(cm),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            int32Ptr(0),
+			ActiveDeadlineSeconds:   int64Ptr(kn.Spec.Web.GetNginxConfigTestTimeout()),
+			TTLSecondsAfterFinished: int32Ptr(24 * 3600),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            "nginx-config-test",
+							Image:           web.Image,
+							ImagePullPolicy: web.ImagePullPolicy,
+							Command:         []string{"nginx", "-t"},
+							VolumeMounts:    web.VolumeMounts,
+							Resources:       web.Resources,
+							// 失败时将 nginx -t 的输出写入终止信息，便于 Operator 读取
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						},
+					},
+					Volumes:          getWebVolumesWithConfigMap(kn, kn.Spec.Web.CustomNginxConfigMap),
+					ImagePullSecrets: deployment.Spec.Template.Spec.ImagePullSecrets,
+					RestartPolicy:    corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+}
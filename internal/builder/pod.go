@@ -0,0 +1,107 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+// podExtensions 用户配置的 Pod 扩展(额外容器、卷、环境变量来源、标签和注解)
+type podExtensions struct {
+	EnvFrom           []corev1.EnvFromSource
+	InitContainers    []corev1.Container
+	ExtraContainers   []corev1.Container
+	ExtraVolumes      []corev1.Volume
+	ExtraVolumeMounts []corev1.VolumeMount
+	PodAnnotations    map[string]string
+	PodLabels         map[string]string
+}
+
+// getServicePodExtensions 获取后端服务的 Pod 扩展配置
+func getServicePodExtensions(s *kubenovav1.ServiceConfig) podExtensions {
+	if s == nil {
+		return podExtensions{}
+	}
+	return podExtensions{
+		EnvFrom:           s.EnvFrom,
+		InitContainers:    s.InitContainers,
+		ExtraContainers:   s.ExtraContainers,
+		ExtraVolumes:      s.ExtraVolumes,
+		ExtraVolumeMounts: s.ExtraVolumeMounts,
+		PodAnnotations:    s.PodAnnotations,
+		PodLabels:         s.PodLabels,
+	}
+}
+
+// getWebPodExtensions 获取 Web 的 Pod 扩展配置
+func getWebPodExtensions(w *kubenovav1.WebConfig) podExtensions {
+	return podExtensions{
+		EnvFrom:           w.EnvFrom,
+		InitContainers:    w.InitContainers,
+		ExtraContainers:   w.ExtraContainers,
+		ExtraVolumes:      w.ExtraVolumes,
+		ExtraVolumeMounts: w.ExtraVolumeMounts,
+		PodAnnotations:    w.PodAnnotations,
+		PodLabels:         w.PodLabels,
+	}
+}
+
+// applyPodExtensions 将用户配置的 Pod 扩展合并到 Pod 模板
+// 环境变量来源和卷挂载只作用于主容器(第一个容器)，额外容器追加在内置 sidecar 之后；
+// Operator 生成的标签和注解优先，用户配置不能覆盖
+func applyPodExtensions(template *corev1.PodTemplateSpec, ext podExtensions) {
+	podSpec := &template.Spec
+	main := &podSpec.Containers[0]
+
+	main.EnvFrom = append(main.EnvFrom, ext.EnvFrom...)
+	main.VolumeMounts = append(main.VolumeMounts, ext.ExtraVolumeMounts...)
+
+	for i := range ext.ExtraVolumes {
+		podSpec.Volumes = append(podSpec.Volumes, *ext.ExtraVolumes[i].DeepCopy())
+	}
+	for i := range ext.InitContainers {
+		podSpec.InitContainers = append(podSpec.InitContainers, *ext.InitContainers[i].DeepCopy())
+	}
+	for i := range ext.ExtraContainers {
+		podSpec.Containers = append(podSpec.Containers, *ext.ExtraContainers[i].DeepCopy())
+	}
+
+	// Deployment 和 Pod 模板共用标签 map，添加 Pod 标签前先复制
+	if len(ext.PodLabels) > 0 {
+		labels := make(map[string]string, len(template.Labels)+len(ext.PodLabels))
+		for k, v := range ext.PodLabels {
+			labels[k] = v
+		}
+		for k, v := range template.Labels {
+			labels[k] = v
+		}
+		template.Labels = labels
+	}
+
+	if len(ext.PodAnnotations) > 0 {
+		if template.Annotations == nil {
+			template.Annotations = make(map[string]string, len(ext.PodAnnotations))
+		}
+		for k, v := range ext.PodAnnotations {
+			if _, exists := template.Annotations[k]; !exists {
+				template.Annotations[k] = v
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+// applyProbes 使用用户配置覆盖容器探针的时间参数
+func applyProbes(container *corev1.Container, probes *kubenovav1.ProbesConfig) {
+	if probes == nil {
+		return
+	}
+	applyProbeOverride(container.StartupProbe, probes.Startup)
+	applyProbeOverride(container.LivenessProbe, probes.Liveness)
+	applyProbeOverride(container.ReadinessProbe, probes.Readiness)
+}
+
+// applyProbeOverride 覆盖单个探针的时间参数
+func applyProbeOverride(probe *corev1.Probe, override *kubenovav1.ProbeOverride) {
+	if probe == nil || override == nil {
+		return
+	}
+	if override.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *override.InitialDelaySeconds
+	}
+	if override.PeriodSeconds != nil {
+		probe.PeriodSeconds = *override.PeriodSeconds
+	}
+	if override.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *override.TimeoutSeconds
+	}
+	if override.FailureThreshold != nil {
+		probe.FailureThreshold = *override.FailureThreshold
+	}
+}
+
+// applyLifecycle 设置优雅停止时间和 preStop 等待
+// preStop 通过 exec 执行 sleep 命令，原生 sleep 动作需要 Kubernetes 1.30 及以上版本
+func applyLifecycle(podSpec *corev1.PodSpec, container *corev1.Container, lifecycle *kubenovav1.LifecycleConfig) {
+	podSpec.TerminationGracePeriodSeconds = int64Ptr(lifecycle.GetTerminationGracePeriodSeconds())
+
+	if seconds := lifecycle.GetPreStopSleepSeconds(); seconds > 0 {
+		container.Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sleep", strconv.FormatInt(seconds, 10)}},
+			},
+		}
+	}
+}
@@ -43,12 +43,18 @@ func BuildServiceAccount(kn *kubenovav1.KubeNova, namespace string) *corev1.Serv
 	}
 }
 
+// ClusterRoleBindingName 获取 ClusterRoleBinding 名称
+// 名称格式: kube-nova-{namespace}-{instance-name}-cluster-admin
+func ClusterRoleBindingName(kn *kubenovav1.KubeNova, namespace string) string {
+	return fmt.Sprintf("kube-nova-%s-%s-cluster-admin", namespace, kn.Name)
+}
+
 // BuildClusterRoleBinding 构建 ClusterRoleBinding
 // 名称格式: kube-nova-{namespace}-{instance-name}-cluster-admin
 func BuildClusterRoleBinding(kn *kubenovav1.KubeNova, namespace string) *rbacv1.ClusterRoleBinding {
 	return &rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: fmt.Sprintf("kube-nova-%s-%s-cluster-admin", namespace, kn.Name),
+			Name: ClusterRoleBindingName(kn, namespace),
 			Labels: map[string]string{
 				"app.kubernetes.io/name":       "kube-nova",
 				"app.kubernetes.io/instance":   kn.Name,
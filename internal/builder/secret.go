@@ -18,6 +18,10 @@ package builder
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,26 +29,64 @@ import (
 	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
 )
 
+const (
+	// SecretName 后端服务共用的配置 Secret 名称
+	SecretName = "kube-nova-secret"
+
+	// SecretChecksumAnnotation kube-nova-secret checksum 注解
+	// 后端服务通过 envFrom 读取配置，服务配置引用的键变化后通过该注解触发滚动更新
+	SecretChecksumAnnotation = "kubenova.io/secret-checksum"
+)
+
+// secretReferencePattern 服务配置文件中引用环境变量的占位符
+var secretReferencePattern = regexp.MustCompile(`\$\{([A-Z0-9_]+)\}`)
+
+// BuildServiceSecretKeys 返回各后端服务配置文件引用的 kube-nova-secret 键(按服务名索引)
+// 服务通过 envFrom 读取整个 Secret，但只有配置中引用的键变化时才需要滚动更新，
+// 避免节点 IP、JWT 或托管凭据等变化重启不相关的服务
+func BuildServiceSecretKeys(kn *kubenovav1.KubeNova, namespace string) map[string][]string {
+	result := make(map[string][]string)
+	for _, cm := range BuildAllConfigMaps(kn, namespace) {
+		seen := make(map[string]bool)
+		var keys []string
+		for _, content := range cm.Data {
+			for _, match := range secretReferencePattern.FindAllStringSubmatch(content, -1) {
+				if !seen[match[1]] {
+					seen[match[1]] = true
+					keys = append(keys, match[1])
+				}
+			}
+		}
+		sort.Strings(keys)
+		result[strings.TrimSuffix(cm.Name, "-config")] = keys
+	}
+	return result
+}
+
 // BuildSecret 构建 Secret
 func BuildSecret(kn *kubenovav1.KubeNova, namespace string, nodeIP string, nodePort int32) *corev1.Secret {
 	data := make(map[string][]byte)
 
 	// 全局配置
 	data["DEFAULT_TIMEOUT"] = []byte(fmt.Sprintf("%d", kn.Spec.Services.GlobalTimeout))
+	data["LOG_TIME_FORMAT"] = []byte(kn.GetLogTimeFormat())
+	// 默认输出到标准输出，配置日志卷的服务通过容器环境变量覆盖为 file
+	data["LOG_MODE"] = []byte("console")
 
 	// MySQL 数据库配置
-	data["MYSQL_HOST"] = []byte(kn.Spec.Database.Host)
-	data["MYSQL_PORT"] = []byte(fmt.Sprintf("%d", kn.Spec.Database.Port))
-	data["MYSQL_DATABASE"] = []byte(kn.Spec.Database.Database)
-	data["MYSQL_USER"] = []byte(kn.Spec.Database.User)
+	// 托管依赖的密码由控制器通过 ApplyManagedCredentials 写入
+	data["MYSQL_HOST"] = []byte(kn.GetDatabaseHost())
+	data["MYSQL_PORT"] = []byte(fmt.Sprintf("%d", kn.GetDatabasePort()))
+	data["MYSQL_DATABASE"] = []byte(kn.GetDatabaseName())
+	data["MYSQL_USER"] = []byte(kn.GetDatabaseUser())
 	data["MYSQL_PASSWORD"] = []byte(kn.Spec.Database.Password)
 	data["MYSQL_MAX_OPEN_CONNS"] = []byte(fmt.Sprintf("%d", kn.Spec.Database.GetMaxOpenConns()))
 	data["MYSQL_MAX_IDLE_CONNS"] = []byte(fmt.Sprintf("%d", kn.Spec.Database.GetMaxIdleConns()))
 	data["MYSQL_CONN_MAX_LIFETIME"] = []byte(kn.Spec.Database.GetConnMaxLifetime())
 
 	// Redis 缓存配置
-	data["REDIS_HOST"] = []byte(kn.Spec.Cache.Host)
-	data["REDIS_PORT"] = []byte(fmt.Sprintf("%d", kn.Spec.Cache.Port))
+	data["REDIS_HOST"] = []byte(kn.GetCacheHost())
+	data["REDIS_PORT"] = []byte(fmt.Sprintf("%d", kn.GetCachePort()))
 	data["REDIS_PASSWORD"] = []byte(kn.Spec.Cache.GetCachePassword())
 	data["REDIS_TYPE"] = []byte(kn.Spec.Cache.Type)
 	data["REDIS_TLS"] = []byte(fmt.Sprintf("%t", kn.Spec.Cache.TLS))
@@ -53,10 +95,10 @@ func BuildSecret(kn *kubenovav1.KubeNova, namespace string, nodeIP string, nodeP
 
 	// MinIO 对象存储配置
 	// MINIO_ENDPOINT: SDK 使用的纯地址:端口（不带协议和路径）
-	data["MINIO_ENDPOINT"] = []byte(kn.Spec.Storage.Endpoint)
+	data["MINIO_ENDPOINT"] = []byte(kn.GetStorageAddress())
 	data["MINIO_ACCESS_KEY"] = []byte(kn.Spec.Storage.AccessKey)
 	data["MINIO_SECRET_KEY"] = []byte(kn.Spec.Storage.SecretKey)
-	data["MINIO_BUCKET"] = []byte(kn.Spec.Storage.Bucket)
+	data["MINIO_BUCKET"] = []byte(kn.GetStorageBucket())
 
 	// MINIO_USE_SSL: SDK 是否使用 SSL（取决于 MinIO 是否启用 TLS）
 	useSSL := kn.Spec.Storage.TLS != nil && kn.Spec.Storage.TLS.Enabled
@@ -87,24 +129,28 @@ func BuildSecret(kn *kubenovav1.KubeNova, namespace string, nodeIP string, nodeP
 			if useSSL {
 				protocol = "https"
 			}
-			endpointProxy = fmt.Sprintf("%s://%s", protocol, kn.Spec.Storage.Endpoint)
+			endpointProxy = fmt.Sprintf("%s://%s", protocol, kn.GetStorageAddress())
 		}
 	}
 
 	data["MINIO_ENDPOINT_PROXY"] = []byte(endpointProxy)
 
 	// JWT 认证配置
+	// 未配置的密钥和轮换过渡期的旧密钥由控制器通过 ApplyJWTSecret 写入
 	data["JWT_ACCESS_SECRET"] = []byte(kn.Spec.Services.JWT.AccessSecret)
+	data["JWT_ACCESS_SECRET_PREVIOUS"] = []byte("")
 	data["JWT_ACCESS_EXPIRE"] = []byte(fmt.Sprintf("%d", kn.Spec.Services.JWT.AccessExpire))
 	data["JWT_REFRESH_SECRET"] = []byte(kn.Spec.Services.JWT.RefreshSecret)
+	data["JWT_REFRESH_SECRET_PREVIOUS"] = []byte("")
 	data["JWT_REFRESH_EXPIRE"] = []byte(fmt.Sprintf("%d", kn.Spec.Services.JWT.RefreshExpire))
 	data["JWT_REFRESH_AFTER"] = []byte(fmt.Sprintf("%d", kn.Spec.Services.JWT.RefreshAfter))
 
 	// Webhook 配置
+	// 启用告警接入且未配置 Token 时由控制器自动生成
 	if kn.Spec.Services.WebhookToken != "" {
-		data["ALERTMANAGER_WEBHOOK_TOKEN"] = []byte(kn.Spec.Services.WebhookToken)
+		data[AlertmanagerWebhookTokenKey] = []byte(kn.Spec.Services.WebhookToken)
 	} else {
-		data["ALERTMANAGER_WEBHOOK_TOKEN"] = []byte("")
+		data[AlertmanagerWebhookTokenKey] = []byte("")
 	}
 
 	// Jaeger 链路追踪配置
@@ -134,7 +180,7 @@ func BuildSecret(kn *kubenovav1.KubeNova, namespace string, nodeIP string, nodeP
 
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kube-nova-secret",
+			Name:      SecretName,
 			Namespace: namespace,
 			Labels: map[string]string{
 				"app.kubernetes.io/name":       "kube-nova",
@@ -146,3 +192,31 @@ func BuildSecret(kn *kubenovav1.KubeNova, namespace string, nodeIP string, nodeP
 		Data: data,
 	}
 }
+
+// BuildSecretBackup 构建 kube-nova-secret 的备份 Secret
+// 名称使用删除时间戳，保证多次协调时生成相同的名称
+func BuildSecretBackup(kn *kubenovav1.KubeNova, source *corev1.Secret, timestamp time.Time) *corev1.Secret {
+	data := make(map[string][]byte, len(source.Data))
+	for k, v := range source.Data {
+		data[k] = v
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-backup-%s", source.Name, timestamp.Format("20060102-150405")),
+			Namespace: source.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "kube-nova",
+				"app.kubernetes.io/instance":   kn.Name,
+				"app.kubernetes.io/managed-by": "kube-nova-operator",
+				"app.kubernetes.io/component":  "backup",
+			},
+			Annotations: map[string]string{
+				"kubenova.io/backup-source": source.Name,
+				"kubenova.io/backup-time":   timestamp.Format(time.RFC3339),
+			},
+		},
+		Type: source.Type,
+		Data: data,
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+func TestBuildServiceSecretKeys(t *testing.T) {
+	kn := &kubenovav1.KubeNova{ObjectMeta: metav1.ObjectMeta{Name: "kube-nova", Namespace: "kube-nova"}}
+	keys := BuildServiceSecretKeys(kn, "kube-nova")
+
+	tests := []struct {
+		service string
+		want    []string
+		exclude []string
+	}{
+		{
+			service: "portal-rpc",
+			want:    []string{"MINIO_ENDPOINT_PROXY", "JWT_ACCESS_SECRET", "MYSQL_PASSWORD"},
+		},
+		{
+			service: "manager-api",
+			want:    []string{"ALERTMANAGER_WEBHOOK_TOKEN", "REDIS_PASSWORD"},
+			exclude: []string{"MINIO_ENDPOINT_PROXY", "JWT_ACCESS_SECRET"},
+		},
+		{
+			service: "workload-api",
+			want:    []string{"INJECT_IMAGE"},
+			exclude: []string{"MINIO_ENDPOINT_PROXY", "JWT_ACCESS_SECRET", "ALERTMANAGER_WEBHOOK_TOKEN"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			for _, key := range tt.want {
+				if !slices.Contains(keys[tt.service], key) {
+					t.Errorf("%s secret keys = %v, want containing %s", tt.service, keys[tt.service], key)
+				}
+			}
+			for _, key := range tt.exclude {
+				if slices.Contains(keys[tt.service], key) {
+					t.Errorf("%s secret keys = %v, want not containing %s", tt.service, keys[tt.service], key)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateSecretKeysChecksum(t *testing.T) {
+	kn := &kubenovav1.KubeNova{ObjectMeta: metav1.ObjectMeta{Name: "kube-nova", Namespace: "kube-nova"}}
+	keys := []string{"REDIS_HOST", "REDIS_PASSWORD"}
+
+	secret := BuildSecret(kn, "kube-nova", "10.0.0.1", 30080)
+	checksum := CalculateSecretKeysChecksum(secret, keys)
+
+	secret.Data["MINIO_ENDPOINT_PROXY"] = []byte("http://10.0.0.2:30080/storage")
+	if got := CalculateSecretKeysChecksum(secret, keys); got != checksum {
+		t.Errorf("checksum changed after unreferenced key update")
+	}
+
+	secret.Data["REDIS_PASSWORD"] = []byte("changed")
+	if got := CalculateSecretKeysChecksum(secret, keys); got == checksum {
+		t.Errorf("checksum unchanged after referenced key update")
+	}
+}
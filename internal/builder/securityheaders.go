@@ -0,0 +1,124 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+// corsOriginVariable CORS 来源匹配结果变量
+const corsOriginVariable = "$kubenova_cors_origin"
+
+// buildSecurityHeaders 构建安全响应头
+// nginx 的 location 只要定义了 add_header 就不再继承 server 级别的响应头，
+// 因此 server 块和所有定义了 add_header 的 location 块都需要完整输出
+func buildSecurityHeaders(kn *kubenovav1.KubeNova, indent int) string {
+	headers := kn.Spec.Web.GetSecurityHeaders()
+	pad := strings.Repeat(" ", indent)
+
+	var b strings.Builder
+	b.WriteString(pad + "# Security headers\n")
+	addHeader := func(name, value string) {
+		fmt.Fprintf(&b, "%sadd_header %s %s always;\n", pad, name, quoteHeaderValue(value))
+	}
+
+	if frameOptions := headers.GetFrameOptions(); frameOptions != "" {
+		addHeader("X-Frame-Options", frameOptions)
+	}
+	addHeader("X-Content-Type-Options", "nosniff")
+	if headers.IsXSSProtectionEnabled() {
+		addHeader("X-XSS-Protection", "1; mode=block")
+	}
+	addHeader("Referrer-Policy", headers.GetReferrerPolicy())
+	addHeader("Permissions-Policy", headers.GetPermissionsPolicy())
+	if csp := headers.GetContentSecurityPolicy(); csp != "" {
+		addHeader("Content-Security-Policy", csp)
+	}
+	if kn.IsHSTSEnabled() {
+		addHeader("Strict-Transport-Security", headers.GetHSTSValue())
+	}
+	for _, header := range headers.CustomHeaders {
+		addHeader(header.Name, header.Value)
+	}
+	return b.String()
+}
+
+// buildCORSMap 构建 CORS 来源匹配 map(http 上下文)
+// 只有匹配的来源会回写到 Access-Control-Allow-Origin
+func buildCORSMap(kn *kubenovav1.KubeNova) string {
+	headers := kn.Spec.Web.GetSecurityHeaders()
+	if !headers.IsCORSEnabled() || slices.Contains(headers.CORS.AllowedOrigins, "*") {
+		return ""
+	}
+
+	config := fmt.Sprintf(`
+# CORS allowed origins
+map $http_origin %s {
+    default "";
+`, corsOriginVariable)
+	for _, origin := range headers.CORS.AllowedOrigins {
+		config += fmt.Sprintf("    %s $http_origin;\n", quoteHeaderValue(origin))
+	}
+	config += "}\n"
+	return config
+}
+
+// buildAPIHeaders 构建 API location 的 CORS 响应头
+// 未启用 CORS 时 API location 直接继承 server 级别的安全响应头
+func buildAPIHeaders(kn *kubenovav1.KubeNova) string {
+	headers := kn.Spec.Web.GetSecurityHeaders()
+	if !headers.IsCORSEnabled() {
+		return ""
+	}
+	cors := headers.CORS
+
+	allowOrigin := corsOriginVariable
+	if slices.Contains(cors.AllowedOrigins, "*") {
+		allowOrigin = `"*"`
+	}
+
+	config := `
+        # CORS
+        add_header Access-Control-Allow-Origin ` + allowOrigin + ` always;
+        add_header Access-Control-Allow-Methods ` + quoteHeaderValue(cors.GetAllowedMethods()) + ` always;
+        add_header Access-Control-Allow-Headers ` + quoteHeaderValue(cors.GetAllowedHeaders()) + ` always;
+`
+	if cors.AllowCredentials {
+		config += `        add_header Access-Control-Allow-Credentials "true" always;
+`
+	}
+	config += fmt.Sprintf(`        add_header Access-Control-Max-Age %d always;
+        add_header Vary Origin always;
+
+        # CORS preflight
+        if ($request_method = OPTIONS) {
+            return 204;
+        }
+
+`, cors.GetMaxAge())
+	return config + buildSecurityHeaders(kn, 8)
+}
+
+// quoteHeaderValue 使用双引号包裹响应头的值
+func quoteHeaderValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
@@ -36,6 +36,8 @@ const (
 type ServiceResources struct {
 	Deployment *appsv1.Deployment
 	Service    *corev1.Service
+	// HeadlessService RPC 客户端使用 DNS 发现时的 headless Service，不需要时为 nil
+	HeadlessService *corev1.Service
 }
 
 // BuildAllServices 构建所有后端服务
@@ -61,21 +63,17 @@ func BuildAllServices(kn *kubenovav1.KubeNova, namespace string) map[string]*Ser
 	// Portal RPC - 需要特殊处理 MinIO 证书
 	if kn.Spec.Services.PortalRPC == nil || kn.Spec.Services.PortalRPC.IsServiceEnabled() {
 		services["portal-rpc"] = buildService(kn, namespace, &serviceConfig{
-			Name:           "portal-rpc",
-			Port:           30010,
-			TargetPort:     30010,
-			MetricsPort:    9999,
-			ConfigMapName:  "portal-rpc-config",
-			Registry:       registry,
-			ServiceConfig:  kn.Spec.Services.PortalRPC,
-			Component:      "rpc",
-			NeedMinIOCerts: kn.Spec.Storage.TLS != nil && kn.Spec.Storage.TLS.Enabled,
-			MinIOCertSecret: func() string {
-				if kn.Spec.Storage.TLS != nil && kn.Spec.Storage.TLS.Enabled {
-					return kn.Spec.Storage.TLS.SecretName
-				}
-				return ""
-			}(),
+			Name:            "portal-rpc",
+			Port:            30010,
+			TargetPort:      30010,
+			MetricsPort:     9999,
+			ConfigMapName:   "portal-rpc-config",
+			Registry:        registry,
+			ServiceConfig:   kn.Spec.Services.PortalRPC,
+			Component:       "rpc",
+			NeedMinIOCerts:  kn.Spec.Storage.TLS != nil && kn.Spec.Storage.TLS.Enabled,
+			MinIOCertSecret: kn.GetMinIOTLSSecretName(),
+			MinIOCertIssued: kn.IsMinIOCertIssued(),
 		})
 	}
 
@@ -166,20 +164,41 @@ type serviceConfig struct {
 	Component       string
 	NeedMinIOCerts  bool
 	MinIOCertSecret string
+	MinIOCertIssued bool // 证书由 cert-manager 签发，使用 tls.crt/tls.key 键
 	NeedCache       bool
+	// InternalTLSSecret 内部 TLS 证书 Secret(启用内部 TLS 时设置)
+	InternalTLSSecret string
+}
+
+// isRPC 检查是否为 gRPC 服务
+func (cfg *serviceConfig) isRPC() bool {
+	return cfg.Component == "rpc"
 }
 
 // buildService 构建单个服务
 func buildService(kn *kubenovav1.KubeNova, namespace string, cfg *serviceConfig) *ServiceResources {
-	return &ServiceResources{
+	if kn.IsInternalTLSEnabled() {
+		cfg.InternalTLSSecret = InternalTLSSecretName(cfg.Name)
+	}
+
+	resources := &ServiceResources{
 		Deployment: buildDeployment(kn, namespace, cfg),
 		Service:    buildK8sService(namespace, cfg),
 	}
+	// Service 本身为 headless 时 DNS 发现直接使用该 Service
+	if kn.IsRPCDNSDiscoveryUsed(cfg.Name) && !cfg.ServiceConfig.IsHeadless() {
+		resources.HeadlessService = buildHeadlessService(namespace, cfg)
+	}
+	return resources
 }
 
 // buildDeployment 构建 Deployment
 func buildDeployment(kn *kubenovav1.KubeNova, namespace string, cfg *serviceConfig) *appsv1.Deployment {
 	replicas := cfg.ServiceConfig.GetReplicas()
+	// 维护页面模式下可选择将后端服务缩容到 0
+	if kn.IsBackendScaledDown() {
+		replicas = 0
+	}
 
 	// 构建镜像名称
 	image := fmt.Sprintf("%s/%s/%s:%s", cfg.Registry.Registry, cfg.Registry.Organization, cfg.Name, cfg.Registry.Tag)
@@ -255,6 +274,7 @@ func buildDeployment(kn *kubenovav1.KubeNova, namespace string, cfg *serviceConf
 							ImagePullPolicy: cfg.Registry.PullPolicy,
 							Ports: []corev1.ContainerPort{
 								{
+									Name:          getServicePortName(cfg),
 									ContainerPort: cfg.TargetPort,
 									Protocol:      corev1.ProtocolTCP,
 								},
@@ -273,20 +293,14 @@ func buildDeployment(kn *kubenovav1.KubeNova, namespace string, cfg *serviceConf
 									},
 								},
 							},
-							Env: []corev1.EnvVar{
-								{
-									Name:  "TZ",
-									Value: "Asia/Shanghai",
-								},
-								{
-									Name: "POD_NAMESPACE",
-									ValueFrom: &corev1.EnvVarSource{
-										FieldRef: &corev1.ObjectFieldSelector{
-											FieldPath: "metadata.namespace",
-										},
+							Env: append(buildLocaleEnv(kn, kn.GetServiceTimezone(cfg.ServiceConfig)), corev1.EnvVar{
+								Name: "POD_NAMESPACE",
+								ValueFrom: &corev1.EnvVarSource{
+									FieldRef: &corev1.ObjectFieldSelector{
+										FieldPath: "metadata.namespace",
 									},
 								},
-							},
+							}),
 							StartupProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
 									HTTPGet: &corev1.HTTPGetAction{
@@ -312,12 +326,7 @@ func buildDeployment(kn *kubenovav1.KubeNova, namespace string, cfg *serviceConf
 								TimeoutSeconds:      3,
 							},
 							ReadinessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/healthz",
-										Port: intstr.FromInt32(cfg.MetricsPort),
-									},
-								},
+								ProbeHandler:        getReadinessProbeHandler(kn, cfg),
 								InitialDelaySeconds: 10,
 								PeriodSeconds:       5,
 								FailureThreshold:    3,
@@ -328,21 +337,25 @@ func buildDeployment(kn *kubenovav1.KubeNova, namespace string, cfg *serviceConf
 							SecurityContext: getSecurityContext(),
 						},
 					},
-					Volumes:                       getServiceVolumes(cfg),
-					DNSPolicy:                     corev1.DNSClusterFirst,
-					RestartPolicy:                 corev1.RestartPolicyAlways,
-					TerminationGracePeriodSeconds: int64Ptr(30),
+					Volumes:       getServiceVolumes(cfg),
+					DNSPolicy:     corev1.DNSClusterFirst,
+					RestartPolicy: corev1.RestartPolicyAlways,
 				},
 			},
 		},
 	}
 
-	// 添加额外的环境变量
+	// 探针参数覆盖和优雅停止配置
+	podSpec := &deployment.Spec.Template.Spec
+	applyProbes(&podSpec.Containers[0], cfg.ServiceConfig.GetProbes())
+	applyLifecycle(podSpec, &podSpec.Containers[0], cfg.ServiceConfig.GetLifecycle())
+
+	// 日志和缓存目录卷需要的环境变量
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, getServiceDataVolumeEnv(cfg, podSpec.Containers[0].VolumeMounts)...)
+
+	// 添加额外的环境变量，同名变量覆盖 Operator 生成的值
 	if cfg.ServiceConfig != nil && len(cfg.ServiceConfig.Env) > 0 {
-		deployment.Spec.Template.Spec.Containers[0].Env = append(
-			deployment.Spec.Template.Spec.Containers[0].Env,
-			cfg.ServiceConfig.Env...,
-		)
+		podSpec.Containers[0].Env = mergeEnvVars(podSpec.Containers[0].Env, cfg.ServiceConfig.Env)
 	}
 
 	// 添加镜像拉取密钥
@@ -354,6 +367,9 @@ func buildDeployment(kn *kubenovav1.KubeNova, namespace string, cfg *serviceConf
 		deployment.Spec.Template.Spec.ImagePullSecrets = imagePullSecrets
 	}
 
+	// 用户配置的额外容器、卷和 Pod 元数据
+	applyPodExtensions(&deployment.Spec.Template, getServicePodExtensions(cfg.ServiceConfig))
+
 	return deployment
 }
 
@@ -364,7 +380,18 @@ func buildK8sService(namespace string, cfg *serviceConfig) *corev1.Service {
 		"component": cfg.Component,
 	}
 
-	return &corev1.Service{
+	port := corev1.ServicePort{
+		Name:       getServicePortName(cfg),
+		Port:       cfg.Port,
+		TargetPort: intstr.FromInt32(cfg.TargetPort),
+		Protocol:   corev1.ProtocolTCP,
+	}
+	if cfg.isRPC() {
+		appProtocol := "grpc"
+		port.AppProtocol = &appProtocol
+	}
+
+	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfg.Name,
 			Namespace: namespace,
@@ -375,18 +402,63 @@ func buildK8sService(namespace string, cfg *serviceConfig) *corev1.Service {
 			Selector: map[string]string{
 				"app": cfg.Name,
 			},
-			Ports: []corev1.ServicePort{
-				{
-					Name:       "http",
-					Port:       cfg.Port,
-					TargetPort: intstr.FromInt32(cfg.TargetPort),
-					Protocol:   corev1.ProtocolTCP,
+			Ports: []corev1.ServicePort{port},
+		},
+	}
+	if cfg.isRPC() && cfg.ServiceConfig.IsHeadless() {
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+	}
+	return service
+}
+
+// getServicePortName 获取服务端口名称
+func getServicePortName(cfg *serviceConfig) string {
+	if cfg.isRPC() {
+		return "grpc"
+	}
+	return "http"
+}
+
+// getReadinessProbeHandler 获取就绪探针
+// RPC 服务默认检查 RPC 端口的 gRPC 健康状态，使就绪状态反映 RPC 服务本身；
+// kubelet 的 gRPC 探针不支持 TLS，启用内部 TLS 时改为检查端口是否可连接
+func getReadinessProbeHandler(kn *kubenovav1.KubeNova, cfg *serviceConfig) corev1.ProbeHandler {
+	if cfg.isRPC() && cfg.ServiceConfig.IsGRPCHealthProbeEnabled() {
+		if kn.IsInternalTLSEnabled() {
+			return corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt32(cfg.TargetPort),
 				},
+			}
+		}
+		return corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{
+				Port: cfg.TargetPort,
 			},
+		}
+	}
+	return corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path: "/healthz",
+			Port: intstr.FromInt32(cfg.MetricsPort),
 		},
 	}
 }
 
+// HeadlessServiceName 获取服务的 headless Service 名称
+func HeadlessServiceName(service string) string {
+	return service + "-headless"
+}
+
+// buildHeadlessService 构建 headless Service
+// DNS 直接解析为 Pod IP，由 gRPC 客户端在多个副本之间负载均衡
+func buildHeadlessService(namespace string, cfg *serviceConfig) *corev1.Service {
+	service := buildK8sService(namespace, cfg)
+	service.Name = HeadlessServiceName(cfg.Name)
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	return service
+}
+
 // getServiceResources 获取服务资源配置
 func getServiceResources(sc *kubenovav1.ServiceConfig) corev1.ResourceRequirements {
 	if sc != nil && sc.Resources != nil {
@@ -424,14 +496,25 @@ func getServiceVolumeMounts(cfg *serviceConfig) []corev1.VolumeMount {
 		})
 	}
 
-	// 如果需要缓存目录
-	if cfg.NeedCache {
+	// 如果启用内部 TLS，挂载服务证书
+	if cfg.InternalTLSSecret != "" {
 		mounts = append(mounts, corev1.VolumeMount{
-			Name:      "cache",
-			MountPath: "/app/cache",
+			Name:      "internal-tls",
+			MountPath: InternalTLSMountPath,
+			ReadOnly:  true,
 		})
 	}
 
+	// 如果配置了日志卷
+	if logs := cfg.ServiceConfig.GetLogsVolume(); logs != nil {
+		mounts = append(mounts, buildLogsVolumeMount("/app/logs", logs))
+	}
+
+	// 如果需要缓存目录
+	if cfg.NeedCache {
+		mounts = append(mounts, buildCacheVolumeMount("/app/cache", cfg.ServiceConfig.GetCacheVolume()))
+	}
+
 	return mounts
 }
 
@@ -452,6 +535,10 @@ func getServiceVolumes(cfg *serviceConfig) []corev1.Volume {
 
 	// 如果需要 MinIO 证书（仅 portal-rpc）
 	if cfg.NeedMinIOCerts && cfg.MinIOCertSecret != "" {
+		certKey, keyKey := "public.crt", "private.key"
+		if cfg.MinIOCertIssued {
+			certKey, keyKey = "tls.crt", "tls.key"
+		}
 		volumes = append(volumes, corev1.Volume{
 			Name: "minio-certs",
 			VolumeSource: corev1.VolumeSource{
@@ -460,11 +547,11 @@ func getServiceVolumes(cfg *serviceConfig) []corev1.Volume {
 					Optional:   boolPtr(false),
 					Items: []corev1.KeyToPath{
 						{
-							Key:  "public.crt",
+							Key:  certKey,
 							Path: "public.crt",
 						},
 						{
-							Key:  "private.key",
+							Key:  keyKey,
 							Path: "private.key",
 						},
 					},
@@ -473,21 +560,48 @@ func getServiceVolumes(cfg *serviceConfig) []corev1.Volume {
 		})
 	}
 
-	// 如果需要缓存目录
-	if cfg.NeedCache {
+	// 如果启用内部 TLS，添加服务证书 Volume
+	if cfg.InternalTLSSecret != "" {
 		volumes = append(volumes, corev1.Volume{
-			Name: "cache",
+			Name: "internal-tls",
 			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{
-					SizeLimit: resource.NewQuantity(1*1024*1024*1024, resource.BinarySI), // 1Gi
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cfg.InternalTLSSecret,
+					Optional:   boolPtr(false),
 				},
 			},
 		})
 	}
 
+	// ephemeral PVC 使用服务名称标签，便于按服务查找
+	claimLabels := map[string]string{
+		"app":                          cfg.Name,
+		"app.kubernetes.io/managed-by": "kube-nova-operator",
+	}
+
+	// 如果配置了日志卷
+	if logs := cfg.ServiceConfig.GetLogsVolume(); logs != nil {
+		volumes = append(volumes, buildDataVolume("logs", logs, resource.MustParse("1Gi"), claimLabels))
+	}
+
+	// 如果需要缓存目录
+	if cfg.NeedCache {
+		volumes = append(volumes, buildDataVolume("cache", cfg.ServiceConfig.GetCacheVolume(), resource.MustParse("1Gi"), claimLabels))
+	}
+
 	return volumes
 }
 
+// getServiceDataVolumeEnv 获取日志和缓存目录卷需要的环境变量
+// 配置日志卷后日志写入文件；使用已有 PVC 时需要 POD_NAME 作为子目录
+func getServiceDataVolumeEnv(cfg *serviceConfig, mounts []corev1.VolumeMount) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if cfg.ServiceConfig.GetLogsVolume() != nil {
+		env = append(env, corev1.EnvVar{Name: "LOG_MODE", Value: "file"})
+	}
+	return append(env, buildPodNameEnv(mounts)...)
+}
+
 // getSecurityContext 获取安全上下文
 func getSecurityContext() *corev1.SecurityContext {
 	return &corev1.SecurityContext{
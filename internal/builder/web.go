@@ -24,16 +24,33 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
 )
 
+const (
+	// NginxConfigChecksumAnnotation Nginx 配置 checksum 注解
+	// 配置内容变化时该注解随之变化，从而触发 Web 滚动更新
+	NginxConfigChecksumAnnotation = "kubenova.io/nginx-config-checksum"
+
+	// NginxConfigMapName Web 挂载的 Nginx 配置 ConfigMap 名称
+	// 使用自定义配置时，校验通过的配置也会复制到该 ConfigMap
+	NginxConfigMapName = "frontend-nginx-config"
+
+	// TLSChecksumAnnotation NodePort HTTPS 证书 checksum 注解
+	// Nginx 不会自动重新加载证书，证书续期后通过该注解触发 Web 滚动更新
+	TLSChecksumAnnotation = "kubenova.io/tls-checksum"
+)
+
 // WebResources Web 资源
 type WebResources struct {
 	Deployment     *appsv1.Deployment
 	Service        *corev1.Service
 	Ingress        *networkingv1.Ingress
+	HTTPRoute      *unstructured.Unstructured
+	Gateway        *unstructured.Unstructured
 	NginxConfigMap *corev1.ConfigMap
 }
 
@@ -48,6 +65,9 @@ func BuildWebResources(kn *kubenovav1.KubeNova, namespace string) *WebResources
 
 	// 构建 Deployment
 	resources.Deployment = buildWebDeployment(kn, namespace)
+	if resources.NginxConfigMap != nil {
+		resources.SetNginxConfigMap(kn, resources.NginxConfigMap)
+	}
 
 	// 构建 Service
 	resources.Service = buildWebService(kn, namespace)
@@ -57,9 +77,45 @@ func BuildWebResources(kn *kubenovav1.KubeNova, namespace string) *WebResources
 		resources.Ingress = buildWebIngress(kn, namespace)
 	}
 
+	// 构建 HTTPRoute 和 Gateway（如果使用 Gateway 模式）
+	if kn.Spec.Web.ExposeType == "gateway" {
+		resources.HTTPRoute = buildWebHTTPRoute(kn, namespace)
+		resources.Gateway = buildWebGateway(kn, namespace)
+	}
+
 	return resources
 }
 
+// SetNginxConfigMap 设置要部署的 Nginx ConfigMap
+// Nginx 配置通过 SubPath 挂载，ConfigMap 更新不会自动生效，
+// 记录配置 checksum 以便配置变化时滚动更新 Web Pod；reload 模式下由 reloader 生效，不滚动更新
+func (w *WebResources) SetNginxConfigMap(kn *kubenovav1.KubeNova, cm *corev1.ConfigMap) {
+	w.NginxConfigMap = cm
+	if kn.Spec.Web.IsNginxReloadMode() {
+		return
+	}
+	if w.Deployment.Spec.Template.Annotations == nil {
+		w.Deployment.Spec.Template.Annotations = make(map[string]string)
+	}
+	w.Deployment.Spec.Template.Annotations[NginxConfigChecksumAnnotation] = CalculateConfigMapChecksum(cm)
+}
+
+// BuildCustomNginxConfigMap 根据用户自定义的 ConfigMap 构建 Web 挂载的 Nginx ConfigMap
+// 只复制 nginx.conf 和 default.conf
+func BuildCustomNginxConfigMap(kn *kubenovav1.KubeNova, namespace string, custom *corev1.ConfigMap) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NginxConfigMapName,
+			Namespace: namespace,
+			Labels:    getCommonLabels(kn),
+		},
+		Data: withNginxBootstrap(kn, map[string]string{
+			"nginx.conf":   custom.Data["nginx.conf"],
+			"default.conf": custom.Data["default.conf"],
+		}),
+	}
+}
+
 // buildWebDeployment 构建 Web Deployment
 func buildWebDeployment(kn *kubenovav1.KubeNova, namespace string) *appsv1.Deployment {
 	registry := kn.GetImageRegistry()
@@ -132,20 +188,16 @@ func buildWebDeployment(kn *kubenovav1.KubeNova, namespace string) *appsv1.Deplo
 							Image:           image,
 							ImagePullPolicy: registry.PullPolicy,
 							Ports:           getWebPorts(kn),
-							Env: []corev1.EnvVar{
-								{
-									Name:  "TZ",
-									Value: "Asia/Shanghai",
-								},
-								{
+							Env: append(buildLocaleEnv(kn, kn.GetWebTimezone()),
+								corev1.EnvVar{
 									Name:  "NGINX_WORKER_PROCESSES",
 									Value: "auto",
 								},
-								{
+								corev1.EnvVar{
 									Name:  "NGINX_WORKER_CONNECTIONS",
 									Value: "4096",
 								},
-							},
+							),
 							VolumeMounts: getWebVolumeMounts(kn),
 							LivenessProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
@@ -192,15 +244,22 @@ func buildWebDeployment(kn *kubenovav1.KubeNova, namespace string) *appsv1.Deplo
 							Resources: getWebResourceRequirements(kn),
 						},
 					},
-					Volumes:                       getWebVolumes(kn, namespace),
-					DNSPolicy:                     corev1.DNSClusterFirst,
-					RestartPolicy:                 corev1.RestartPolicyAlways,
-					TerminationGracePeriodSeconds: int64Ptr(30),
+					Volumes:       getWebVolumes(kn, namespace),
+					DNSPolicy:     corev1.DNSClusterFirst,
+					RestartPolicy: corev1.RestartPolicyAlways,
 				},
 			},
 		},
 	}
 
+	// 探针参数覆盖和优雅停止配置(sidecar 添加之前，只作用于 Nginx 容器)
+	webPodSpec := &deployment.Spec.Template.Spec
+	applyProbes(&webPodSpec.Containers[0], kn.Spec.Web.Probes)
+	applyLifecycle(webPodSpec, &webPodSpec.Containers[0], kn.Spec.Web.Lifecycle)
+
+	// 日志和缓存目录使用已有 PVC 时需要 POD_NAME 作为子目录
+	webPodSpec.Containers[0].Env = append(webPodSpec.Containers[0].Env, buildPodNameEnv(webPodSpec.Containers[0].VolumeMounts)...)
+
 	// 添加镜像拉取密钥
 	if len(registry.PullSecrets) > 0 {
 		imagePullSecrets := make([]corev1.LocalObjectReference, 0, len(registry.PullSecrets))
@@ -210,6 +269,23 @@ func buildWebDeployment(kn *kubenovav1.KubeNova, namespace string) *appsv1.Deplo
 		deployment.Spec.Template.Spec.ImagePullSecrets = imagePullSecrets
 	}
 
+	// reload 模式：添加 reloader sidecar，共享进程命名空间以便发送 reload 信号
+	if kn.Spec.Web.IsNginxReloadMode() {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.ShareProcessNamespace = boolPtr(true)
+		podSpec.Containers = append(podSpec.Containers, buildNginxReloaderContainer(kn, podSpec.Containers[0]))
+	}
+
+	// 外部认证 sidecar 模式：oauth2-proxy 与 Nginx 部署在同一个 Pod 中
+	if kn.Spec.Web.IsExternalAuthEnabled() &&
+		kn.Spec.Web.AccessControl.Auth.GetAuthMode() == kubenovav1.AuthModeSidecar {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Containers = append(podSpec.Containers, buildOAuth2ProxyContainer(kn))
+	}
+
+	// 用户配置的额外容器、卷和 Pod 元数据(额外容器追加在内置 sidecar 之后)
+	applyPodExtensions(&deployment.Spec.Template, getWebPodExtensions(&kn.Spec.Web))
+
 	return deployment
 }
 
@@ -271,8 +347,8 @@ func buildWebService(kn *kubenovav1.KubeNova, namespace string) *corev1.Service
 	}
 
 	// 根据暴露类型配置 Service
-	if kn.Spec.Web.ExposeType == "ingress" {
-		// Ingress 模式：使用 ClusterIP
+	if kn.Spec.Web.ExposeType == "ingress" || kn.Spec.Web.ExposeType == "gateway" {
+		// Ingress/Gateway 模式：使用 ClusterIP
 		service.Spec.Type = corev1.ServiceTypeClusterIP
 	} else if kn.Spec.Web.ExposeType == "nodeport" {
 		// NodePort 模式
@@ -283,7 +359,7 @@ func buildWebService(kn *kubenovav1.KubeNova, namespace string) *corev1.Service
 				TimeoutSeconds: int32Ptr(10800), // 3 小时
 			},
 		}
-		service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+		service.Spec.ExternalTrafficPolicy = kn.GetWebExternalTrafficPolicy()
 
 		// 设置 HTTP NodePort
 		if kn.Spec.Web.NodePort != nil && kn.Spec.Web.NodePort.HTTPPort > 0 {
@@ -305,6 +381,30 @@ func buildWebService(kn *kubenovav1.KubeNova, namespace string) *corev1.Service
 			}
 			service.Spec.Ports = append(service.Spec.Ports, httpsPort)
 		}
+	} else if kn.Spec.Web.ExposeType == "loadbalancer" {
+		// LoadBalancer 模式
+		service.Spec.Type = corev1.ServiceTypeLoadBalancer
+		service.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+		service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{
+				TimeoutSeconds: int32Ptr(10800), // 3 小时
+			},
+		}
+		service.Spec.ExternalTrafficPolicy = kn.GetWebExternalTrafficPolicy()
+
+		if lb := kn.Spec.Web.LoadBalancer; lb != nil {
+			service.Spec.LoadBalancerIP = lb.LoadBalancerIP
+			if lb.LoadBalancerClass != "" {
+				service.Spec.LoadBalancerClass = &lb.LoadBalancerClass
+			}
+			service.Spec.LoadBalancerSourceRanges = lb.SourceRanges
+			if len(lb.Annotations) > 0 {
+				service.Annotations = make(map[string]string, len(lb.Annotations))
+				for k, v := range lb.Annotations {
+					service.Annotations[k] = v
+				}
+			}
+		}
 	}
 
 	return service
@@ -363,14 +463,12 @@ func buildWebIngress(kn *kubenovav1.KubeNova, namespace string) *networkingv1.In
 	}
 
 	// 配置 TLS
-	if kn.Spec.Web.Ingress.TLS != nil && kn.Spec.Web.Ingress.TLS.Enabled {
-		if kn.Spec.Web.Ingress.TLS.SecretName != "" {
-			ingress.Spec.TLS = []networkingv1.IngressTLS{
-				{
-					Hosts:      []string{kn.Spec.Web.Ingress.Host},
-					SecretName: kn.Spec.Web.Ingress.TLS.SecretName,
-				},
-			}
+	if secretName := kn.GetWebTLSSecretName(); secretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts:      []string{kn.Spec.Web.Ingress.Host},
+				SecretName: secretName,
+			},
 		}
 	}
 
@@ -411,6 +509,11 @@ func getIngressAnnotations(kn *kubenovav1.KubeNova) map[string]string {
 		annotations["nginx.ingress.kubernetes.io/force-ssl-redirect"] = "true"
 	}
 
+	// IP 黑白名单
+	for k, v := range getIngressAccessAnnotations(kn) {
+		annotations[k] = v
+	}
+
 	// 合并用户自定义注解
 	if kn.Spec.Web.Ingress != nil && len(kn.Spec.Web.Ingress.Annotations) > 0 {
 		for k, v := range kn.Spec.Web.Ingress.Annotations {
@@ -442,44 +545,34 @@ func getWebResourceRequirements(kn *kubenovav1.KubeNova) corev1.ResourceRequirem
 // getWebVolumeMounts 获取 Web VolumeMounts
 func getWebVolumeMounts(kn *kubenovav1.KubeNova) []corev1.VolumeMount {
 	mounts := []corev1.VolumeMount{
-		{
-			Name:      "cache",
-			MountPath: "/var/cache/nginx",
-		},
-		{
-			Name:      "logs",
-			MountPath: "/var/log/nginx",
-		},
+		buildCacheVolumeMount("/var/cache/nginx", kn.Spec.Web.GetCacheVolume()),
+		buildLogsVolumeMount("/var/log/nginx", kn.Spec.Web.GetLogsVolume()),
 		{
 			Name:      "run",
 			MountPath: "/var/run",
 		},
 	}
 
-	// 使用自定义 ConfigMap 或默认生成的 ConfigMap
-	if kn.Spec.Web.CustomNginxConfigMap != "" {
-
+	// 自定义配置校验通过后复制到同一个 ConfigMap，挂载方式相同
+	if kn.Spec.Web.IsNginxReloadMode() {
+		mounts = append(mounts, getNginxReloadVolumeMounts()...)
+	} else {
+		mounts = append(mounts,
+			corev1.VolumeMount{
+				Name:      "nginx-config",
+				MountPath: "/etc/nginx/nginx.conf",
+				SubPath:   "nginx.conf",
+			},
+			corev1.VolumeMount{
+				Name:      "nginx-config",
+				MountPath: "/etc/nginx/conf.d/default.conf",
+				SubPath:   "default.conf",
+			},
+		)
 	}
 
-	mounts = append(mounts,
-		corev1.VolumeMount{
-			Name:      "nginx-config",
-			MountPath: "/etc/nginx/nginx.conf",
-			SubPath:   "nginx.conf",
-		},
-		corev1.VolumeMount{
-			Name:      "nginx-config",
-			MountPath: "/etc/nginx/conf.d/default.conf",
-			SubPath:   "default.conf",
-		},
-	)
-
 	// 如果启用 HTTPS (NodePort 模式)，挂载证书
-	if kn.Spec.Web.ExposeType == "nodeport" &&
-		kn.Spec.Web.NodePort != nil &&
-		kn.Spec.Web.NodePort.HTTPS != nil &&
-		kn.Spec.Web.NodePort.HTTPS.Enabled &&
-		kn.Spec.Web.NodePort.HTTPS.SecretName != "" {
+	if kn.IsNodePortHTTPSEnabled() {
 		mounts = append(mounts, corev1.VolumeMount{
 			Name:      "tls-certs",
 			MountPath: "/etc/nginx/certs",
@@ -487,14 +580,29 @@ func getWebVolumeMounts(kn *kubenovav1.KubeNova) []corev1.VolumeMount {
 		})
 	}
 
+	// 如果启用内部 TLS，挂载内部 CA 用于校验后端证书
+	if kn.IsInternalTLSEnabled() {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "internal-ca",
+			MountPath: webInternalCAMountPath,
+			ReadOnly:  true,
+		})
+	}
+
 	return mounts
 }
 
 // getWebVolumes 获取 Web Volumes
 func getWebVolumes(kn *kubenovav1.KubeNova, namespace string) []corev1.Volume {
-	configMapName := "frontend-nginx-config"
-	if kn.Spec.Web.CustomNginxConfigMap != "" {
-		configMapName = kn.Spec.Web.CustomNginxConfigMap
+	return getWebVolumesWithConfigMap(kn, NginxConfigMapName)
+}
+
+// getWebVolumesWithConfigMap 获取 Web Volumes，Nginx 配置从指定的 ConfigMap 挂载
+func getWebVolumesWithConfigMap(kn *kubenovav1.KubeNova, configMapName string) []corev1.Volume {
+	// ephemeral PVC 使用 Web 标签，便于查找
+	webClaimLabels := map[string]string{
+		"app":                          "kube-nova-web",
+		"app.kubernetes.io/managed-by": "kube-nova-operator",
 	}
 
 	volumes := []corev1.Volume{
@@ -518,22 +626,8 @@ func getWebVolumes(kn *kubenovav1.KubeNova, namespace string) []corev1.Volume {
 				},
 			},
 		},
-		{
-			Name: "cache",
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{
-					SizeLimit: resource.NewQuantity(1*1024*1024*1024, resource.BinarySI), // 1Gi
-				},
-			},
-		},
-		{
-			Name: "logs",
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{
-					SizeLimit: resource.NewQuantity(500*1024*1024, resource.BinarySI), // 500Mi
-				},
-			},
-		},
+		buildDataVolume("cache", kn.Spec.Web.GetCacheVolume(), resource.MustParse("1Gi"), webClaimLabels),
+		buildDataVolume("logs", kn.Spec.Web.GetLogsVolume(), resource.MustParse("500Mi"), webClaimLabels),
 		{
 			Name: "run",
 			VolumeSource: corev1.VolumeSource{
@@ -544,18 +638,43 @@ func getWebVolumes(kn *kubenovav1.KubeNova, namespace string) []corev1.Volume {
 		},
 	}
 
+	// reload 模式：挂载 bootstrap 配置，default.conf 单独以目录方式挂载到 conf.d
+	if kn.Spec.Web.IsNginxReloadMode() {
+		configVolume := volumes[0].ConfigMap
+		configVolume.Items = append(configVolume.Items, corev1.KeyToPath{
+			Key:  nginxBootstrapKey,
+			Path: nginxBootstrapKey,
+		})
+		volumes = append(volumes, buildNginxConfDVolume(configMapName))
+	}
+
 	// 如果启用 HTTPS (NodePort 模式)，添加证书 Volume
-	if kn.Spec.Web.ExposeType == "nodeport" &&
-		kn.Spec.Web.NodePort != nil &&
-		kn.Spec.Web.NodePort.HTTPS != nil &&
-		kn.Spec.Web.NodePort.HTTPS.Enabled &&
-		kn.Spec.Web.NodePort.HTTPS.SecretName != "" {
+	if kn.IsNodePortHTTPSEnabled() {
 		volumes = append(volumes, corev1.Volume{
 			Name: "tls-certs",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: kn.Spec.Web.NodePort.HTTPS.SecretName,
+					SecretName: kn.GetWebTLSSecretName(),
+					Optional:   boolPtr(false),
+				},
+			},
+		})
+	}
+
+	// 如果启用内部 TLS，添加内部 CA Volume(只挂载证书，不挂载 CA 私钥)
+	if kn.IsInternalTLSEnabled() {
+		volumes = append(volumes, corev1.Volume{
+			Name: "internal-ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: InternalCASecretName,
 					Optional:   boolPtr(false),
+					Items: []corev1.KeyToPath{
+						{
+							Key:  "ca.crt",
+							Path: "ca.crt",
+						},
+					},
 				},
 			},
 		})
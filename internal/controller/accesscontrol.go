@@ -0,0 +1,128 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+)
+
+// reconcileOAuth2Proxy 部署 deployment 模式的 oauth2-proxy
+// 需要在 Nginx 配置校验之前执行，否则 nginx -t 无法解析认证代理的 Service 地址；
+// 切换到其他模式或关闭认证后删除之前创建的资源
+func (r *KubeNovaReconciler) reconcileOAuth2Proxy(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
+	logger := log.FromContext(ctx)
+	namespace := kubenova.GetTargetNamespace()
+
+	deployment, service := builder.BuildOAuth2ProxyResources(kubenova, namespace)
+	if deployment == nil {
+		return r.deleteOAuth2Proxy(ctx, kubenova, namespace)
+	}
+
+	// 部署 Service
+	if err := r.setOwnership(kubenova, service); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+	existingSvc := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: namespace}, existingSvc); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取 oauth2-proxy Service 失败: %w", err)
+		}
+		logger.Info("创建 oauth2-proxy Service", "名称", service.Name)
+		if err := r.Create(ctx, service); err != nil {
+			return fmt.Errorf("创建 oauth2-proxy Service 失败: %w", err)
+		}
+	}
+
+	// 部署 Deployment
+	if err := r.setOwnership(kubenova, deployment); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+	existingDeploy := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: namespace}, existingDeploy); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取 oauth2-proxy Deployment 失败: %w", err)
+		}
+		logger.Info("创建 oauth2-proxy Deployment", "名称", deployment.Name)
+		if err := r.Create(ctx, deployment); err != nil {
+			return fmt.Errorf("创建 oauth2-proxy Deployment 失败: %w", err)
+		}
+		return nil
+	}
+
+	if !deploymentSpecEqual(&existingDeploy.Spec, &deployment.Spec) {
+		existingDeploy.Spec.Replicas = deployment.Spec.Replicas
+		existingDeploy.Spec.Template.Spec.Containers = deployment.Spec.Template.Spec.Containers
+
+		logger.Info("更新 oauth2-proxy Deployment", "名称", deployment.Name)
+		if err := r.Update(ctx, existingDeploy); err != nil {
+			return fmt.Errorf("更新 oauth2-proxy Deployment 失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteOAuth2Proxy 删除 deployment 模式创建的 oauth2-proxy
+func (r *KubeNovaReconciler) deleteOAuth2Proxy(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) error {
+	logger := log.FromContext(ctx)
+
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}} {
+		if err := r.Get(ctx, types.NamespacedName{Name: builder.OAuth2ProxyName, Namespace: namespace}, obj); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("获取 oauth2-proxy 失败: %w", err)
+		}
+		if !isManagedBy(kubenova, obj) {
+			continue
+		}
+		logger.Info("删除 oauth2-proxy", "类型", fmt.Sprintf("%T", obj), "名称", obj.GetName())
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("删除 oauth2-proxy 失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// ingressEqual 比较 Ingress 的规则、TLS 和注解
+// 已移除的 IP 黑白名单注解也视为变化
+func ingressEqual(existing, desired *networkingv1.Ingress) bool {
+	if !reflect.DeepEqual(existing.Spec.Rules, desired.Spec.Rules) ||
+		!reflect.DeepEqual(existing.Spec.TLS, desired.Spec.TLS) {
+		return false
+	}
+	for _, key := range []string{builder.IngressAllowListAnnotation, builder.IngressDenyListAnnotation} {
+		if _, ok := desired.Annotations[key]; !ok {
+			if _, exists := existing.Annotations[key]; exists {
+				return false
+			}
+		}
+	}
+	return objectMetadataSynced(existing, desired)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+)
+
+// alertingTokenSize 自动生成的 Webhook Token 字节数
+const alertingTokenSize = 32
+
+// ensureAlertingWebhookToken 启用告警接入且未配置 Webhook Token 时补全 Token
+// 优先沿用已保存的 Token，避免每次协调都生成新 Token 导致 Alertmanager 认证失败
+// Token 写入 kube-nova-secret，生成或修改后 manager-api 通过 SecretChecksumAnnotation 滚动更新加载新 Token
+func ensureAlertingWebhookToken(kubenova *kubenovav1.KubeNova, desired, existing *corev1.Secret) error {
+	if !kubenova.IsAlertingEnabled() || len(desired.Data[builder.AlertmanagerWebhookTokenKey]) > 0 {
+		return nil
+	}
+
+	if existing != nil {
+		if token := existing.Data[builder.AlertmanagerWebhookTokenKey]; len(token) > 0 {
+			desired.Data[builder.AlertmanagerWebhookTokenKey] = token
+			return nil
+		}
+	}
+
+	token, err := builder.GenerateRandomToken(alertingTokenSize)
+	if err != nil {
+		return err
+	}
+	desired.Data[builder.AlertmanagerWebhookTokenKey] = []byte(token)
+	return nil
+}
+
+// reconcileAlerting 生成指向 manager-api 的 Alertmanager 接收配置
+// 关闭告警接入或切换生成方式后删除之前生成的资源
+func (r *KubeNovaReconciler) reconcileAlerting(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
+	namespace := kubenova.GetTargetNamespace()
+
+	if config := builder.BuildAlertmanagerConfig(kubenova, namespace); config != nil {
+		if err := r.reconcileUnstructured(ctx, kubenova, config); err != nil {
+			return err
+		}
+	} else if err := r.deleteAlertmanagerConfig(ctx, kubenova, namespace); err != nil {
+		return err
+	}
+
+	if kubenova.IsAlertingEnabled() && kubenova.Spec.Alerting.GetAlertingMode() == kubenovav1.AlertingModeSecret {
+		return r.reconcileAlertmanagerReceiverSecret(ctx, kubenova, namespace)
+	}
+	return r.deleteAlertmanagerReceiverSecret(ctx, kubenova, namespace)
+}
+
+// reconcileAlertmanagerReceiverSecret 创建或更新 receiver 配置片段 Secret
+func (r *KubeNovaReconciler) reconcileAlertmanagerReceiverSecret(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) error {
+	logger := log.FromContext(ctx)
+
+	// Token 以 kube-nova-secret 中保存的值为准(可能是自动生成的)
+	tokenSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "kube-nova-secret", Namespace: namespace}, tokenSecret); err != nil {
+		return fmt.Errorf("获取 kube-nova-secret 失败: %w", err)
+	}
+	token := string(tokenSecret.Data[builder.AlertmanagerWebhookTokenKey])
+
+	var caCert []byte
+	if kubenova.IsInternalTLSEnabled() {
+		tlsSecret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: builder.InternalTLSSecretName("manager-api"), Namespace: namespace}, tlsSecret); err != nil {
+			return fmt.Errorf("获取 manager-api 内部证书失败: %w", err)
+		}
+		caCert = tlsSecret.Data["ca.crt"]
+	}
+
+	secret := builder.BuildAlertmanagerReceiverSecret(kubenova, namespace, token, caCert)
+	if err := r.setOwnership(kubenova, secret); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+
+	existing := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取告警 receiver Secret 失败: %w", err)
+		}
+		logger.Info("创建告警 receiver Secret", "名称", secret.Name)
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf("创建告警 receiver Secret 失败: %w", err)
+		}
+		return nil
+	}
+
+	if !reflect.DeepEqual(existing.Data, secret.Data) || !compareStringMap(existing.Labels, secret.Labels) {
+		existing.Data = secret.Data
+		existing.Labels = mergeStringMap(existing.Labels, secret.Labels)
+		logger.Info("更新告警 receiver Secret", "名称", secret.Name)
+		if err := r.Update(ctx, existing); err != nil {
+			return fmt.Errorf("更新告警 receiver Secret 失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteAlertmanagerConfig 删除之前生成的 AlertmanagerConfig
+// 集群未安装 Prometheus Operator CRD 时直接跳过
+func (r *KubeNovaReconciler) deleteAlertmanagerConfig(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) error {
+	config := &unstructured.Unstructured{}
+	config.SetGroupVersionKind(builder.AlertmanagerConfigGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.AlertingResourceName, Namespace: namespace}, config); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("获取 AlertmanagerConfig 失败: %w", err)
+	}
+	return r.deleteAlertingObject(ctx, kubenova, config)
+}
+
+// deleteAlertmanagerReceiverSecret 删除之前生成的 receiver 配置片段 Secret
+func (r *KubeNovaReconciler) deleteAlertmanagerReceiverSecret(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) error {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.AlertingResourceName, Namespace: namespace}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("获取告警 receiver Secret 失败: %w", err)
+	}
+	return r.deleteAlertingObject(ctx, kubenova, secret)
+}
+
+// deleteAlertingObject 删除由当前 KubeNova 管理的告警接入资源，其他来源的同名对象保持不变
+func (r *KubeNovaReconciler) deleteAlertingObject(ctx context.Context, kubenova *kubenovav1.KubeNova, obj client.Object) error {
+	if !isManagedBy(kubenova, obj) {
+		return nil
+	}
+	log.FromContext(ctx).Info("删除告警接入资源", "类型", fmt.Sprintf("%T", obj), "名称", obj.GetName())
+	if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("删除 %s 失败: %w", obj.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+)
+
+// reconcileCertificates 创建 cert-manager Certificate 并检查是否已签发
+// 返回 false 表示仍有证书未就绪，需要稍后重试
+func (r *KubeNovaReconciler) reconcileCertificates(ctx context.Context, kubenova *kubenovav1.KubeNova) (bool, error) {
+	logger := log.FromContext(ctx)
+	namespace := kubenova.GetTargetNamespace()
+
+	// 证书 Secret 由 cert-manager 创建，通过归属标签在续期时触发协调
+	secretLabels := map[string]string{
+		ownerNameLabel:      kubenova.Name,
+		ownerNamespaceLabel: kubenova.Namespace,
+	}
+	certificates := builder.BuildCertificates(kubenova, namespace, r.getNodeIP(ctx), secretLabels)
+	if len(certificates) == 0 {
+		meta.RemoveStatusCondition(&kubenova.Status.Conditions, kubenovav1.ConditionTypeCertificatesReady)
+		return true, nil
+	}
+
+	logger.Info("开始创建证书", "数量", len(certificates))
+
+	var pending []string
+	for _, certificate := range certificates {
+		if err := r.reconcileUnstructured(ctx, kubenova, certificate); err != nil {
+			return false, err
+		}
+
+		ready, message, err := r.isCertificateReady(ctx, certificate.GetName(), namespace)
+		if err != nil {
+			return false, err
+		}
+		if !ready {
+			pending = append(pending, fmt.Sprintf("%s(%s)", certificate.GetName(), message))
+		}
+	}
+
+	if len(pending) > 0 {
+		meta.SetStatusCondition(&kubenova.Status.Conditions, metav1.Condition{
+			Type:               kubenovav1.ConditionTypeCertificatesReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "CertificatesPending",
+			Message:            fmt.Sprintf("等待证书签发: %s", strings.Join(pending, ", ")),
+			ObservedGeneration: kubenova.Generation,
+		})
+		return false, nil
+	}
+
+	meta.SetStatusCondition(&kubenova.Status.Conditions, metav1.Condition{
+		Type:               kubenovav1.ConditionTypeCertificatesReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "CertificatesIssued",
+		Message:            "所有证书已签发",
+		ObservedGeneration: kubenova.Generation,
+	})
+	return true, nil
+}
+
+// isCertificateReady 检查 Certificate 的 Ready 条件
+func (r *KubeNovaReconciler) isCertificateReady(ctx context.Context, name, namespace string) (bool, string, error) {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(builder.CertificateGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, certificate); err != nil {
+		if errors.IsNotFound(err) {
+			return false, "创建中", nil
+		}
+		return false, "", fmt.Errorf("获取 Certificate %s 失败: %w", name, err)
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return condition["status"] == "True", message, nil
+	}
+	return false, "签发中", nil
+}
+
+// getWebTLSChecksum 获取 NodePort HTTPS 证书 Secret 的 checksum
+// 未启用 HTTPS 或 Secret 不存在时返回空字符串
+func (r *KubeNovaReconciler) getWebTLSChecksum(ctx context.Context, kubenova *kubenovav1.KubeNova) string {
+	if !kubenova.IsNodePortHTTPSEnabled() {
+		return ""
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      kubenova.GetWebTLSSecretName(),
+		Namespace: kubenova.GetTargetNamespace(),
+	}, secret); err != nil {
+		return ""
+	}
+	return builder.CalculateSecretChecksum(secret)
+}
+
+// isWebTLSChanged 检查 NodePort HTTPS 证书是否已变化但 Web 尚未滚动更新
+func (r *KubeNovaReconciler) isWebTLSChanged(ctx context.Context, kubenova *kubenovav1.KubeNova) bool {
+	checksum := r.getWebTLSChecksum(ctx, kubenova)
+	if checksum == "" {
+		return false
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      "kube-nova-web",
+		Namespace: kubenova.GetTargetNamespace(),
+	}, deployment); err != nil {
+		return false
+	}
+	return deployment.Spec.Template.Annotations[builder.TLSChecksumAnnotation] != checksum
+}
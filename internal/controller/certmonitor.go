@@ -0,0 +1,167 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/pki"
+	"github.com/yanshicheng/kube-nova-operator/internal/validator"
+)
+
+const (
+	// certificateWarningDays 证书剩余天数低于该值时发出 Warning 事件
+	certificateWarningDays = 30
+
+	// defaultRequeueInterval 默认的定期协调间隔
+	defaultRequeueInterval = 5 * time.Minute
+)
+
+// certificateDaysRemaining 证书剩余有效天数
+var certificateDaysRemaining = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "kubenova_certificate_days_remaining",
+		Help: "KubeNova 引用的 TLS 证书距离过期的剩余天数",
+	},
+	[]string{"namespace", "name", "source", "secret"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(certificateDaysRemaining)
+}
+
+// monitorCertificates 解析实例引用的 TLS 证书，更新 status.certificates 和监控指标
+// 证书即将过期或已过期时发出 Warning 事件(剩余天数变化时才发送，避免重复)
+func (r *KubeNovaReconciler) monitorCertificates(ctx context.Context, kubenova *kubenovav1.KubeNova) {
+	namespace := kubenova.GetTargetNamespace()
+	now := time.Now()
+
+	previous := make(map[string]kubenovav1.CertificateStatus, len(kubenova.Status.Certificates))
+	for _, status := range kubenova.Status.Certificates {
+		previous[status.Source+"/"+status.SecretName] = status
+	}
+
+	// 先清理旧指标，已不再引用的证书不会残留
+	certificateDaysRemaining.DeletePartialMatch(prometheus.Labels{
+		"namespace": kubenova.Namespace,
+		"name":      kubenova.Name,
+	})
+
+	var statuses []kubenovav1.CertificateStatus
+	for _, ref := range validator.TLSSecretRefs(kubenova) {
+		status := kubenovav1.CertificateStatus{
+			Source:     ref.Source,
+			SecretName: ref.Name,
+		}
+
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+			status.Message = fmt.Sprintf("获取 Secret 失败: %v", err)
+			statuses = append(statuses, status)
+			continue
+		}
+		cert, err := pki.ParseCertificate(secret.Data[ref.CertKey])
+		if err != nil {
+			status.Message = fmt.Sprintf("解析 %s 失败: %v", ref.CertKey, err)
+			statuses = append(statuses, status)
+			continue
+		}
+
+		remaining := cert.NotAfter.Sub(now)
+		notAfter := metav1.NewTime(cert.NotAfter)
+		status.Subject = cert.Subject.String()
+		status.SANs = append(status.SANs, cert.DNSNames...)
+		for _, ip := range cert.IPAddresses {
+			status.SANs = append(status.SANs, ip.String())
+		}
+		status.NotAfter = &notAfter
+		status.DaysRemaining = int32(math.Floor(remaining.Hours() / 24))
+		statuses = append(statuses, status)
+
+		certificateDaysRemaining.WithLabelValues(kubenova.Namespace, kubenova.Name, ref.Source, ref.Name).
+			Set(remaining.Hours() / 24)
+
+		if prev, ok := previous[ref.Source+"/"+ref.Name]; ok && prev.DaysRemaining == status.DaysRemaining && prev.NotAfter != nil {
+			continue
+		}
+		switch {
+		case remaining <= 0:
+			r.recordWarning(kubenova, "CertificateExpired",
+				fmt.Sprintf("%s 证书 %s 已于 %s 过期", ref.Source, ref.Name, cert.NotAfter.Format(time.RFC3339)))
+		case status.DaysRemaining < certificateWarningDays:
+			r.recordWarning(kubenova, "CertificateExpiringSoon",
+				fmt.Sprintf("%s 证书 %s 将在 %d 天后过期(%s)", ref.Source, ref.Name, status.DaysRemaining, cert.NotAfter.Format(time.RFC3339)))
+		}
+	}
+
+	kubenova.Status.Certificates = statuses
+}
+
+// nextCertificateCheck 根据证书状态计算下一次协调间隔
+// 在证书进入告警窗口、剩余天数变化或过期时及时协调，最长不超过默认间隔
+func nextCertificateCheck(kubenova *kubenovav1.KubeNova) time.Duration {
+	next := defaultRequeueInterval
+	now := time.Now()
+
+	for _, status := range kubenova.Status.Certificates {
+		if status.NotAfter == nil {
+			continue
+		}
+		notAfter := status.NotAfter.Time
+		for _, at := range []time.Time{
+			notAfter.Add(-certificateWarningDays * 24 * time.Hour),
+			notAfter.Add(-time.Duration(status.DaysRemaining) * 24 * time.Hour),
+			notAfter,
+		} {
+			if d := at.Sub(now); d > 0 && d < next {
+				next = d
+			}
+		}
+	}
+
+	// 避免过于频繁的协调
+	if next < time.Second {
+		next = time.Second
+	}
+	return next
+}
+
+// recordWarning 记录 Warning 事件
+func (r *KubeNovaReconciler) recordWarning(kubenova *kubenovav1.KubeNova, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(kubenova, corev1.EventTypeWarning, reason, message)
+}
+
+// deleteCertificateMetrics 删除实例对应的证书指标
+func deleteCertificateMetrics(kubenova *kubenovav1.KubeNova) {
+	certificateDaysRemaining.DeletePartialMatch(prometheus.Labels{
+		"namespace": kubenova.Namespace,
+		"name":      kubenova.Name,
+	})
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+)
+
+// dataBearingLists 返回数据类子资源的列表类型(Retain 策略下保留)
+// 证书 Secret 由 cert-manager 创建，同时保留 Certificate 使其继续续期
+func dataBearingLists() []client.ObjectList {
+	return []client.ObjectList{
+		&corev1.SecretList{},
+		&corev1.ConfigMapList{},
+		&corev1.PersistentVolumeClaimList{},
+		unstructuredList(builder.CertificateGVK),
+	}
+}
+
+// workloadLists 返回删除时需要主动删除并等待 Pod 退出的工作负载列表类型
+func workloadLists() []client.ObjectList {
+	return []client.ObjectList{
+		&appsv1.DeploymentList{},
+		&appsv1.StatefulSetList{},
+		&batchv1.CronJobList{},
+	}
+}
+
+// allOwnedLists 返回所有命名空间级子资源的列表类型(Orphan 策略下保留)
+func allOwnedLists() []client.ObjectList {
+	lists := append(dataBearingLists(), workloadLists()...)
+	return append(lists,
+		&corev1.ServiceList{},
+		&corev1.ServiceAccountList{},
+		&networkingv1.IngressList{},
+		&batchv1.JobList{},
+		unstructuredList(builder.HTTPRouteGVK),
+		unstructuredList(builder.GatewayGVK),
+		unstructuredList(builder.AlertmanagerConfigGVK),
+	)
+}
+
+// unstructuredList 构建第三方 CRD 资源的列表对象
+func unstructuredList(gvk schema.GroupVersionKind) client.ObjectList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return list
+}
+
+// backupSecret 将 kube-nova-secret 备份为带时间戳的 Secret
+// 时间戳取自 DeletionTimestamp，重复协调不会产生多个备份
+func (r *KubeNovaReconciler) backupSecret(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) error {
+	logger := log.FromContext(ctx)
+
+	source := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "kube-nova-secret", Namespace: namespace}, source); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("kube-nova-secret 不存在，跳过备份")
+			return nil
+		}
+		return fmt.Errorf("获取 kube-nova-secret 失败: %w", err)
+	}
+
+	backup := builder.BuildSecretBackup(kubenova, source, kubenova.DeletionTimestamp.Time)
+
+	existing := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: backup.Name, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取备份 Secret 失败: %w", err)
+		}
+		logger.Info("备份 kube-nova-secret", "备份名称", backup.Name)
+		if err := r.Create(ctx, backup); err != nil {
+			return fmt.Errorf("创建备份 Secret 失败: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// releaseOwnedResources 移除子资源上指向 KubeNova 的 OwnerReference 和归属标签
+// 移除后这些资源不会随 KubeNova 一起被垃圾回收或被 Finalizer 清理
+func (r *KubeNovaReconciler) releaseOwnedResources(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string, lists []client.ObjectList) error {
+	logger := log.FromContext(ctx)
+
+	for _, list := range lists {
+		if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			// 集群未安装对应 CRD 时跳过
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("获取资源列表失败: %w", err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("解析资源列表失败: %w", err)
+		}
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || !isManagedBy(kubenova, obj) {
+				continue
+			}
+
+			removeOwnership(kubenova, obj)
+
+			logger.Info("保留子资源", "类型", fmt.Sprintf("%T", obj), "名称", obj.GetName())
+			if err := r.Update(ctx, obj); err != nil {
+				return fmt.Errorf("移除 %s 的 OwnerReference 失败: %w", obj.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteClusterRoleBinding 删除实例对应的 ClusterRoleBinding
+func (r *KubeNovaReconciler) deleteClusterRoleBinding(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
+	logger := log.FromContext(ctx)
+
+	crbName := builder.ClusterRoleBindingName(kubenova, kubenova.GetTargetNamespace())
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := r.Get(ctx, types.NamespacedName{Name: crbName}, crb); err == nil {
+		logger.Info("删除 ClusterRoleBinding", "名称", crbName)
+		if err := r.Delete(ctx, crb); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "删除 ClusterRoleBinding 失败")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteOwnedResources 删除 KubeNova 管理的指定类型子资源，不等待删除完成
+func (r *KubeNovaReconciler) deleteOwnedResources(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string, lists []client.ObjectList) error {
+	logger := log.FromContext(ctx)
+
+	for _, list := range lists {
+		if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("获取资源列表失败: %w", err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("解析资源列表失败: %w", err)
+		}
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || !isManagedBy(kubenova, obj) || !obj.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+			logger.Info("删除子资源", "类型", fmt.Sprintf("%T", obj), "名称", obj.GetName())
+			if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("删除 %s 失败: %w", obj.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteInstanceClaims 删除实例的 PVC(托管依赖的数据卷)
+// StatefulSet 创建的 PVC 没有 OwnerReference，需要在 Pod 退出后主动删除
+func (r *KubeNovaReconciler) deleteInstanceClaims(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) error {
+	logger := log.FromContext(ctx)
+
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, claims,
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			"app.kubernetes.io/instance":   kubenova.Name,
+			"app.kubernetes.io/managed-by": "kube-nova-operator",
+		},
+	); err != nil {
+		return fmt.Errorf("获取 PVC 列表失败: %w", err)
+	}
+
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		if !claim.DeletionTimestamp.IsZero() {
+			continue
+		}
+		logger.Info("删除 PVC", "名称", claim.Name)
+		if err := r.Delete(ctx, claim); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("删除 PVC %s 失败: %w", claim.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteLabeledResources 删除跨命名空间部署时通过归属标签管理的子资源
+// 这些资源没有 OwnerReference，不会被垃圾回收
+func (r *KubeNovaReconciler) deleteLabeledResources(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string, lists []client.ObjectList) error {
+	logger := log.FromContext(ctx)
+
+	for _, list := range lists {
+		if err := r.List(ctx, list,
+			client.InNamespace(namespace),
+			client.MatchingLabels{
+				ownerNameLabel:      kubenova.Name,
+				ownerNamespaceLabel: kubenova.Namespace,
+			},
+		); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("获取资源列表失败: %w", err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("解析资源列表失败: %w", err)
+		}
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			logger.Info("删除子资源", "类型", fmt.Sprintf("%T", obj), "名称", obj.GetName())
+			// Job 默认不级联删除 Pod，统一使用后台级联删除
+			if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("删除 %s 失败: %w", obj.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// countInstancePods 统计实例仍在运行的 Pod 数量
+func (r *KubeNovaReconciler) countInstancePods(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) (int, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			"app.kubernetes.io/instance":   kubenova.Name,
+			"app.kubernetes.io/managed-by": "kube-nova-operator",
+		},
+	); err != nil {
+		return 0, err
+	}
+	return len(pods.Items), nil
+}
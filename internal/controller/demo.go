@@ -0,0 +1,181 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+)
+
+// reconcileDemoReset 创建或更新演示环境数据重置 CronJob，并记录最近一次重置时间
+// 关闭演示模式或删除 demo 配置后删除 CronJob
+func (r *KubeNovaReconciler) reconcileDemoReset(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
+	logger := log.FromContext(ctx)
+	namespace := kubenova.GetTargetNamespace()
+
+	existing := &batchv1.CronJob{}
+	found := true
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.DemoResetCronJobName, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取演示环境重置 CronJob 失败: %w", err)
+		}
+		found = false
+	}
+
+	if kubenova.GetDemoReset() == nil {
+		kubenova.Status.Demo = nil
+		if !found || !isManagedBy(kubenova, existing) {
+			return nil
+		}
+		logger.Info("删除演示环境重置 CronJob", "名称", existing.Name)
+		if err := r.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("删除演示环境重置 CronJob 失败: %w", err)
+		}
+		return nil
+	}
+
+	desired := builder.BuildDemoResetCronJob(kubenova, namespace)
+	if err := r.setOwnership(kubenova, desired); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+
+	if !found {
+		logger.Info("创建演示环境重置 CronJob", "名称", desired.Name, "计划", desired.Spec.Schedule)
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("创建演示环境重置 CronJob 失败: %w", err)
+		}
+		kubenova.Status.Demo = &kubenovav1.DemoStatus{CronJob: desired.Name}
+		return nil
+	}
+
+	if !cronJobSpecEqual(&existing.Spec, &desired.Spec) {
+		existing.Labels = mergeStringMap(existing.Labels, desired.Labels)
+		existing.Spec.Schedule = desired.Spec.Schedule
+		existing.Spec.TimeZone = desired.Spec.TimeZone
+		existing.Spec.Suspend = desired.Spec.Suspend
+		existing.Spec.ConcurrencyPolicy = desired.Spec.ConcurrencyPolicy
+		existing.Spec.JobTemplate = desired.Spec.JobTemplate
+		logger.Info("更新演示环境重置 CronJob", "名称", existing.Name, "计划", desired.Spec.Schedule)
+		if err := r.Update(ctx, existing); err != nil {
+			return fmt.Errorf("更新演示环境重置 CronJob 失败: %w", err)
+		}
+	}
+
+	r.setDemoStatus(ctx, kubenova, existing)
+	return nil
+}
+
+// refreshDemoStatus 根据 CronJob 状态更新 status.demo
+// 跳过协调时使用，获取失败时保留原状态
+func (r *KubeNovaReconciler) refreshDemoStatus(ctx context.Context, kubenova *kubenovav1.KubeNova) {
+	if kubenova.Status.Demo == nil {
+		return
+	}
+
+	existing := &batchv1.CronJob{}
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.DemoResetCronJobName, Namespace: kubenova.GetTargetNamespace()}, existing); err != nil {
+		log.FromContext(ctx).Error(err, "获取演示环境重置 CronJob 失败")
+		return
+	}
+	r.setDemoStatus(ctx, kubenova, existing)
+}
+
+// setDemoStatus 根据 CronJob 和最近一次失败的 Job 更新 status.demo
+// 新的重置失败记录 Warning 事件，避免失败只体现在 Job 上
+func (r *KubeNovaReconciler) setDemoStatus(ctx context.Context, kubenova *kubenovav1.KubeNova, cronJob *batchv1.CronJob) {
+	status := &kubenovav1.DemoStatus{
+		CronJob:          cronJob.Name,
+		LastResetTime:    cronJob.Status.LastSuccessfulTime,
+		LastScheduleTime: cronJob.Status.LastScheduleTime,
+		Active:           len(cronJob.Status.Active) > 0,
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs,
+		client.InNamespace(cronJob.Namespace),
+		client.MatchingLabels{
+			"app.kubernetes.io/instance":  kubenova.Name,
+			"app.kubernetes.io/component": builder.DemoResetComponent,
+		},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "获取演示环境重置 Job 失败")
+	} else if failure := latestJobFailure(cronJob, jobs.Items); failure != nil &&
+		(status.LastResetTime == nil || status.LastResetTime.Before(&failure.LastTransitionTime)) {
+		status.LastFailureTime = &failure.LastTransitionTime
+		status.LastFailureMessage = failure.Message
+	}
+
+	previous := kubenova.Status.Demo
+	if status.LastFailureTime != nil &&
+		(previous == nil || previous.LastFailureTime == nil || !previous.LastFailureTime.Equal(status.LastFailureTime)) {
+		r.recordWarning(kubenova, "DemoResetFailed", fmt.Sprintf("演示环境数据重置失败: %s", status.LastFailureMessage))
+	}
+	kubenova.Status.Demo = status
+}
+
+// latestJobFailure 返回 CronJob 创建的 Job 中最近一次失败的 Condition，没有失败时返回 nil
+func latestJobFailure(cronJob *batchv1.CronJob, jobs []batchv1.Job) *batchv1.JobCondition {
+	var latest *batchv1.JobCondition
+	for i := range jobs {
+		if !metav1.IsControlledBy(&jobs[i], cronJob) {
+			continue
+		}
+		for j := range jobs[i].Status.Conditions {
+			condition := &jobs[i].Status.Conditions[j]
+			if condition.Type != batchv1.JobFailed || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			if latest == nil || latest.LastTransitionTime.Before(&condition.LastTransitionTime) {
+				latest = condition
+			}
+		}
+	}
+	return latest
+}
+
+// cronJobSpecEqual 比较 CronJob 的计划、暂停状态和 Job 模板中的容器、卷
+func cronJobSpecEqual(existing, desired *batchv1.CronJobSpec) bool {
+	if existing.Schedule != desired.Schedule ||
+		!reflect.DeepEqual(existing.TimeZone, desired.TimeZone) ||
+		!compareBoolPtr(existing.Suspend, desired.Suspend) ||
+		existing.ConcurrencyPolicy != desired.ConcurrencyPolicy {
+		return false
+	}
+
+	existingJob, desiredJob := &existing.JobTemplate.Spec, &desired.JobTemplate.Spec
+	if !reflect.DeepEqual(existingJob.ActiveDeadlineSeconds, desiredJob.ActiveDeadlineSeconds) {
+		return false
+	}
+	existingPod, desiredPod := &existingJob.Template.Spec, &desiredJob.Template.Spec
+	return slices.EqualFunc(existingPod.InitContainers, desiredPod.InitContainers, containerEqual) &&
+		slices.EqualFunc(existingPod.Containers, desiredPod.Containers, containerEqual) &&
+		compareVolumes(existingPod.Volumes, desiredPod.Volumes) &&
+		slices.Equal(existingPod.ImagePullSecrets, desiredPod.ImagePullSecrets)
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+	"github.com/yanshicheng/kube-nova-operator/internal/pki"
+)
+
+// reconcileInternalTLS 生成并轮换内部 CA 和各服务证书
+// CA 轮换分两步进行：先发布包含新旧 CA 的信任链并滚动更新所有工作负载，
+// 待工作负载全部加载新信任链后再使用新 CA 重新签发服务证书，
+// 避免仍只信任旧 CA 的 Pod 拒绝新证书
+func (r *KubeNovaReconciler) reconcileInternalTLS(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
+	if !kubenova.IsInternalTLSEnabled() {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("开始检查内部 TLS 证书")
+
+	namespace := kubenova.GetTargetNamespace()
+	now := time.Now()
+	renewBefore := kubenova.GetInternalCertRenewBefore()
+
+	ca, err := r.reconcileInternalCA(ctx, kubenova, namespace, now)
+	if err != nil {
+		return err
+	}
+
+	// CA 轮换过渡期内，工作负载全部加载新信任链后才使用新 CA 重新签发服务证书
+	reissue := true
+	if ca.rotating {
+		reissue = r.isInternalCABundleRolledOut(ctx, kubenova, namespace, ca.bundle)
+		if !reissue {
+			logger.Info("内部 CA 已轮换，等待工作负载加载新信任链后再重新签发服务证书")
+		}
+	}
+
+	for _, service := range builder.InternalTLSServices() {
+		name := builder.InternalTLSSecretName(service)
+		dnsNames := builder.InternalTLSDNSNames(service, namespace)
+
+		existing := &corev1.Secret{}
+		found := true
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, existing); err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("获取内部证书 Secret %s 失败: %w", name, err)
+			}
+			found = false
+		}
+
+		// 证书不存在、即将过期或不是当前 CA 签发时重新签发；
+		// 轮换过渡期内上一代 CA 签发的证书继续使用，直到工作负载加载新信任链
+		cert := existing.Data[corev1.TLSCertKey]
+		valid := found && !pki.NeedsRenewal(cert, renewBefore, now) &&
+			(pki.IsSignedBy(cert, ca.keyPair.CertPEM, dnsNames) ||
+				(!reissue && pki.IsSignedBy(cert, ca.previous, dnsNames)))
+		if !valid {
+			serving, err := pki.GenerateServingCert(ca.keyPair, service, dnsNames, kubenova.GetInternalCertValidity())
+			if err != nil {
+				return fmt.Errorf("签发 %s 内部证书失败: %w", service, err)
+			}
+			secret := builder.BuildInternalTLSSecret(kubenova, namespace, name, map[string][]byte{
+				corev1.TLSCertKey:       serving.CertPEM,
+				corev1.TLSPrivateKeyKey: serving.KeyPEM,
+				"ca.crt":                ca.bundle,
+			})
+			if err := r.applyInternalTLSSecret(ctx, kubenova, secret, existing, found); err != nil {
+				return err
+			}
+			logger.Info("已签发内部证书", "服务", service)
+			continue
+		}
+
+		// 证书有效但信任链变化(如 CA 轮换、旧 CA 过期)时只更新 ca.crt
+		if !bytes.Equal(existing.Data["ca.crt"], ca.bundle) {
+			existing.Data["ca.crt"] = ca.bundle
+			if err := r.Update(ctx, existing); err != nil {
+				return fmt.Errorf("更新内部证书 Secret %s 失败: %w", name, err)
+			}
+		}
+	}
+
+	if ca.rotating && reissue {
+		if err := r.finishInternalCARotation(ctx, namespace); err != nil {
+			return err
+		}
+		logger.Info("内部 CA 轮换完成，服务证书已由新 CA 重新签发")
+	}
+
+	return nil
+}
+
+// internalCA 内部 CA 及其轮换状态
+type internalCA struct {
+	keyPair *pki.KeyPair
+	// previous 上一代 CA 证书，轮换过渡期内仍保留在信任链中
+	previous []byte
+	// bundle 下发给各服务的信任链
+	bundle []byte
+	// rotating CA 已轮换但服务证书尚未由新 CA 重新签发
+	rotating bool
+}
+
+// reconcileInternalCA 确保内部 CA 存在且未临近过期，返回 CA 密钥对、信任链和轮换状态
+// CA 轮换时在 CA Secret 上记录轮换时间，直到服务证书由新 CA 重新签发后清除
+func (r *KubeNovaReconciler) reconcileInternalCA(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string, now time.Time) (*internalCA, error) {
+	logger := log.FromContext(ctx)
+
+	existing := &corev1.Secret{}
+	found := true
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.InternalCASecretName, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("获取内部 CA Secret 失败: %w", err)
+		}
+		found = false
+	}
+
+	ca := &pki.KeyPair{}
+	previous := []byte(nil)
+	rotatedAt := ""
+	if found {
+		ca.CertPEM = existing.Data[corev1.TLSCertKey]
+		ca.KeyPEM = existing.Data[corev1.TLSPrivateKeyKey]
+		previous = existing.Data[builder.InternalCAPreviousKey]
+		rotatedAt = existing.Annotations[builder.InternalCARotatedAtAnnotation]
+	}
+
+	// CA 不存在或即将过期时生成新 CA，旧 CA 保留到信任链中
+	if !found || len(ca.KeyPEM) == 0 || pki.NeedsRenewal(ca.CertPEM, kubenova.GetInternalCertRenewBefore(), now) {
+		newCA, err := pki.GenerateCA(fmt.Sprintf("kube-nova-internal-ca.%s", namespace), kubenova.GetInternalCAValidity())
+		if err != nil {
+			return nil, err
+		}
+		if found && len(ca.CertPEM) > 0 {
+			logger.Info("内部 CA 即将过期，开始轮换")
+			previous = ca.CertPEM
+			rotatedAt = now.UTC().Format(time.RFC3339)
+		} else {
+			logger.Info("生成内部 CA")
+		}
+		ca = newCA
+	}
+
+	caBundle := pki.BuildCABundle(ca.CertPEM, previous, now)
+	data := map[string][]byte{
+		corev1.TLSCertKey:       ca.CertPEM,
+		corev1.TLSPrivateKeyKey: ca.KeyPEM,
+		"ca.crt":                caBundle,
+	}
+	if len(previous) > 0 && !bytes.Equal(caBundle, pki.BuildCABundle(ca.CertPEM, nil, now)) {
+		data[builder.InternalCAPreviousKey] = previous
+	}
+
+	result := &internalCA{keyPair: ca, previous: previous, bundle: caBundle, rotating: rotatedAt != ""}
+	if found && secretDataEqual(existing.Data, data) {
+		return result, nil
+	}
+
+	secret := builder.BuildInternalTLSSecret(kubenova, namespace, builder.InternalCASecretName, data)
+	if rotatedAt != "" {
+		secret.Annotations = map[string]string{builder.InternalCARotatedAtAnnotation: rotatedAt}
+	}
+	if err := r.applyInternalTLSSecret(ctx, kubenova, secret, existing, found); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isInternalCABundleRolledOut 检查使用内部证书的工作负载是否都已加载当前信任链并完成滚动更新
+// 后端服务挂载各自的服务证书 Secret，Web 挂载 CA Secret，未部署的工作负载跳过
+func (r *KubeNovaReconciler) isInternalCABundleRolledOut(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string, caBundle []byte) bool {
+	workloads := map[string]string{"kube-nova-web": builder.InternalCASecretName}
+	for _, service := range builder.InternalTLSServices() {
+		workloads[service] = builder.InternalTLSSecretName(service)
+	}
+
+	for name, secretName := range workloads {
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, deployment); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return false
+		}
+
+		// 挂载的 Secret 尚未包含新信任链，或 Pod 模板尚未引用最新的 Secret 内容
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+			return false
+		}
+		if !bytes.Equal(secret.Data["ca.crt"], caBundle) ||
+			deployment.Spec.Template.Annotations[builder.InternalTLSChecksumAnnotation] != builder.CalculateSecretChecksum(secret) {
+			return false
+		}
+
+		if !isDeploymentRolledOut(deployment) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDeploymentRolledOut 检查 Deployment 的滚动更新是否完成(旧 Pod 已全部替换且新 Pod 可用)
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == replicas &&
+		status.Replicas == replicas &&
+		status.AvailableReplicas >= replicas
+}
+
+// finishInternalCARotation 服务证书由新 CA 重新签发后清除 CA 轮换注解
+func (r *KubeNovaReconciler) finishInternalCARotation(ctx context.Context, namespace string) error {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.InternalCASecretName, Namespace: namespace}, secret); err != nil {
+		return fmt.Errorf("获取内部 CA Secret 失败: %w", err)
+	}
+	if _, ok := secret.Annotations[builder.InternalCARotatedAtAnnotation]; !ok {
+		return nil
+	}
+	delete(secret.Annotations, builder.InternalCARotatedAtAnnotation)
+	if err := r.Update(ctx, secret); err != nil {
+		return fmt.Errorf("更新内部 CA Secret 失败: %w", err)
+	}
+	return nil
+}
+
+// applyInternalTLSSecret 创建或更新内部证书 Secret
+func (r *KubeNovaReconciler) applyInternalTLSSecret(ctx context.Context, kubenova *kubenovav1.KubeNova, desired, existing *corev1.Secret, found bool) error {
+	if err := r.setOwnership(kubenova, desired); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+
+	if !found {
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("创建 Secret %s 失败: %w", desired.Name, err)
+		}
+		return nil
+	}
+
+	existing.Data = desired.Data
+	existing.Labels = mergeStringMap(existing.Labels, desired.Labels)
+	existing.Annotations = mergeStringMap(existing.Annotations, desired.Annotations)
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("更新 Secret %s 失败: %w", desired.Name, err)
+	}
+	return nil
+}
+
+// getSecretChecksum 获取 Secret 的 checksum(内部证书、JWT 密钥)，Secret 不存在时返回空字符串
+func (r *KubeNovaReconciler) getSecretChecksum(ctx context.Context, namespace, name string) string {
+	return builder.CalculateSecretChecksum(r.getSecret(ctx, namespace, name))
+}
+
+// getSecret 获取 Secret，Secret 不存在或获取失败时返回 nil
+func (r *KubeNovaReconciler) getSecret(ctx context.Context, namespace, name string) *corev1.Secret {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		return nil
+	}
+	return secret
+}
+
+// isInternalTLSRenewalDue 检查内部 CA 或服务证书是否需要轮换
+func (r *KubeNovaReconciler) isInternalTLSRenewalDue(ctx context.Context, kubenova *kubenovav1.KubeNova) bool {
+	if !kubenova.IsInternalTLSEnabled() {
+		return false
+	}
+
+	namespace := kubenova.GetTargetNamespace()
+	renewBefore := kubenova.GetInternalCertRenewBefore()
+	now := time.Now()
+
+	names := []string{builder.InternalCASecretName}
+	for _, service := range builder.InternalTLSServices() {
+		names = append(names, builder.InternalTLSSecretName(service))
+	}
+
+	for _, name := range names {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+			return true
+		}
+		if pki.NeedsRenewal(secret.Data[corev1.TLSCertKey], renewBefore, now) {
+			return true
+		}
+		// CA 轮换未完成时持续调谐，等待工作负载滚动更新后重新签发服务证书
+		if _, ok := secret.Annotations[builder.InternalCARotatedAtAnnotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// secretDataEqual 比较 Secret 数据
+func secretDataEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if !bytes.Equal(v, b[k]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+)
+
+// jwtSecretSize 自动生成的 JWT 密钥字节数(十六进制编码后 64 字符)
+const jwtSecretSize = 32
+
+// reconcileJWTSecret 生成未配置的 JWT 密钥并按计划轮换
+// 轮换后旧密钥在过渡期内继续发布，过渡期结束后删除
+func (r *KubeNovaReconciler) reconcileJWTSecret(ctx context.Context, kubenova *kubenovav1.KubeNova) (*builder.JWTSecretState, error) {
+	if !kubenova.IsJWTSecretGenerated() {
+		kubenova.Status.JWT = nil
+		return nil, nil
+	}
+
+	logger := log.FromContext(ctx)
+	namespace := kubenova.GetTargetNamespace()
+	now := time.Now()
+
+	existing := &corev1.Secret{}
+	found := true
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.JWTSecretName, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("获取 JWT 密钥 Secret 失败: %w", err)
+		}
+		found = false
+	}
+
+	state := &builder.JWTSecretState{Data: make(map[string][]byte)}
+	if found {
+		state = builder.ParseJWTSecretState(existing)
+	}
+
+	changed := false
+	for _, key := range []string{builder.JWTAccessSecretKey, builder.JWTRefreshSecretKey} {
+		if len(state.Data[key]) > 0 {
+			continue
+		}
+		secret, err := builder.GenerateRandomToken(jwtSecretSize)
+		if err != nil {
+			return nil, err
+		}
+		state.Data[key] = []byte(secret)
+		changed = true
+	}
+	if changed || state.RotatedAt.IsZero() {
+		logger.Info("生成 JWT 密钥")
+		state.RotatedAt = now
+		changed = true
+	}
+
+	// 到达轮换周期时生成新密钥，当前密钥保留为旧密钥
+	if kubenova.IsJWTRotationEnabled() && !now.Before(state.RotatedAt.Add(kubenova.GetJWTRotationInterval())) {
+		rotated := make(map[string][]byte, 4)
+		rotated[builder.JWTAccessSecretPreviousKey] = state.Data[builder.JWTAccessSecretKey]
+		rotated[builder.JWTRefreshSecretPreviousKey] = state.Data[builder.JWTRefreshSecretKey]
+		for _, key := range []string{builder.JWTAccessSecretKey, builder.JWTRefreshSecretKey} {
+			secret, err := builder.GenerateRandomToken(jwtSecretSize)
+			if err != nil {
+				return nil, err
+			}
+			rotated[key] = []byte(secret)
+		}
+		logger.Info("轮换 JWT 密钥", "旧密钥保留时间", kubenova.GetJWTRotationGracePeriod().String())
+		state.Data = rotated
+		state.RotatedAt = now
+		state.PreviousExpiresAt = now.Add(kubenova.GetJWTRotationGracePeriod())
+		changed = true
+	}
+
+	// 过渡期结束后不再发布旧密钥
+	if !state.PreviousExpiresAt.IsZero() && !now.Before(state.PreviousExpiresAt) {
+		logger.Info("JWT 旧密钥过渡期结束，删除旧密钥")
+		delete(state.Data, builder.JWTAccessSecretPreviousKey)
+		delete(state.Data, builder.JWTRefreshSecretPreviousKey)
+		state.PreviousExpiresAt = time.Time{}
+		changed = true
+	}
+
+	if !found || changed {
+		secret := builder.BuildJWTSecret(kubenova, namespace, state)
+		if err := r.setOwnership(kubenova, secret); err != nil {
+			return nil, fmt.Errorf("设置 OwnerReference 失败: %w", err)
+		}
+		if !found {
+			if err := r.Create(ctx, secret); err != nil {
+				return nil, fmt.Errorf("创建 JWT 密钥 Secret 失败: %w", err)
+			}
+		} else {
+			existing.Data = secret.Data
+			existing.Labels = mergeStringMap(existing.Labels, secret.Labels)
+			existing.Annotations = mergeStringMap(existing.Annotations, secret.Annotations)
+			if state.PreviousExpiresAt.IsZero() {
+				delete(existing.Annotations, builder.JWTPreviousExpiresAtAnnotation)
+			}
+			if err := r.Update(ctx, existing); err != nil {
+				return nil, fmt.Errorf("更新 JWT 密钥 Secret 失败: %w", err)
+			}
+		}
+	}
+
+	kubenova.Status.JWT = buildJWTStatus(kubenova, state)
+	return state, nil
+}
+
+// buildJWTStatus 构建 JWT 密钥状态
+func buildJWTStatus(kubenova *kubenovav1.KubeNova, state *builder.JWTSecretState) *kubenovav1.JWTStatus {
+	rotatedAt := metav1.NewTime(state.RotatedAt)
+	status := &kubenovav1.JWTStatus{
+		Generated:        true,
+		LastRotationTime: &rotatedAt,
+	}
+	if kubenova.IsJWTRotationEnabled() {
+		next := metav1.NewTime(state.RotatedAt.Add(kubenova.GetJWTRotationInterval()))
+		status.NextRotationTime = &next
+	}
+	if !state.PreviousExpiresAt.IsZero() {
+		expire := metav1.NewTime(state.PreviousExpiresAt)
+		status.PreviousSecretExpireTime = &expire
+	}
+	return status
+}
+
+// isJWTRotationDue 检查是否到达 JWT 密钥轮换时间或旧密钥过渡期已结束
+func (r *KubeNovaReconciler) isJWTRotationDue(ctx context.Context, kubenova *kubenovav1.KubeNova) bool {
+	if !kubenova.IsJWTSecretGenerated() {
+		return false
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.JWTSecretName, Namespace: kubenova.GetTargetNamespace()}, secret); err != nil {
+		return true
+	}
+
+	state := builder.ParseJWTSecretState(secret)
+	now := time.Now()
+	if kubenova.IsJWTRotationEnabled() && !now.Before(state.RotatedAt.Add(kubenova.GetJWTRotationInterval())) {
+		return true
+	}
+	return !state.PreviousExpiresAt.IsZero() && !now.Before(state.PreviousExpiresAt)
+}
@@ -17,23 +17,30 @@ limitations under the License.
 package controller
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
@@ -48,20 +55,29 @@ const (
 // KubeNovaReconciler reconciles a KubeNova object
 type KubeNovaReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=apps.ikubeops.com,resources=kubenova,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps.ikubeops.com,resources=kubenova/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=apps.ikubeops.com,resources=kubenova/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;gateways,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=alertmanagerconfigs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;update;patch;delete
 
@@ -97,12 +113,29 @@ func (r *KubeNovaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// 维护模式(paused)：暂停所有资源写入，只更新状态
+	if kubenova.IsReconcilePaused() {
+		return r.reconcilePaused(ctx, kubenova)
+	}
+
 	// 检查 ObservedGeneration，避免不必要的 reconcile
 	// 这个检查帮助我们避免对已经处理过且没有变化的资源重复执行昂贵的操作
+	// 外部状态变化(LoadBalancer 地址、证书续期等)时即使 Spec 未变化也需要协调
 	if kubenova.Status.ObservedGeneration == kubenova.Generation &&
-		kubenova.Status.Phase == kubenovav1.PhaseReady {
+		kubenova.Status.Phase == kubenovav1.PhaseReady &&
+		!r.needsResync(ctx, kubenova) {
 		logger.Info("资源已处理且无变化，跳过 reconcile")
-		return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+		// 证书到期状态、托管依赖就绪状态和演示环境重置状态随时间变化，跳过协调时仍需更新
+		previous := kubenova.Status.DeepCopy()
+		r.monitorCertificates(ctx, kubenova)
+		r.refreshManagedDependencyStatus(ctx, kubenova)
+		r.refreshDemoStatus(ctx, kubenova)
+		if !reflect.DeepEqual(previous, &kubenova.Status) {
+			if err := r.updateStatusWithRetry(ctx, kubenova); err != nil {
+				logger.Error(err, "更新状态失败")
+			}
+		}
+		return ctrl.Result{RequeueAfter: nextCertificateCheck(kubenova)}, nil
 	}
 
 	// ========== 阶段 1: 验证配置 ==========
@@ -128,6 +161,10 @@ func (r *KubeNovaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{RequeueAfter: time.Minute}, err
 	}
 
+	// 更新证书到期状态
+	// 在后续阶段提前返回(如等待签发、引用校验失败)时，证书状态和告警事件仍随失败状态一起更新
+	r.monitorCertificates(ctx, kubenova)
+
 	// ========== 阶段 3: 创建 RBAC ==========
 	if err := r.reconcileRBAC(ctx, kubenova); err != nil {
 		logger.Error(err, "创建 RBAC 资源失败")
@@ -138,6 +175,49 @@ func (r *KubeNovaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{RequeueAfter: time.Minute}, err
 	}
 
+	// ========== 阶段 3.5: 签发证书 ==========
+	certificatesReady, err := r.reconcileCertificates(ctx, kubenova)
+	if err != nil {
+		logger.Error(err, "创建证书失败")
+		r.setStatusPhase(kubenova, kubenovav1.PhaseFailed, fmt.Sprintf("创建证书失败: %v", err))
+		if updateErr := r.updateStatusWithRetry(ctx, kubenova); updateErr != nil {
+			logger.Error(updateErr, "更新状态失败")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	if !certificatesReady {
+		logger.Info("等待 cert-manager 签发证书")
+		r.setStatusPhase(kubenova, kubenovav1.PhasePending, "等待 cert-manager 签发证书")
+		if updateErr := r.updateStatusWithRetry(ctx, kubenova); updateErr != nil {
+			logger.Error(updateErr, "更新状态失败")
+		}
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+
+	// ========== 阶段 3.6: 内部 TLS 证书 ==========
+	if err := r.reconcileInternalTLS(ctx, kubenova); err != nil {
+		logger.Error(err, "签发内部 TLS 证书失败")
+		r.setStatusPhase(kubenova, kubenovav1.PhaseFailed, fmt.Sprintf("签发内部 TLS 证书失败: %v", err))
+		if updateErr := r.updateStatusWithRetry(ctx, kubenova); updateErr != nil {
+			logger.Error(updateErr, "更新状态失败")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// ========== 阶段 3.7: 校验引用的 Secret 和 ConfigMap ==========
+	// 校验失败只记录 Condition 并跳过使用该引用的组件，其余组件继续协调
+	invalidRefs := r.reconcileReferences(ctx, kubenova)
+
+	// ========== 阶段 3.8: 部署托管依赖 ==========
+	if err := r.reconcileManagedDependencies(ctx, kubenova); err != nil {
+		logger.Error(err, "部署托管依赖失败")
+		r.setStatusPhase(kubenova, kubenovav1.PhaseFailed, fmt.Sprintf("部署托管依赖失败: %v", err))
+		if updateErr := r.updateStatusWithRetry(ctx, kubenova); updateErr != nil {
+			logger.Error(updateErr, "更新状态失败")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
 	// ========== 阶段 4: 创建 Secret ==========
 	if err := r.reconcileSecret(ctx, kubenova); err != nil {
 		logger.Error(err, "创建 Secret 失败")
@@ -159,7 +239,9 @@ func (r *KubeNovaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	// ========== 阶段 6: 部署后端服务 ==========
-	if err := r.reconcileServices(ctx, kubenova); err != nil {
+	if blockedBy := invalidRefs.blocking(serviceReferenceConditions); blockedBy != "" {
+		logger.Info("引用资源校验失败，跳过部署后端服务", "Condition", blockedBy)
+	} else if err := r.reconcileServices(ctx, kubenova); err != nil {
 		logger.Error(err, "部署后端服务失败")
 		r.setStatusPhase(kubenova, kubenovav1.PhaseFailed, fmt.Sprintf("部署后端服务失败: %v", err))
 		if updateErr := r.updateStatusWithRetry(ctx, kubenova); updateErr != nil {
@@ -169,7 +251,9 @@ func (r *KubeNovaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	// ========== 阶段 7: 部署 Web 前端 ==========
-	if err := r.reconcileWeb(ctx, kubenova); err != nil {
+	if blockedBy := invalidRefs.blocking(webReferenceConditions); blockedBy != "" {
+		logger.Info("引用资源校验失败，跳过部署 Web 前端", "Condition", blockedBy)
+	} else if err := r.reconcileWeb(ctx, kubenova); err != nil {
 		logger.Error(err, "部署 Web 前端失败")
 		r.setStatusPhase(kubenova, kubenovav1.PhaseFailed, fmt.Sprintf("部署 Web 前端失败: %v", err))
 		if updateErr := r.updateStatusWithRetry(ctx, kubenova); updateErr != nil {
@@ -178,6 +262,28 @@ func (r *KubeNovaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{RequeueAfter: time.Minute}, err
 	}
 
+	// ========== 阶段 7.5: 告警接入 ==========
+	if err := r.reconcileAlerting(ctx, kubenova); err != nil {
+		logger.Error(err, "配置告警接入失败")
+		r.setStatusPhase(kubenova, kubenovav1.PhaseFailed, fmt.Sprintf("配置告警接入失败: %v", err))
+		if updateErr := r.updateStatusWithRetry(ctx, kubenova); updateErr != nil {
+			logger.Error(updateErr, "更新状态失败")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// ========== 阶段 7.6: 演示环境数据重置 ==========
+	if blockedBy := invalidRefs.blocking(demoReferenceConditions); blockedBy != "" {
+		logger.Info("引用资源校验失败，跳过配置演示环境数据重置", "Condition", blockedBy)
+	} else if err := r.reconcileDemoReset(ctx, kubenova); err != nil {
+		logger.Error(err, "配置演示环境数据重置失败")
+		r.setStatusPhase(kubenova, kubenovav1.PhaseFailed, fmt.Sprintf("配置演示环境数据重置失败: %v", err))
+		if updateErr := r.updateStatusWithRetry(ctx, kubenova); updateErr != nil {
+			logger.Error(updateErr, "更新状态失败")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
 	// ========== 阶段 8: 检查组件状态 ==========
 	// checkComponentStatus 会在函数内部调用 updateStatusWithRetry，统一更新所有状态
 	if err := r.checkComponentStatus(ctx, kubenova); err != nil {
@@ -187,8 +293,14 @@ func (r *KubeNovaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	// 引用资源修复后尽快恢复被跳过的组件
+	if len(invalidRefs) > 0 {
+		logger.Info("KubeNova 协调完成，部分组件因引用资源无效被跳过", "无效引用", invalidRefs)
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
 	logger.Info("KubeNova 协调完成")
-	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	return ctrl.Result{RequeueAfter: nextCertificateCheck(kubenova)}, nil
 }
 
 // updateStatusWithRetry 带重试机制的状态更新函数
@@ -245,27 +357,123 @@ func (r *KubeNovaReconciler) updateStatusWithRetry(ctx context.Context, kubenova
 }
 
 // reconcileDelete 处理删除逻辑
+// 根据 DeletionPolicy 决定子资源的去留：
+//   - Delete: 删除 ClusterRoleBinding 和工作负载，等待 Pod 全部退出后删除 PVC 和证书 Secret，
+//     其余资源通过 OwnerReference 自动删除(跨命名空间部署时根据归属标签主动删除)
+//   - Retain: 先移除 Secret/ConfigMap/PVC/Certificate 的 OwnerReference 将其保留，其余同 Delete
+//   - Orphan: 移除所有子资源的 OwnerReference，保留 ClusterRoleBinding，不等待 Pod
 func (r *KubeNovaReconciler) reconcileDelete(ctx context.Context, kubenova *kubenovav1.KubeNova) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
-	logger.Info("开始删除 KubeNova 资源")
+	policy := kubenova.GetDeletionPolicy()
+	logger.Info("开始删除 KubeNova 资源", "删除策略", policy)
+
+	namespace := kubenova.GetTargetNamespace()
 
-	r.setStatusPhase(kubenova, kubenovav1.PhaseDeleting, "正在删除资源")
+	// 目标命名空间被更早创建的实例使用时，固定名称和实例标签可能指向该实例的资源，
+	// 跳过清理直接移除 Finalizer，避免删除其他实例的子资源
+	conflict, err := validator.FindTargetNamespaceConflict(ctx, r.Client, kubenova)
+	if err != nil {
+		logger.Error(err, "检查目标命名空间冲突失败")
+		return ctrl.Result{}, err
+	}
+	if conflict != nil {
+		logger.Info("目标命名空间被其他 KubeNova 使用，跳过清理子资源",
+			"命名空间", namespace, "KubeNova", conflict.Namespace+"/"+conflict.Name)
+		return r.removeFinalizer(ctx, kubenova)
+	}
+
+	r.setStatusPhase(kubenova, kubenovav1.PhaseDeleting, fmt.Sprintf("正在删除资源(删除策略: %s)", policy))
 	// 使用重试机制更新删除状态
 	_ = r.updateStatusWithRetry(ctx, kubenova)
 
-	// 只删除 ClusterRoleBinding（因为它是集群级资源）
-	// 其他命名空间级资源会通过 OwnerReference 自动删除
-	crbName := fmt.Sprintf("kube-nova-%s-%s-cluster-admin", kubenova.Namespace, kubenova.Name)
-	crb := &rbacv1.ClusterRoleBinding{}
-	if err := r.Get(ctx, types.NamespacedName{Name: crbName}, crb); err == nil {
-		logger.Info("删除 ClusterRoleBinding", "名称", crbName)
-		if err := r.Delete(ctx, crb); err != nil && !errors.IsNotFound(err) {
-			logger.Error(err, "删除 ClusterRoleBinding 失败")
+	// 备份 kube-nova-secret
+	if kubenova.IsSecretBackupOnDeleteEnabled() {
+		if err := r.backupSecret(ctx, kubenova, namespace); err != nil {
+			logger.Error(err, "备份 Secret 失败")
+			return ctrl.Result{}, err
+		}
+	}
+
+	switch policy {
+	case kubenovav1.DeletionPolicyOrphan:
+		if err := r.releaseOwnedResources(ctx, kubenova, namespace, allOwnedLists()); err != nil {
+			logger.Error(err, "移除子资源 OwnerReference 失败")
+			return ctrl.Result{}, err
+		}
+		logger.Info("删除策略为 Orphan，保留所有子资源和 ClusterRoleBinding")
+	default:
+		if policy == kubenovav1.DeletionPolicyRetain {
+			if err := r.releaseOwnedResources(ctx, kubenova, namespace, dataBearingLists()); err != nil {
+				logger.Error(err, "移除数据类资源 OwnerReference 失败")
+				return ctrl.Result{}, err
+			}
+		}
+
+		// ClusterRoleBinding 是集群级资源，无法通过 OwnerReference 自动删除
+		if err := r.deleteClusterRoleBinding(ctx, kubenova); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// 主动删除工作负载并等待 Pod 退出
+		// 否则 Finalizer 移除前 OwnerReference 级联删除不会开始
+		if err := r.deleteOwnedResources(ctx, kubenova, namespace, workloadLists()); err != nil {
+			logger.Error(err, "删除工作负载失败")
 			return ctrl.Result{}, err
 		}
+
+		remaining, err := r.countInstancePods(ctx, kubenova, namespace)
+		if err != nil {
+			logger.Error(err, "获取 Pod 列表失败")
+			return ctrl.Result{}, err
+		}
+		if remaining > 0 {
+			timeout := kubenova.GetPodTerminationTimeout()
+			if time.Since(kubenova.DeletionTimestamp.Time) < timeout {
+				logger.Info("等待 Pod 退出", "剩余 Pod 数", remaining)
+				r.setStatusPhase(kubenova, kubenovav1.PhaseDeleting, fmt.Sprintf("等待 %d 个 Pod 退出", remaining))
+				_ = r.updateStatusWithRetry(ctx, kubenova)
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			}
+			logger.Info("等待 Pod 退出超时，继续移除 Finalizer", "剩余 Pod 数", remaining, "超时时间", timeout)
+		}
+
+		// 跨命名空间的子资源没有 OwnerReference，需要主动清理
+		if kubenova.IsCrossNamespace() {
+			if err := r.deleteLabeledResources(ctx, kubenova, namespace, allOwnedLists()); err != nil {
+				logger.Error(err, "清理跨命名空间子资源失败")
+				return ctrl.Result{}, err
+			}
+		}
+
+		if policy == kubenovav1.DeletionPolicyDelete {
+			// cert-manager 创建的证书 Secret 只有归属标签，不会随 Certificate 删除
+			// 先删除 Certificate，避免 cert-manager 重新签发
+			if !kubenova.IsCrossNamespace() {
+				if err := r.deleteOwnedResources(ctx, kubenova, namespace, []client.ObjectList{unstructuredList(builder.CertificateGVK)}); err != nil {
+					logger.Error(err, "删除 Certificate 失败")
+					return ctrl.Result{}, err
+				}
+				if err := r.deleteLabeledResources(ctx, kubenova, namespace, []client.ObjectList{&corev1.SecretList{}}); err != nil {
+					logger.Error(err, "清理证书 Secret 失败")
+					return ctrl.Result{}, err
+				}
+			}
+			if err := r.deleteInstanceClaims(ctx, kubenova, namespace); err != nil {
+				logger.Error(err, "删除 PVC 失败")
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
-	// 移除 Finalizer，允许对象被真正删除
+	return r.removeFinalizer(ctx, kubenova)
+}
+
+// removeFinalizer 清理证书指标并移除 Finalizer，允许对象被真正删除
+func (r *KubeNovaReconciler) removeFinalizer(ctx context.Context, kubenova *kubenovav1.KubeNova) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	deleteCertificateMetrics(kubenova)
+
 	logger.Info("移除 Finalizer")
 	controllerutil.RemoveFinalizer(kubenova, kubenovaFinalizer)
 	if err := r.Update(ctx, kubenova); err != nil {
@@ -277,6 +485,20 @@ func (r *KubeNovaReconciler) reconcileDelete(ctx context.Context, kubenova *kube
 	return ctrl.Result{}, nil
 }
 
+// reconcilePaused 处理暂停协调的维护模式
+// 不创建、更新或删除任何子资源，只读取组件状态并更新 Status
+func (r *KubeNovaReconciler) reconcilePaused(ctx context.Context, kubenova *kubenovav1.KubeNova) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("维护模式已启用，暂停协调")
+
+	if err := r.checkComponentStatus(ctx, kubenova); err != nil {
+		logger.Error(err, "检查组件状态失败")
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+}
+
 // reconcileValidation 验证配置
 // 注意：此函数只修改内存中的状态，不调用 Status().Update()
 func (r *KubeNovaReconciler) reconcileValidation(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
@@ -299,6 +521,24 @@ func (r *KubeNovaReconciler) reconcileValidation(ctx context.Context, kubenova *
 		return err
 	}
 
+	// 同一目标命名空间只能部署一个实例，固定名称的子资源会被互相覆盖
+	conflict, err := validator.FindTargetNamespaceConflict(ctx, r.Client, kubenova)
+	if err != nil {
+		return err
+	}
+	if conflict != nil {
+		err := fmt.Errorf("目标命名空间 %s 已被 KubeNova %s/%s 使用，同一命名空间只能部署一个实例",
+			kubenova.GetTargetNamespace(), conflict.Namespace, conflict.Name)
+		meta.SetStatusCondition(&kubenova.Status.Conditions, metav1.Condition{
+			Type:               kubenovav1.ConditionTypeValidated,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NamespaceConflict",
+			Message:            err.Error(),
+			ObservedGeneration: kubenova.Generation,
+		})
+		return err
+	}
+
 	// 记录验证成功的 Condition
 	meta.SetStatusCondition(&kubenova.Status.Conditions, metav1.Condition{
 		Type:               kubenovav1.ConditionTypeValidated,
@@ -313,17 +553,135 @@ func (r *KubeNovaReconciler) reconcileValidation(ctx context.Context, kubenova *
 	return nil
 }
 
-// reconcileNamespace 检查 Namespace 是否存在
+// 引用资源校验失败时被跳过的组件
+var (
+	// serviceReferenceConditions 后端服务挂载的引用资源(MinIO 证书)
+	serviceReferenceConditions = []string{
+		kubenovav1.ConditionTypeStorageTLSValid,
+	}
+	// webReferenceConditions Web 前端使用的引用资源
+	webReferenceConditions = []string{
+		kubenovav1.ConditionTypeWebTLSValid,
+		kubenovav1.ConditionTypeCustomNginxConfigValid,
+		kubenovav1.ConditionTypeAuthProxySecretValid,
+	}
+	// demoReferenceConditions 演示环境数据重置使用的引用资源
+	demoReferenceConditions = []string{
+		kubenovav1.ConditionTypeDemoSeedValid,
+		kubenovav1.ConditionTypeStorageTLSValid,
+	}
+)
+
+// invalidReferences 校验失败的引用资源，键为 Condition 类型，值为失败原因
+type invalidReferences map[string]string
+
+// blocking 返回阻塞组件的第一个校验失败的 Condition 类型，没有时返回空字符串
+func (refs invalidReferences) blocking(conditionTypes []string) string {
+	for _, conditionType := range conditionTypes {
+		if _, ok := refs[conditionType]; ok {
+			return conditionType
+		}
+	}
+	return ""
+}
+
+// reconcileReferences 运行时校验引用的 TLS Secret、ConfigMap 等资源
+// 每类校验结果记录为独立的 Condition，避免 Pod 卡在 ContainerCreating 才发现问题；
+// 返回校验失败的引用，调用者只跳过使用这些引用的组件
+func (r *KubeNovaReconciler) reconcileReferences(ctx context.Context, kubenova *kubenovav1.KubeNova) invalidReferences {
+	logger := log.FromContext(ctx)
+	logger.Info("开始校验引用资源")
+
+	results := validator.ValidateReferences(ctx, r.Client, kubenova)
+
+	applicable := make(map[string]bool, len(results))
+	invalid := make(invalidReferences)
+	for _, result := range results {
+		applicable[result.ConditionType] = true
+
+		condition := metav1.Condition{
+			Type:               result.ConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             result.Reason,
+			Message:            "校验通过",
+			ObservedGeneration: kubenova.Generation,
+		}
+		if result.Err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Message = result.Err.Error()
+			invalid[result.ConditionType] = result.Err.Error()
+			logger.Info("引用资源校验失败", "Condition", result.ConditionType, "原因", result.Err.Error())
+		}
+		meta.SetStatusCondition(&kubenova.Status.Conditions, condition)
+	}
+
+	// 移除当前配置下不再适用的校验 Condition
+	for _, conditionType := range []string{
+		kubenovav1.ConditionTypeWebTLSValid,
+		kubenovav1.ConditionTypeStorageTLSValid,
+		kubenovav1.ConditionTypeCustomNginxConfigValid,
+		kubenovav1.ConditionTypeAuthProxySecretValid,
+		kubenovav1.ConditionTypeDemoSeedValid,
+	} {
+		if !applicable[conditionType] {
+			meta.RemoveStatusCondition(&kubenova.Status.Conditions, conditionType)
+		}
+	}
+
+	return invalid
+}
+
+// isReferenceStatusChanged 检查引用资源的校验结果是否与 Condition 记录的不一致
+// 引用的 Secret 或 ConfigMap 修复、删除或证书过期后，即使 Spec 未变化也需要协调
+func (r *KubeNovaReconciler) isReferenceStatusChanged(ctx context.Context, kubenova *kubenovav1.KubeNova) bool {
+	for _, result := range validator.ValidateReferences(ctx, r.Client, kubenova) {
+		condition := meta.FindStatusCondition(kubenova.Status.Conditions, result.ConditionType)
+		if condition == nil || (condition.Status == metav1.ConditionTrue) != (result.Err == nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileNamespace 检查目标 Namespace 是否存在
+// 配置了 targetNamespaceOptions.create 时自动创建，并合并标签和注解
 func (r *KubeNovaReconciler) reconcileNamespace(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
 	logger := log.FromContext(ctx)
-	namespace := kubenova.Namespace
+	namespace := kubenova.GetTargetNamespace()
 
 	ns := &corev1.Namespace{}
 	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
-		if errors.IsNotFound(err) {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取命名空间失败: %w", err)
+		}
+		if !kubenova.ShouldCreateTargetNamespace() {
 			return fmt.Errorf("命名空间 %s 不存在，请先创建命名空间", namespace)
 		}
-		return fmt.Errorf("获取命名空间失败: %w", err)
+
+		desired := builder.BuildNamespace(kubenova)
+		// 只记录用户配置的标签和注解，后续删除配置时同步删除
+		if opts := kubenova.Spec.TargetNamespaceOptions; opts != nil {
+			desired.Annotations = recordAppliedKeys(desired.Annotations, appliedLabelsAnnotation, opts.Labels)
+			desired.Annotations = recordAppliedKeys(desired.Annotations, appliedAnnotationsAnnotation, opts.Annotations)
+		}
+		logger.Info("创建命名空间", "命名空间", namespace)
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("创建命名空间 %s 失败: %w", namespace, err)
+		}
+		return nil
+	}
+
+	// 已存在的命名空间只同步用户配置的标签和注解，不覆盖其他字段
+	if kubenova.ShouldCreateTargetNamespace() {
+		opts := kubenova.Spec.TargetNamespaceOptions
+		desired := &metav1.ObjectMeta{Labels: opts.Labels, Annotations: opts.Annotations}
+		if !objectMetadataSynced(ns, desired) {
+			syncObjectMetadata(ns, desired)
+			logger.Info("更新命名空间标签和注解", "命名空间", namespace)
+			if err := r.Update(ctx, ns); err != nil {
+				return fmt.Errorf("更新命名空间 %s 失败: %w", namespace, err)
+			}
+		}
 	}
 
 	logger.Info("命名空间检查通过", "命名空间", namespace)
@@ -335,11 +693,11 @@ func (r *KubeNovaReconciler) reconcileRBAC(ctx context.Context, kubenova *kubeno
 	logger := log.FromContext(ctx)
 	logger.Info("开始创建 RBAC 资源")
 
-	namespace := kubenova.Namespace
+	namespace := kubenova.GetTargetNamespace()
 
 	// 创建 ServiceAccount
 	sa := builder.BuildServiceAccount(kubenova, namespace)
-	if err := controllerutil.SetControllerReference(kubenova, sa, r.Scheme); err != nil {
+	if err := r.setOwnership(kubenova, sa); err != nil {
 		return fmt.Errorf("设置 ServiceAccount OwnerReference 失败: %w", err)
 	}
 
@@ -379,21 +737,46 @@ func (r *KubeNovaReconciler) reconcileSecret(ctx context.Context, kubenova *kube
 	logger := log.FromContext(ctx)
 	logger.Info("开始创建 Secret")
 
-	namespace := kubenova.Namespace
+	namespace := kubenova.GetTargetNamespace()
 
 	// 获取 Node IP 和 NodePort（用于 MinIO 代理端点）
-	nodeIP := r.getNodeIP(ctx)
+	// LoadBalancer 模式下使用 Service 分配的外部地址
+	var nodeIP string
+	if kubenova.Spec.Web.ExposeType == "loadbalancer" {
+		nodeIP = r.getWebLoadBalancerAddress(ctx, namespace)
+	} else {
+		nodeIP = r.getNodeIP(ctx)
+	}
 	nodePort := r.getWebNodePort(ctx, kubenova, namespace)
 
+	// 未配置的 JWT 密钥由 Operator 生成并按计划轮换
+	jwtState, err := r.reconcileJWTSecret(ctx, kubenova)
+	if err != nil {
+		return err
+	}
+
+	// 托管依赖的密码由 Operator 生成
+	managedCredentials, err := r.getManagedCredentials(ctx, kubenova)
+	if err != nil {
+		return err
+	}
+
 	secret := builder.BuildSecret(kubenova, namespace, nodeIP, nodePort)
+	builder.ApplyJWTSecret(kubenova, secret, jwtState)
+	if err := builder.ApplyManagedCredentials(kubenova, secret, managedCredentials); err != nil {
+		return err
+	}
 
-	if err := controllerutil.SetControllerReference(kubenova, secret, r.Scheme); err != nil {
+	if err := r.setOwnership(kubenova, secret); err != nil {
 		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
 	}
 
 	existingSecret := &corev1.Secret{}
 	if err := r.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: namespace}, existingSecret); err != nil {
 		if errors.IsNotFound(err) {
+			if err := ensureAlertingWebhookToken(kubenova, secret, nil); err != nil {
+				return err
+			}
 			logger.Info("创建 Secret", "名称", secret.Name)
 			if err := r.Create(ctx, secret); err != nil {
 				return fmt.Errorf("创建 Secret 失败: %w", err)
@@ -402,6 +785,9 @@ func (r *KubeNovaReconciler) reconcileSecret(ctx context.Context, kubenova *kube
 			return fmt.Errorf("获取 Secret 失败: %w", err)
 		}
 	} else {
+		if err := ensureAlertingWebhookToken(kubenova, secret, existingSecret); err != nil {
+			return err
+		}
 		// 只在 Data 有变化时才更新
 		if !reflect.DeepEqual(existingSecret.Data, secret.Data) {
 			existingSecret.Data = secret.Data
@@ -464,16 +850,88 @@ func (r *KubeNovaReconciler) getWebNodePort(ctx context.Context, kubenova *kuben
 	return 30080
 }
 
+// getWebLoadBalancerAddress 获取 Web Service 分配的 LoadBalancer 外部地址
+// 地址尚未分配时返回空字符串
+func (r *KubeNovaReconciler) getWebLoadBalancerAddress(ctx context.Context, namespace string) string {
+	webSvc := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      "kube-nova-web",
+		Namespace: namespace,
+	}, webSvc); err != nil {
+		return ""
+	}
+
+	for _, ingress := range webSvc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+	}
+	return ""
+}
+
+// needsResync 检查 Spec 未变化时是否仍需要协调
+func (r *KubeNovaReconciler) needsResync(ctx context.Context, kubenova *kubenovav1.KubeNova) bool {
+	return r.isLoadBalancerAddressChanged(ctx, kubenova) ||
+		r.isWebTLSChanged(ctx, kubenova) ||
+		r.isInternalTLSRenewalDue(ctx, kubenova) ||
+		r.isJWTRotationDue(ctx, kubenova) ||
+		r.isNginxConfigChanged(ctx, kubenova) ||
+		r.isSecretChecksumChanged(ctx, kubenova) ||
+		r.isReferenceStatusChanged(ctx, kubenova)
+}
+
+// isSecretChecksumChanged 检查后端服务记录的 kube-nova-secret checksum 是否与 Secret 中该服务引用的键一致
+// 同一次协调中更新的 Secret 可能尚未同步到缓存，需要在 Secret 事件中补充滚动更新
+func (r *KubeNovaReconciler) isSecretChecksumChanged(ctx context.Context, kubenova *kubenovav1.KubeNova) bool {
+	namespace := kubenova.GetTargetNamespace()
+	secretKeys := builder.BuildServiceSecretKeys(kubenova, namespace)
+	secret := r.getSecret(ctx, namespace, builder.SecretName)
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments,
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			"app.kubernetes.io/instance":   kubenova.Name,
+			"app.kubernetes.io/managed-by": "kube-nova-operator",
+		},
+	); err != nil {
+		return false
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		recorded, ok := deployment.Spec.Template.Annotations[builder.SecretChecksumAnnotation]
+		if ok && recorded != builder.CalculateSecretKeysChecksum(secret, secretKeys[deployment.Name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLoadBalancerAddressChanged 检查 LoadBalancer 外部地址是否与状态中记录的不一致
+func (r *KubeNovaReconciler) isLoadBalancerAddressChanged(ctx context.Context, kubenova *kubenovav1.KubeNova) bool {
+	if kubenova.Spec.Web.ExposeType != "loadbalancer" {
+		return false
+	}
+	if kubenova.IsLoadBalancerPending() {
+		return true
+	}
+	address := r.getWebLoadBalancerAddress(ctx, kubenova.GetTargetNamespace())
+	return address != kubenova.Status.AccessInfo.LoadBalancerAddress
+}
+
 // reconcileConfigMaps 创建所有 ConfigMap
 func (r *KubeNovaReconciler) reconcileConfigMaps(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
 	logger := log.FromContext(ctx)
 	logger.Info("开始创建 ConfigMap")
 
-	namespace := kubenova.Namespace
+	namespace := kubenova.GetTargetNamespace()
 	configMaps := builder.BuildAllConfigMaps(kubenova, namespace)
 
 	for _, cm := range configMaps {
-		if err := controllerutil.SetControllerReference(kubenova, cm, r.Scheme); err != nil {
+		if err := r.setOwnership(kubenova, cm); err != nil {
 			return fmt.Errorf("设置 OwnerReference 失败: %w", err)
 		}
 
@@ -509,13 +967,30 @@ func (r *KubeNovaReconciler) reconcileServices(ctx context.Context, kubenova *ku
 
 	r.setStatusPhase(kubenova, kubenovav1.PhaseCreating, "正在部署后端服务")
 
-	namespace := kubenova.Namespace
+	namespace := kubenova.GetTargetNamespace()
 	services := builder.BuildAllServices(kubenova, namespace)
+	secretKeys := builder.BuildServiceSecretKeys(kubenova, namespace)
+	secret := r.getSecret(ctx, namespace, builder.SecretName)
 
 	for serviceName, resources := range services {
 		// 部署 Deployment
 		deployment := resources.Deployment
-		if err := controllerutil.SetControllerReference(kubenova, deployment, r.Scheme); err != nil {
+
+		// 服务通过 envFrom 读取 kube-nova-secret，配置引用的键变化后滚动更新使新配置生效
+		// (如 LoadBalancer 地址分配后 portal-rpc 使用的 MINIO_ENDPOINT_PROXY)
+		deployment.Spec.Template.Annotations[builder.SecretChecksumAnnotation] =
+			builder.CalculateSecretKeysChecksum(secret, secretKeys[serviceName])
+		// 内部证书轮换后滚动更新服务以加载新证书
+		if kubenova.IsInternalTLSEnabled() {
+			deployment.Spec.Template.Annotations[builder.InternalTLSChecksumAnnotation] =
+				r.getSecretChecksum(ctx, namespace, builder.InternalTLSSecretName(serviceName))
+		}
+		// JWT 密钥轮换后滚动更新 portal-rpc 以加载新密钥
+		if serviceName == "portal-rpc" && kubenova.IsJWTSecretGenerated() {
+			deployment.Spec.Template.Annotations[builder.JWTChecksumAnnotation] =
+				r.getSecretChecksum(ctx, namespace, builder.JWTSecretName)
+		}
+		if err := r.setOwnership(kubenova, deployment); err != nil {
 			return fmt.Errorf("设置 OwnerReference 失败: %w", err)
 		}
 
@@ -523,6 +998,7 @@ func (r *KubeNovaReconciler) reconcileServices(ctx context.Context, kubenova *ku
 		if err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: namespace}, existingDeploy); err != nil {
 			if errors.IsNotFound(err) {
 				logger.Info("创建 Deployment", "服务", serviceName, "名称", deployment.Name)
+				recordTemplateMetadata(deployment)
 				if err := r.Create(ctx, deployment); err != nil {
 					return fmt.Errorf("创建 Deployment %s 失败: %w", serviceName, err)
 				}
@@ -531,7 +1007,7 @@ func (r *KubeNovaReconciler) reconcileServices(ctx context.Context, kubenova *ku
 			}
 		} else {
 			// 只在 Spec 有实质变化时才更新
-			if !deploymentSpecEqual(&existingDeploy.Spec, &deployment.Spec) {
+			if !deploymentSpecEqual(&existingDeploy.Spec, &deployment.Spec) || !templateMetadataSynced(existingDeploy, deployment) {
 				// 关键修复：重新获取最新对象，避免 resourceVersion 冲突
 				// 这是处理普通资源（非状态）更新冲突的标准方法
 				latestDeploy := &appsv1.Deployment{}
@@ -541,10 +1017,13 @@ func (r *KubeNovaReconciler) reconcileServices(ctx context.Context, kubenova *ku
 
 				// 更新 Spec
 				latestDeploy.Spec.Replicas = deployment.Spec.Replicas
+				syncTemplateMetadata(latestDeploy, deployment)
+				latestDeploy.Spec.Template.Spec.InitContainers = deployment.Spec.Template.Spec.InitContainers
 				latestDeploy.Spec.Template.Spec.Containers = deployment.Spec.Template.Spec.Containers
 				latestDeploy.Spec.Template.Spec.Volumes = deployment.Spec.Template.Spec.Volumes
 				latestDeploy.Spec.Template.Spec.ServiceAccountName = deployment.Spec.Template.Spec.ServiceAccountName
 				latestDeploy.Spec.Template.Spec.ImagePullSecrets = deployment.Spec.Template.Spec.ImagePullSecrets
+				latestDeploy.Spec.Template.Spec.TerminationGracePeriodSeconds = deployment.Spec.Template.Spec.TerminationGracePeriodSeconds
 
 				logger.Info("更新 Deployment", "服务", serviceName, "名称", deployment.Name)
 				if err := r.Update(ctx, latestDeploy); err != nil {
@@ -555,7 +1034,7 @@ func (r *KubeNovaReconciler) reconcileServices(ctx context.Context, kubenova *ku
 
 		// 部署 Service
 		service := resources.Service
-		if err := controllerutil.SetControllerReference(kubenova, service, r.Scheme); err != nil {
+		if err := r.setOwnership(kubenova, service); err != nil {
 			return fmt.Errorf("设置 OwnerReference 失败: %w", err)
 		}
 
@@ -563,12 +1042,20 @@ func (r *KubeNovaReconciler) reconcileServices(ctx context.Context, kubenova *ku
 		if err := r.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: namespace}, existingSvc); err != nil {
 			if errors.IsNotFound(err) {
 				logger.Info("创建 Service", "服务", serviceName, "名称", service.Name)
+				recordObjectMetadata(service)
 				if err := r.Create(ctx, service); err != nil {
 					return fmt.Errorf("创建 Service %s 失败: %w", serviceName, err)
 				}
 			} else {
 				return fmt.Errorf("获取 Service 失败: %w", err)
 			}
+		} else if err := r.updateService(ctx, serviceName, existingSvc, service); err != nil {
+			return err
+		}
+
+		// RPC 客户端使用 DNS 发现时部署 headless Service
+		if err := r.reconcileHeadlessService(ctx, kubenova, serviceName, namespace, resources.HeadlessService); err != nil {
+			return err
 		}
 	}
 
@@ -576,18 +1063,109 @@ func (r *KubeNovaReconciler) reconcileServices(ctx context.Context, kubenova *ku
 	return nil
 }
 
+// updateService 更新后端服务的 Service 端口
+// ClusterIP 不可修改，切换 headless 时删除并重建 Service
+func (r *KubeNovaReconciler) updateService(ctx context.Context, serviceName string, existing, desired *corev1.Service) error {
+	logger := log.FromContext(ctx)
+
+	existingHeadless := existing.Spec.ClusterIP == corev1.ClusterIPNone
+	desiredHeadless := desired.Spec.ClusterIP == corev1.ClusterIPNone
+	if existingHeadless != desiredHeadless {
+		logger.Info("Service 的 headless 设置变化，重建 Service", "服务", serviceName, "headless", desiredHeadless)
+		if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("删除 Service %s 失败: %w", serviceName, err)
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("创建 Service %s 失败: %w", serviceName, err)
+		}
+		return nil
+	}
+
+	if servicePortsEqual(existing.Spec.Ports, desired.Spec.Ports) {
+		return nil
+	}
+	existing.Spec.Ports = desired.Spec.Ports
+	logger.Info("更新 Service", "服务", serviceName, "名称", existing.Name)
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("更新 Service %s 失败: %w", serviceName, err)
+	}
+	return nil
+}
+
+// servicePortsEqual 比较 Service 端口的名称、端口号、目标端口和应用协议
+func servicePortsEqual(a, b []corev1.ServicePort) bool {
+	return slices.EqualFunc(a, b, func(x, y corev1.ServicePort) bool {
+		return x.Name == y.Name && x.Port == y.Port &&
+			x.TargetPort.String() == y.TargetPort.String() &&
+			derefString(x.AppProtocol) == derefString(y.AppProtocol)
+	})
+}
+
+// reconcileHeadlessService 创建或更新 headless Service 的端口，不再需要时删除之前创建的 headless Service
+func (r *KubeNovaReconciler) reconcileHeadlessService(ctx context.Context, kubenova *kubenovav1.KubeNova, serviceName, namespace string, desired *corev1.Service) error {
+	logger := log.FromContext(ctx)
+
+	existing := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: builder.HeadlessServiceName(serviceName), Namespace: namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("获取 headless Service 失败: %w", err)
+	}
+	found := err == nil
+
+	if desired == nil {
+		if !found || !isManagedBy(kubenova, existing) {
+			return nil
+		}
+		logger.Info("删除 headless Service", "服务", serviceName, "名称", existing.Name)
+		if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("删除 headless Service %s 失败: %w", serviceName, err)
+		}
+		return nil
+	}
+
+	if err := r.setOwnership(kubenova, desired); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+	if found {
+		// 不修改用户创建的同名 Service
+		if !isManagedBy(kubenova, existing) {
+			return nil
+		}
+		return r.updateService(ctx, serviceName, existing, desired)
+	}
+	logger.Info("创建 headless Service", "服务", serviceName, "名称", desired.Name)
+	if err := r.Create(ctx, desired); err != nil {
+		return fmt.Errorf("创建 headless Service %s 失败: %w", serviceName, err)
+	}
+	return nil
+}
+
 // reconcileWeb 部署 Web 前端
 func (r *KubeNovaReconciler) reconcileWeb(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
 	logger := log.FromContext(ctx)
 	logger.Info("开始部署 Web 前端")
 
-	namespace := kubenova.Namespace
-	webResources := builder.BuildWebResources(kubenova, namespace)
+	namespace := kubenova.GetTargetNamespace()
+
+	// 部署外部认证代理(deployment 模式)
+	if err := r.reconcileOAuth2Proxy(ctx, kubenova); err != nil {
+		return err
+	}
+
+	webResources, err := r.buildWebResources(ctx, kubenova)
+	if err != nil {
+		return err
+	}
+
+	// 更新配置前校验，未通过时保留当前配置
+	if err := r.reconcileNginxConfigValidation(ctx, kubenova, webResources); err != nil {
+		return err
+	}
 
 	// 部署 Nginx ConfigMap
 	if webResources.NginxConfigMap != nil {
 		cm := webResources.NginxConfigMap
-		if err := controllerutil.SetControllerReference(kubenova, cm, r.Scheme); err != nil {
+		if err := r.setOwnership(kubenova, cm); err != nil {
 			return fmt.Errorf("设置 OwnerReference 失败: %w", err)
 		}
 
@@ -615,7 +1193,24 @@ func (r *KubeNovaReconciler) reconcileWeb(ctx context.Context, kubenova *kubenov
 
 	// 部署 Deployment
 	deployment := webResources.Deployment
-	if err := controllerutil.SetControllerReference(kubenova, deployment, r.Scheme); err != nil {
+
+	// Nginx 不会自动重新加载证书，记录证书 checksum 以便证书续期后滚动更新
+	if checksum := r.getWebTLSChecksum(ctx, kubenova); checksum != "" {
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = make(map[string]string)
+		}
+		deployment.Spec.Template.Annotations[builder.TLSChecksumAnnotation] = checksum
+	}
+
+	// 内部 CA 轮换后滚动更新 Web，使 Nginx 加载新的信任链
+	if kubenova.IsInternalTLSEnabled() {
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = make(map[string]string)
+		}
+		deployment.Spec.Template.Annotations[builder.InternalTLSChecksumAnnotation] =
+			r.getSecretChecksum(ctx, namespace, builder.InternalCASecretName)
+	}
+	if err := r.setOwnership(kubenova, deployment); err != nil {
 		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
 	}
 
@@ -623,6 +1218,7 @@ func (r *KubeNovaReconciler) reconcileWeb(ctx context.Context, kubenova *kubenov
 	if err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: namespace}, existingDeploy); err != nil {
 		if errors.IsNotFound(err) {
 			logger.Info("创建 Web Deployment", "名称", deployment.Name)
+			recordTemplateMetadata(deployment)
 			if err := r.Create(ctx, deployment); err != nil {
 				return fmt.Errorf("创建 Web Deployment 失败: %w", err)
 			}
@@ -631,7 +1227,7 @@ func (r *KubeNovaReconciler) reconcileWeb(ctx context.Context, kubenova *kubenov
 		}
 	} else {
 		// 只在 Spec 有实质变化时才更新
-		if !deploymentSpecEqual(&existingDeploy.Spec, &deployment.Spec) {
+		if !deploymentSpecEqual(&existingDeploy.Spec, &deployment.Spec) || !templateMetadataSynced(existingDeploy, deployment) {
 			latestDeploy := &appsv1.Deployment{}
 			if err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: namespace}, latestDeploy); err != nil {
 				return fmt.Errorf("重新获取 Web Deployment 失败: %w", err)
@@ -639,9 +1235,13 @@ func (r *KubeNovaReconciler) reconcileWeb(ctx context.Context, kubenova *kubenov
 
 			// 更新 Spec
 			latestDeploy.Spec.Replicas = deployment.Spec.Replicas
+			syncTemplateMetadata(latestDeploy, deployment)
+			latestDeploy.Spec.Template.Spec.InitContainers = deployment.Spec.Template.Spec.InitContainers
 			latestDeploy.Spec.Template.Spec.Containers = deployment.Spec.Template.Spec.Containers
 			latestDeploy.Spec.Template.Spec.Volumes = deployment.Spec.Template.Spec.Volumes
 			latestDeploy.Spec.Template.Spec.ImagePullSecrets = deployment.Spec.Template.Spec.ImagePullSecrets
+			latestDeploy.Spec.Template.Spec.ShareProcessNamespace = deployment.Spec.Template.Spec.ShareProcessNamespace
+			latestDeploy.Spec.Template.Spec.TerminationGracePeriodSeconds = deployment.Spec.Template.Spec.TerminationGracePeriodSeconds
 
 			logger.Info("更新 Web Deployment", "名称", deployment.Name)
 			if err := r.Update(ctx, latestDeploy); err != nil {
@@ -652,7 +1252,7 @@ func (r *KubeNovaReconciler) reconcileWeb(ctx context.Context, kubenova *kubenov
 
 	// 部署 Service
 	service := webResources.Service
-	if err := controllerutil.SetControllerReference(kubenova, service, r.Scheme); err != nil {
+	if err := r.setOwnership(kubenova, service); err != nil {
 		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
 	}
 
@@ -660,18 +1260,29 @@ func (r *KubeNovaReconciler) reconcileWeb(ctx context.Context, kubenova *kubenov
 	if err := r.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: namespace}, existingSvc); err != nil {
 		if errors.IsNotFound(err) {
 			logger.Info("创建 Web Service", "名称", service.Name)
+			recordObjectMetadata(service)
 			if err := r.Create(ctx, service); err != nil {
 				return fmt.Errorf("创建 Web Service 失败: %w", err)
 			}
 		} else {
 			return fmt.Errorf("获取 Web Service 失败: %w", err)
 		}
+	} else if !webServiceEqual(existingSvc, service) {
+		// 暴露方式或 Service 配置变化时更新(保留已分配的 ClusterIP 和 NodePort)
+		// 离开 loadbalancer 时清除 LoadBalancer 专用字段和注解，避免遗留绕过 Ingress 和访问控制的外部入口
+		syncWebService(existingSvc, service)
+		syncObjectMetadata(existingSvc, service)
+
+		logger.Info("更新 Web Service", "名称", service.Name, "类型", service.Spec.Type)
+		if err := r.Update(ctx, existingSvc); err != nil {
+			return fmt.Errorf("更新 Web Service 失败: %w", err)
+		}
 	}
 
 	// 部署 Ingress
 	if webResources.Ingress != nil {
 		ingress := webResources.Ingress
-		if err := controllerutil.SetControllerReference(kubenova, ingress, r.Scheme); err != nil {
+		if err := r.setOwnership(kubenova, ingress); err != nil {
 			return fmt.Errorf("设置 OwnerReference 失败: %w", err)
 		}
 
@@ -679,19 +1290,156 @@ func (r *KubeNovaReconciler) reconcileWeb(ctx context.Context, kubenova *kubenov
 		if err := r.Get(ctx, types.NamespacedName{Name: ingress.Name, Namespace: namespace}, existingIngress); err != nil {
 			if errors.IsNotFound(err) {
 				logger.Info("创建 Ingress", "名称", ingress.Name)
+				recordObjectMetadata(ingress)
 				if err := r.Create(ctx, ingress); err != nil {
 					return fmt.Errorf("创建 Ingress 失败: %w", err)
 				}
 			} else {
 				return fmt.Errorf("获取 Ingress 失败: %w", err)
 			}
+		} else if !ingressEqual(existingIngress, ingress) {
+			// 清除已移除的 IP 黑白名单注解
+			for _, key := range []string{builder.IngressAllowListAnnotation, builder.IngressDenyListAnnotation} {
+				if _, ok := ingress.Annotations[key]; !ok {
+					delete(existingIngress.Annotations, key)
+				}
+			}
+			existingIngress.Spec.Rules = ingress.Spec.Rules
+			existingIngress.Spec.TLS = ingress.Spec.TLS
+			syncObjectMetadata(existingIngress, ingress)
+
+			logger.Info("更新 Ingress", "名称", ingress.Name)
+			if err := r.Update(ctx, existingIngress); err != nil {
+				return fmt.Errorf("更新 Ingress 失败: %w", err)
+			}
 		}
+	} else if err := r.deleteWebIngress(ctx, kubenova, namespace); err != nil {
+		return err
+	}
+
+	// 部署 Gateway(需先于 HTTPRoute 创建)
+	// 切换暴露方式或关闭监听器后删除之前创建的 Gateway
+	if webResources.Gateway != nil {
+		if err := r.reconcileUnstructured(ctx, kubenova, webResources.Gateway); err != nil {
+			return err
+		}
+	} else if err := r.deleteUnstructured(ctx, kubenova, builder.GatewayGVK, namespace, "kube-nova-web"); err != nil {
+		return err
+	}
+
+	// 部署 HTTPRoute
+	if webResources.HTTPRoute != nil {
+		if err := r.reconcileUnstructured(ctx, kubenova, webResources.HTTPRoute); err != nil {
+			return err
+		}
+	} else if err := r.deleteUnstructured(ctx, kubenova, builder.HTTPRouteGVK, namespace, "kube-nova-web"); err != nil {
+		return err
 	}
 
 	logger.Info("Web 前端部署完成")
 	return nil
 }
 
+// deleteWebIngress 切换暴露方式后删除之前创建的 Ingress
+func (r *KubeNovaReconciler) deleteWebIngress(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) error {
+	existing := &networkingv1.Ingress{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "kube-nova-web", Namespace: namespace}, existing); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("获取 Ingress 失败: %w", err)
+	}
+	if !isManagedBy(kubenova, existing) {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("删除 Ingress", "名称", existing.Name)
+	if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("删除 Ingress 失败: %w", err)
+	}
+	return nil
+}
+
+// webServiceEqual 比较 Web Service 的类型、端口、会话保持、外部流量策略、LoadBalancer 配置和元数据
+// 未指定的 NodePort 由集群分配，LoadBalancerClass 创建后不可修改，均不参与比较
+func webServiceEqual(existing, desired *corev1.Service) bool {
+	if existing.Spec.Type != desired.Spec.Type {
+		return false
+	}
+	if !servicePortsEqual(existing.Spec.Ports, desired.Spec.Ports) {
+		return false
+	}
+	for i, port := range desired.Spec.Ports {
+		if port.NodePort != 0 && existing.Spec.Ports[i].NodePort != port.NodePort {
+			return false
+		}
+	}
+	if cmp.Or(existing.Spec.SessionAffinity, corev1.ServiceAffinityNone) != cmp.Or(desired.Spec.SessionAffinity, corev1.ServiceAffinityNone) {
+		return false
+	}
+	if getSessionAffinityTimeout(existing) != getSessionAffinityTimeout(desired) {
+		return false
+	}
+	if existing.Spec.ExternalTrafficPolicy != desired.Spec.ExternalTrafficPolicy {
+		return false
+	}
+	if existing.Spec.LoadBalancerIP != desired.Spec.LoadBalancerIP {
+		return false
+	}
+	if len(existing.Spec.LoadBalancerSourceRanges) != len(desired.Spec.LoadBalancerSourceRanges) ||
+		(len(desired.Spec.LoadBalancerSourceRanges) > 0 &&
+			!reflect.DeepEqual(existing.Spec.LoadBalancerSourceRanges, desired.Spec.LoadBalancerSourceRanges)) {
+		return false
+	}
+	if desired.Spec.Type != corev1.ServiceTypeLoadBalancer && existing.Spec.LoadBalancerClass != nil {
+		return false
+	}
+	return objectMetadataSynced(existing, desired)
+}
+
+// syncWebService 将期望的 Web Service 配置同步到已有 Service
+// 未指定 NodePort 的端口沿用已分配的 NodePort；切换为其他类型时清除 LoadBalancer 专用字段
+func syncWebService(existing, desired *corev1.Service) {
+	ports := slices.Clone(desired.Spec.Ports)
+	if desired.Spec.Type != corev1.ServiceTypeClusterIP {
+		for i := range ports {
+			if ports[i].NodePort != 0 {
+				continue
+			}
+			if j := slices.IndexFunc(existing.Spec.Ports, func(p corev1.ServicePort) bool { return p.Name == ports[i].Name }); j >= 0 {
+				ports[i].NodePort = existing.Spec.Ports[j].NodePort
+			}
+		}
+	}
+
+	existing.Spec.Type = desired.Spec.Type
+	existing.Spec.Ports = ports
+	existing.Spec.SessionAffinity = desired.Spec.SessionAffinity
+	existing.Spec.SessionAffinityConfig = desired.Spec.SessionAffinityConfig
+	existing.Spec.ExternalTrafficPolicy = desired.Spec.ExternalTrafficPolicy
+	existing.Spec.LoadBalancerIP = desired.Spec.LoadBalancerIP
+	existing.Spec.LoadBalancerSourceRanges = desired.Spec.LoadBalancerSourceRanges
+	if desired.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		existing.Spec.LoadBalancerClass = nil
+		existing.Spec.AllocateLoadBalancerNodePorts = nil
+	}
+	if desired.Spec.Type != corev1.ServiceTypeLoadBalancer ||
+		desired.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyLocal {
+		existing.Spec.HealthCheckNodePort = 0
+	}
+}
+
+// getSessionAffinityTimeout 获取 ClientIP 会话保持的超时时间，未配置时为集群默认值
+func getSessionAffinityTimeout(service *corev1.Service) int32 {
+	if service.Spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		return 0
+	}
+	if cfg := service.Spec.SessionAffinityConfig; cfg != nil && cfg.ClientIP != nil && cfg.ClientIP.TimeoutSeconds != nil {
+		return *cfg.ClientIP.TimeoutSeconds
+	}
+	return corev1.DefaultClientIPServiceAffinitySeconds
+}
+
 // deploymentSpecEqual 比较 Deployment Spec
 func deploymentSpecEqual(existing, desired *appsv1.DeploymentSpec) bool {
 	if !compareInt32Ptr(existing.Replicas, desired.Replicas) {
@@ -700,19 +1448,16 @@ func deploymentSpecEqual(existing, desired *appsv1.DeploymentSpec) bool {
 	if len(existing.Template.Spec.Containers) != len(desired.Template.Spec.Containers) {
 		return false
 	}
-	if len(existing.Template.Spec.Containers) > 0 && len(desired.Template.Spec.Containers) > 0 {
-		existingContainer := existing.Template.Spec.Containers[0]
-		desiredContainer := desired.Template.Spec.Containers[0]
-		if existingContainer.Image != desiredContainer.Image {
-			return false
-		}
-		if !compareEnvVars(existingContainer.Env, desiredContainer.Env) {
-			return false
-		}
-		if !compareResourceRequirements(existingContainer.Resources, desiredContainer.Resources) {
+	for i := range desired.Template.Spec.Containers {
+		if !containerEqual(existing.Template.Spec.Containers[i], desired.Template.Spec.Containers[i]) {
 			return false
 		}
-		if !compareVolumeMounts(existingContainer.VolumeMounts, desiredContainer.VolumeMounts) {
+	}
+	if len(existing.Template.Spec.InitContainers) != len(desired.Template.Spec.InitContainers) {
+		return false
+	}
+	for i := range desired.Template.Spec.InitContainers {
+		if !containerEqual(existing.Template.Spec.InitContainers[i], desired.Template.Spec.InitContainers[i]) {
 			return false
 		}
 	}
@@ -722,9 +1467,85 @@ func deploymentSpecEqual(existing, desired *appsv1.DeploymentSpec) bool {
 	if existing.Template.Spec.ServiceAccountName != desired.Template.Spec.ServiceAccountName {
 		return false
 	}
+	if !compareBoolPtr(existing.Template.Spec.ShareProcessNamespace, desired.Template.Spec.ShareProcessNamespace) {
+		return false
+	}
+	if desired.Template.Spec.TerminationGracePeriodSeconds != nil &&
+		!reflect.DeepEqual(existing.Template.Spec.TerminationGracePeriodSeconds, desired.Template.Spec.TerminationGracePeriodSeconds) {
+		return false
+	}
+	if !compareStringMap(existing.Template.Annotations, desired.Template.Annotations) ||
+		!compareStringMap(existing.Template.Labels, desired.Template.Labels) {
+		return false
+	}
 	return true
 }
 
+// containerEqual 比较容器中由 Operator 或用户配置决定的字段
+// 用户提供的 initContainers/extraContainers 原样写入，期望中未设置的字段按 API Server 的默认值比较
+func containerEqual(existing, desired corev1.Container) bool {
+	if existing.Name != desired.Name || existing.Image != desired.Image {
+		return false
+	}
+	if existing.WorkingDir != desired.WorkingDir ||
+		existing.ImagePullPolicy != cmp.Or(desired.ImagePullPolicy, defaultImagePullPolicy(desired.Image)) ||
+		!reflect.DeepEqual(existing.RestartPolicy, desired.RestartPolicy) ||
+		existing.Stdin != desired.Stdin || existing.StdinOnce != desired.StdinOnce || existing.TTY != desired.TTY ||
+		existing.TerminationMessagePath != cmp.Or(desired.TerminationMessagePath, corev1.TerminationMessagePathDefault) ||
+		existing.TerminationMessagePolicy != cmp.Or(desired.TerminationMessagePolicy, corev1.TerminationMessageReadFile) ||
+		!slices.Equal(existing.VolumeDevices, desired.VolumeDevices) ||
+		!equality.Semantic.DeepEqual(existing.SecurityContext, desired.SecurityContext) {
+		return false
+	}
+	if !slices.Equal(existing.Command, desired.Command) ||
+		!slices.Equal(existing.Args, desired.Args) {
+		return false
+	}
+	if !compareEnvVars(existing.Env, desired.Env) || !compareEnvFrom(existing.EnvFrom, desired.EnvFrom) {
+		return false
+	}
+	if !compareResourceRequirements(existing.Resources, desired.Resources) {
+		return false
+	}
+	if !compareVolumeMounts(existing.VolumeMounts, desired.VolumeMounts) {
+		return false
+	}
+	if !compareContainerPorts(existing.Ports, desired.Ports) {
+		return false
+	}
+	if !compareProbe(existing.StartupProbe, desired.StartupProbe) ||
+		!compareProbe(existing.LivenessProbe, desired.LivenessProbe) ||
+		!compareProbe(existing.ReadinessProbe, desired.ReadinessProbe) {
+		return false
+	}
+	return compareLifecycle(existing.Lifecycle, desired.Lifecycle)
+}
+
+// compareStringMap 检查期望的键值是否都已存在(用于注解和标签)
+// 只比较期望的键，忽略其他组件(如 kubectl rollout restart)添加的键
+func compareStringMap(existing, desired map[string]string) bool {
+	for k, v := range desired {
+		if existing[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeStringMap 将期望的键值合并到已有的注解或标签中
+func mergeStringMap(existing, desired map[string]string) map[string]string {
+	if len(desired) == 0 {
+		return existing
+	}
+	if existing == nil {
+		existing = make(map[string]string, len(desired))
+	}
+	for k, v := range desired {
+		existing[k] = v
+	}
+	return existing
+}
+
 func compareInt32Ptr(a, b *int32) bool {
 	if a == nil && b == nil {
 		return true
@@ -735,25 +1556,67 @@ func compareInt32Ptr(a, b *int32) bool {
 	return *a == *b
 }
 
+func compareBoolPtr(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 func compareEnvVars(a, b []corev1.EnvVar) bool {
 	if len(a) != len(b) {
 		return false
 	}
 	aMap := make(map[string]string)
 	for _, env := range a {
-		if env.Value != "" {
-			aMap[env.Name] = env.Value
+		if value := envVarValue(env); value != "" {
+			aMap[env.Name] = value
 		}
 	}
 	bMap := make(map[string]string)
 	for _, env := range b {
-		if env.Value != "" {
-			bMap[env.Name] = env.Value
+		if value := envVarValue(env); value != "" {
+			bMap[env.Name] = value
 		}
 	}
 	return reflect.DeepEqual(aMap, bMap)
 }
 
+// envVarValue 获取用于比较的环境变量值，Secret 引用使用 Secret 名称和键
+func envVarValue(env corev1.EnvVar) string {
+	if from := env.ValueFrom; from != nil {
+		switch {
+		case from.SecretKeyRef != nil:
+			return "secret:" + from.SecretKeyRef.Name + "/" + from.SecretKeyRef.Key
+		case from.ConfigMapKeyRef != nil:
+			return "configMap:" + from.ConfigMapKeyRef.Name + "/" + from.ConfigMapKeyRef.Key
+		case from.FieldRef != nil:
+			return "field:" + from.FieldRef.FieldPath
+		case from.ResourceFieldRef != nil:
+			return "resource:" + from.ResourceFieldRef.ContainerName + "/" + from.ResourceFieldRef.Resource
+		}
+	}
+	return env.Value
+}
+
+// compareEnvFrom 比较环境变量来源的顺序、前缀和引用的对象
+func compareEnvFrom(a, b []corev1.EnvFromSource) bool {
+	return slices.EqualFunc(a, b, func(x, y corev1.EnvFromSource) bool {
+		return x.Prefix == y.Prefix && envFromSourceKey(x) == envFromSourceKey(y)
+	})
+}
+
+// envFromSourceKey 获取用于比较的环境变量来源
+func envFromSourceKey(s corev1.EnvFromSource) string {
+	switch {
+	case s.SecretRef != nil:
+		return "secret:" + s.SecretRef.Name
+	case s.ConfigMapRef != nil:
+		return "configMap:" + s.ConfigMapRef.Name
+	}
+	return ""
+}
+
 func compareResourceRequirements(a, b corev1.ResourceRequirements) bool {
 	return reflect.DeepEqual(a.Requests, b.Requests) && reflect.DeepEqual(a.Limits, b.Limits)
 }
@@ -762,40 +1625,208 @@ func compareVolumeMounts(a, b []corev1.VolumeMount) bool {
 	if len(a) != len(b) {
 		return false
 	}
+	// 同一个卷可以挂载到多个路径(如 Web 的 nginx-config)，按挂载路径索引
 	aMap := make(map[string]corev1.VolumeMount)
 	for _, vm := range a {
-		aMap[vm.Name] = vm
+		aMap[vm.MountPath] = vm
 	}
 	for _, vm := range b {
-		if existingVM, ok := aMap[vm.Name]; !ok || existingVM.MountPath != vm.MountPath {
+		existingVM, ok := aMap[vm.MountPath]
+		if !ok || existingVM.Name != vm.Name ||
+			existingVM.SubPath != vm.SubPath || existingVM.SubPathExpr != vm.SubPathExpr ||
+			existingVM.ReadOnly != vm.ReadOnly {
+			return false
+		}
+	}
+	return true
+}
+
+// compareContainerPorts 比较容器端口的名称、端口号和协议(未设置协议时为 TCP)
+func compareContainerPorts(a, b []corev1.ContainerPort) bool {
+	return slices.EqualFunc(a, b, func(x, y corev1.ContainerPort) bool {
+		return x.Name == y.Name && x.ContainerPort == y.ContainerPort &&
+			cmp.Or(x.Protocol, corev1.ProtocolTCP) == cmp.Or(y.Protocol, corev1.ProtocolTCP)
+	})
+}
+
+// compareProbe 比较探针的检查方式和期望中设置的时间参数
+// 未设置的时间参数由 API Server 填充默认值，不参与比较
+func compareProbe(existing, desired *corev1.Probe) bool {
+	if existing == nil || desired == nil {
+		return existing == nil && desired == nil
+	}
+	if !compareProbeHandler(existing.ProbeHandler, desired.ProbeHandler) {
+		return false
+	}
+	for _, pair := range [][2]int32{
+		{existing.InitialDelaySeconds, desired.InitialDelaySeconds},
+		{existing.PeriodSeconds, desired.PeriodSeconds},
+		{existing.TimeoutSeconds, desired.TimeoutSeconds},
+		{existing.FailureThreshold, desired.FailureThreshold},
+		{existing.SuccessThreshold, desired.SuccessThreshold},
+	} {
+		if pair[1] != 0 && pair[0] != pair[1] {
 			return false
 		}
 	}
 	return true
 }
 
+// compareProbeHandler 比较探针的检查方式
+func compareProbeHandler(a, b corev1.ProbeHandler) bool {
+	switch {
+	case b.HTTPGet != nil:
+		return a.HTTPGet != nil && a.HTTPGet.Path == b.HTTPGet.Path &&
+			a.HTTPGet.Port.String() == b.HTTPGet.Port.String() &&
+			a.HTTPGet.Host == b.HTTPGet.Host && slices.Equal(a.HTTPGet.HTTPHeaders, b.HTTPGet.HTTPHeaders) &&
+			cmp.Or(a.HTTPGet.Scheme, corev1.URISchemeHTTP) == cmp.Or(b.HTTPGet.Scheme, corev1.URISchemeHTTP)
+	case b.GRPC != nil:
+		return a.GRPC != nil && a.GRPC.Port == b.GRPC.Port &&
+			derefString(a.GRPC.Service) == derefString(b.GRPC.Service)
+	case b.TCPSocket != nil:
+		return a.TCPSocket != nil && a.TCPSocket.Port.String() == b.TCPSocket.Port.String()
+	case b.Exec != nil:
+		return a.Exec != nil && slices.Equal(a.Exec.Command, b.Exec.Command)
+	}
+	return a.HTTPGet == nil && a.GRPC == nil && a.TCPSocket == nil && a.Exec == nil
+}
+
+// compareLifecycle 比较容器的 postStart 和 preStop 配置
+func compareLifecycle(existing, desired *corev1.Lifecycle) bool {
+	if existing == nil {
+		existing = &corev1.Lifecycle{}
+	}
+	if desired == nil {
+		desired = &corev1.Lifecycle{}
+	}
+	return compareLifecycleHandler(existing.PostStart, desired.PostStart) &&
+		compareLifecycleHandler(existing.PreStop, desired.PreStop)
+}
+
+// compareLifecycleHandler 比较生命周期钩子的执行方式
+func compareLifecycleHandler(a, b *corev1.LifecycleHandler) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if !reflect.DeepEqual(a.Sleep, b.Sleep) {
+		return false
+	}
+	return compareProbeHandler(
+		corev1.ProbeHandler{Exec: a.Exec, HTTPGet: a.HTTPGet, TCPSocket: a.TCPSocket},
+		corev1.ProbeHandler{Exec: b.Exec, HTTPGet: b.HTTPGet, TCPSocket: b.TCPSocket})
+}
+
+// defaultImagePullPolicy API Server 填充的默认拉取策略：latest 或未指定标签时为 Always，否则为 IfNotPresent
+func defaultImagePullPolicy(image string) corev1.PullPolicy {
+	if strings.Contains(image, "@") {
+		return corev1.PullIfNotPresent
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i < 0 || name[i+1:] == "latest" {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
+
+// derefString 返回字符串指针的值，nil 时返回空字符串
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// compareVolumes 比较卷的名称和来源
+// 只比较来源类型和引用的对象，API Server 填充的默认值(如 defaultMode)不参与比较
 func compareVolumes(a, b []corev1.Volume) bool {
 	if len(a) != len(b) {
 		return false
 	}
-	aMap := make(map[string]bool)
+	aMap := make(map[string]string, len(a))
 	for _, v := range a {
-		aMap[v.Name] = true
+		aMap[v.Name] = volumeSourceKey(v.VolumeSource)
 	}
 	for _, v := range b {
-		if !aMap[v.Name] {
+		if source, ok := aMap[v.Name]; !ok || source != volumeSourceKey(v.VolumeSource) {
 			return false
 		}
 	}
 	return true
 }
 
+// volumeSourceKey 获取用于比较的卷来源
+// 常用来源比较引用的对象，其他来源只比较类型
+func volumeSourceKey(s corev1.VolumeSource) string {
+	switch {
+	case s.ConfigMap != nil:
+		return "configMap:" + s.ConfigMap.Name + keyToPathKey(s.ConfigMap.Items)
+	case s.Secret != nil:
+		return "secret:" + s.Secret.SecretName + keyToPathKey(s.Secret.Items)
+	case s.PersistentVolumeClaim != nil:
+		return fmt.Sprintf("pvc:%s:%t", s.PersistentVolumeClaim.ClaimName, s.PersistentVolumeClaim.ReadOnly)
+	case s.EmptyDir != nil:
+		key := "emptyDir:" + string(s.EmptyDir.Medium)
+		if s.EmptyDir.SizeLimit != nil {
+			key += ":" + s.EmptyDir.SizeLimit.String()
+		}
+		return key
+	case s.Ephemeral != nil && s.Ephemeral.VolumeClaimTemplate != nil:
+		spec := s.Ephemeral.VolumeClaimTemplate.Spec
+		key := "ephemeral:" + spec.Resources.Requests.Storage().String()
+		if spec.StorageClassName != nil {
+			key += ":" + *spec.StorageClassName
+		}
+		return key
+	case s.HostPath != nil:
+		return "hostPath:" + s.HostPath.Path
+	case s.NFS != nil:
+		return "nfs:" + s.NFS.Server + ":" + s.NFS.Path
+	case s.CSI != nil:
+		return "csi:" + s.CSI.Driver
+	case s.Projected != nil:
+		sources := make([]string, 0, len(s.Projected.Sources))
+		for _, p := range s.Projected.Sources {
+			switch {
+			case p.ConfigMap != nil:
+				sources = append(sources, "configMap:"+p.ConfigMap.Name+keyToPathKey(p.ConfigMap.Items))
+			case p.Secret != nil:
+				sources = append(sources, "secret:"+p.Secret.Name+keyToPathKey(p.Secret.Items))
+			case p.ServiceAccountToken != nil:
+				sources = append(sources, "token:"+p.ServiceAccountToken.Audience+"/"+p.ServiceAccountToken.Path)
+			case p.DownwardAPI != nil:
+				sources = append(sources, "downwardAPI")
+			}
+		}
+		return "projected:" + strings.Join(sources, ",")
+	}
+
+	v := reflect.ValueOf(s)
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsNil() {
+			return v.Type().Field(i).Name
+		}
+	}
+	return ""
+}
+
+// keyToPathKey 获取用于比较的 ConfigMap/Secret 挂载项
+func keyToPathKey(items []corev1.KeyToPath) string {
+	if len(items) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(items))
+	for _, item := range items {
+		pairs = append(pairs, item.Key+"="+item.Path)
+	}
+	return "[" + strings.Join(pairs, ",") + "]"
+}
+
 // checkComponentStatus 检查所有组件状态并统一更新
 func (r *KubeNovaReconciler) checkComponentStatus(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
 	logger := log.FromContext(ctx)
 	logger.Info("检查组件状态")
 
-	namespace := kubenova.Namespace
+	namespace := kubenova.GetTargetNamespace()
 
 	if kubenova.Status.ComponentStatus.Services == nil {
 		kubenova.Status.ComponentStatus.Services = make(map[string]*kubenovav1.ComponentStatus)
@@ -824,7 +1855,10 @@ func (r *KubeNovaReconciler) checkComponentStatus(ctx context.Context, kubenova
 			LastTransitionTime: metav1.Now(),
 		}
 
-		if deployment.Status.ReadyReplicas == *deployment.Spec.Replicas {
+		if *deployment.Spec.Replicas == 0 {
+			status.State = kubenovav1.ComponentStateReady
+			status.Message = "服务已缩容到 0"
+		} else if deployment.Status.ReadyReplicas == *deployment.Spec.Replicas {
 			status.State = kubenovav1.ComponentStateReady
 			status.Message = "服务运行正常"
 		} else if deployment.Status.ReadyReplicas > 0 {
@@ -868,6 +1902,9 @@ func (r *KubeNovaReconciler) checkComponentStatus(ctx context.Context, kubenova
 	// 更新访问信息
 	r.updateAccessInfo(ctx, kubenova, namespace)
 
+	// 更新证书到期状态
+	r.monitorCertificates(ctx, kubenova)
+
 	// 更新整体状态
 	if allReady {
 		r.setStatusPhase(kubenova, kubenovav1.PhaseReady, "所有组件运行正常")
@@ -889,9 +1926,48 @@ func (r *KubeNovaReconciler) checkComponentStatus(ctx context.Context, kubenova
 		})
 	}
 
+	// 维护模式下覆盖整体阶段
+	r.updateMaintenanceStatus(kubenova)
+
 	return r.updateStatusWithRetry(ctx, kubenova)
 }
 
+// updateMaintenanceStatus 根据维护模式更新阶段和 Maintenance Condition
+func (r *KubeNovaReconciler) updateMaintenanceStatus(kubenova *kubenovav1.KubeNova) {
+	switch {
+	case kubenova.IsReconcilePaused():
+		r.setStatusPhase(kubenova, kubenovav1.PhasePaused, "维护模式：协调已暂停，资源不会被修改")
+		meta.SetStatusCondition(&kubenova.Status.Conditions, metav1.Condition{
+			Type:               kubenovav1.ConditionTypeMaintenance,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ReconcilePaused",
+			Message:            "协调已暂停",
+			ObservedGeneration: kubenova.Generation,
+		})
+	case kubenova.IsMaintenancePageEnabled():
+		message := "维护模式：Web 前端返回维护页面"
+		if kubenova.IsBackendScaledDown() {
+			message += "，后端服务已缩容到 0"
+		}
+		r.setStatusPhase(kubenova, kubenovav1.PhaseMaintenance, message)
+		meta.SetStatusCondition(&kubenova.Status.Conditions, metav1.Condition{
+			Type:               kubenovav1.ConditionTypeMaintenance,
+			Status:             metav1.ConditionTrue,
+			Reason:             "MaintenancePageEnabled",
+			Message:            message,
+			ObservedGeneration: kubenova.Generation,
+		})
+	default:
+		meta.SetStatusCondition(&kubenova.Status.Conditions, metav1.Condition{
+			Type:               kubenovav1.ConditionTypeMaintenance,
+			Status:             metav1.ConditionFalse,
+			Reason:             "MaintenanceDisabled",
+			Message:            "未启用维护模式",
+			ObservedGeneration: kubenova.Generation,
+		})
+	}
+}
+
 func (r *KubeNovaReconciler) updateAccessInfo(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace string) {
 	logger := log.FromContext(ctx)
 
@@ -900,12 +1976,20 @@ func (r *KubeNovaReconciler) updateAccessInfo(ctx context.Context, kubenova *kub
 	}
 
 	// 更新 Web URL
-	if kubenova.Spec.Web.ExposeType == "ingress" && kubenova.Spec.Web.Ingress != nil {
+	if host := kubenova.GetWebHostname(); host != "" {
 		protocol := "http"
-		if kubenova.Spec.Web.Ingress.TLS != nil && kubenova.Spec.Web.Ingress.TLS.Enabled {
+		if kubenova.IsWebHostTLSEnabled() {
 			protocol = "https"
 		}
-		kubenova.Status.AccessInfo.WebURL = fmt.Sprintf("%s://%s", protocol, kubenova.Spec.Web.Ingress.Host)
+		kubenova.Status.AccessInfo.WebURL = fmt.Sprintf("%s://%s", protocol, host)
+	} else if kubenova.Spec.Web.ExposeType == "loadbalancer" {
+		address := r.getWebLoadBalancerAddress(ctx, namespace)
+		kubenova.Status.AccessInfo.LoadBalancerAddress = address
+		if address != "" {
+			kubenova.Status.AccessInfo.WebURL = fmt.Sprintf("%s://%s", kubenova.GetLoadBalancerScheme(), address)
+		} else {
+			kubenova.Status.AccessInfo.WebURL = "LoadBalancer (地址分配中...)"
+		}
 	} else if kubenova.Spec.Web.ExposeType == "nodeport" {
 		webSvc := &corev1.Service{}
 		if err := r.Get(ctx, types.NamespacedName{
@@ -956,9 +2040,13 @@ func (r *KubeNovaReconciler) updateAccessInfo(ctx context.Context, kubenova *kub
 		kubenova.Status.AccessInfo.ServiceEndpoints[svc] = fmt.Sprintf("%s.%s.svc.cluster.local", svc, namespace)
 	}
 
-	kubenova.Status.AccessInfo.DatabaseEndpoint = kubenova.Spec.Database.GetDatabaseEndpoint()
-	kubenova.Status.AccessInfo.CacheEndpoint = kubenova.Spec.Cache.GetCacheEndpoint()
+	kubenova.Status.AccessInfo.DatabaseEndpoint = fmt.Sprintf("%s:%d", kubenova.GetDatabaseHost(), kubenova.GetDatabasePort())
+	kubenova.Status.AccessInfo.CacheEndpoint = fmt.Sprintf("%s:%d", kubenova.GetCacheHost(), kubenova.GetCachePort())
 	kubenova.Status.AccessInfo.StorageEndpoint = kubenova.Spec.Storage.GetStorageEndpoint()
+	if kubenova.Spec.Storage.Managed {
+		// 托管 MinIO 不启用 TLS
+		kubenova.Status.AccessInfo.StorageEndpoint = "http://" + kubenova.GetStorageAddress()
+	}
 
 	if kubenova.IsTelemetryEnabled() {
 		kubenova.Status.AccessInfo.JaegerUIURL = kubenova.Spec.Telemetry.JaegerEndpoint
@@ -1027,10 +2115,22 @@ func (r *KubeNovaReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kubenovav1.KubeNova{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&networkingv1.Ingress{}).
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{}).
+		// 跨命名空间部署时子资源没有 OwnerReference，通过归属标签触发协调
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(r.mapOwnerLabels)).
+		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(r.mapOwnerLabels)).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(r.mapOwnerLabels)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.mapOwnerLabels)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.mapOwnerLabels)).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(r.mapOwnerLabels)).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.mapOwnerLabels)).
+		Watches(&batchv1.CronJob{}, handler.EnqueueRequestsFromMapFunc(r.mapOwnerLabels)).
 		Complete(r)
 }
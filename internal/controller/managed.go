@@ -0,0 +1,280 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+)
+
+// managedCredentialSize 自动生成的托管依赖凭据字节数
+const managedCredentialSize = 16
+
+// reconcileManagedDependencies 部署 Operator 托管的 MySQL、Redis 和 MinIO
+// 关闭 managed 后删除对应的 StatefulSet 和 Service，PVC 和凭据保留
+func (r *KubeNovaReconciler) reconcileManagedDependencies(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
+	namespace := kubenova.GetTargetNamespace()
+
+	if kubenova.IsManagedDependencyUsed() {
+		if err := r.reconcileManagedCredentials(ctx, kubenova); err != nil {
+			return err
+		}
+	}
+
+	deps := builder.BuildManagedDependencies(kubenova, namespace)
+	statuses := make([]kubenovav1.ManagedDependencyStatus, 0, len(deps))
+	enabled := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		ready, err := r.reconcileManagedStatefulSet(ctx, kubenova, dep.StatefulSet)
+		if err != nil {
+			return err
+		}
+		if err := r.reconcileManagedService(ctx, kubenova, dep.Service); err != nil {
+			return err
+		}
+		statuses = append(statuses, kubenovav1.ManagedDependencyStatus{
+			Name:     dep.Name,
+			Endpoint: dep.Endpoint,
+			Ready:    ready,
+		})
+		enabled = append(enabled, dep.StatefulSet.Name)
+	}
+
+	for _, name := range builder.ManagedDependencyNames {
+		if slices.Contains(enabled, name) {
+			continue
+		}
+		if err := r.deleteManagedDependency(ctx, kubenova, namespace, name); err != nil {
+			return err
+		}
+	}
+
+	if len(statuses) == 0 {
+		kubenova.Status.ManagedDependencies = nil
+		meta.RemoveStatusCondition(&kubenova.Status.Conditions, kubenovav1.ConditionTypeNonProduction)
+		return nil
+	}
+
+	names := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		names = append(names, s.Name)
+	}
+	kubenova.Status.ManagedDependencies = statuses
+	meta.SetStatusCondition(&kubenova.Status.Conditions, metav1.Condition{
+		Type:               kubenovav1.ConditionTypeNonProduction,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: kubenova.Generation,
+		Reason:             "ManagedDependencies",
+		Message:            fmt.Sprintf("使用 Operator 托管的单节点 %s，仅适用于试用和评估环境", strings.Join(names, ", ")),
+	})
+	return nil
+}
+
+// refreshManagedDependencyStatus 根据 StatefulSet 状态更新 status.managedDependencies 的就绪状态
+// 跳过协调时使用，获取失败时保留原状态
+func (r *KubeNovaReconciler) refreshManagedDependencyStatus(ctx context.Context, kubenova *kubenovav1.KubeNova) {
+	if len(kubenova.Status.ManagedDependencies) == 0 {
+		return
+	}
+
+	for _, dep := range builder.BuildManagedDependencies(kubenova, kubenova.GetTargetNamespace()) {
+		i := slices.IndexFunc(kubenova.Status.ManagedDependencies, func(s kubenovav1.ManagedDependencyStatus) bool {
+			return s.Name == dep.Name
+		})
+		if i < 0 {
+			continue
+		}
+		existing := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, types.NamespacedName{Name: dep.StatefulSet.Name, Namespace: dep.StatefulSet.Namespace}, existing); err != nil {
+			log.FromContext(ctx).Error(err, "获取托管依赖 StatefulSet 失败", "名称", dep.StatefulSet.Name)
+			continue
+		}
+		kubenova.Status.ManagedDependencies[i].Ready = existing.Status.ReadyReplicas > 0
+	}
+}
+
+// reconcileManagedCredentials 生成托管依赖的凭据
+// 已生成的凭据不会修改或删除，PVC 中的数据依赖这些密码
+func (r *KubeNovaReconciler) reconcileManagedCredentials(ctx context.Context, kubenova *kubenovav1.KubeNova) error {
+	namespace := kubenova.GetTargetNamespace()
+
+	existing := &corev1.Secret{}
+	found := true
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.ManagedCredentialsSecretName, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取托管依赖凭据失败: %w", err)
+		}
+		found = false
+	}
+
+	data := make(map[string][]byte, len(existing.Data))
+	for k, v := range existing.Data {
+		data[k] = v
+	}
+
+	changed := false
+	for _, key := range builder.ManagedCredentialKeys(kubenova) {
+		if len(data[key]) > 0 {
+			continue
+		}
+		value, err := builder.GenerateRandomToken(managedCredentialSize)
+		if err != nil {
+			return err
+		}
+		data[key] = []byte(value)
+		changed = true
+	}
+	if found && !changed {
+		return nil
+	}
+
+	secret := builder.BuildManagedCredentialsSecret(kubenova, namespace, data)
+	if err := r.setOwnership(kubenova, secret); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+	if !found {
+		log.FromContext(ctx).Info("生成托管依赖凭据", "名称", secret.Name)
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf("创建托管依赖凭据失败: %w", err)
+		}
+		return nil
+	}
+	existing.Data = secret.Data
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("更新托管依赖凭据失败: %w", err)
+	}
+	return nil
+}
+
+// getManagedCredentials 读取托管依赖的凭据(由 reconcileManagedDependencies 生成)
+func (r *KubeNovaReconciler) getManagedCredentials(ctx context.Context, kubenova *kubenovav1.KubeNova) (map[string][]byte, error) {
+	if !kubenova.IsManagedDependencyUsed() {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: builder.ManagedCredentialsSecretName, Namespace: kubenova.GetTargetNamespace()}, secret); err != nil {
+		return nil, fmt.Errorf("获取托管依赖凭据失败: %w", err)
+	}
+	return secret.Data, nil
+}
+
+// reconcileManagedStatefulSet 创建或更新托管依赖的 StatefulSet，返回是否就绪
+// volumeClaimTemplates 创建后不可修改，不参与比较
+func (r *KubeNovaReconciler) reconcileManagedStatefulSet(ctx context.Context, kubenova *kubenovav1.KubeNova, desired *appsv1.StatefulSet) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if err := r.setOwnership(kubenova, desired); err != nil {
+		return false, fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+
+	existing := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return false, fmt.Errorf("获取 StatefulSet %s 失败: %w", desired.Name, err)
+		}
+		logger.Info("创建托管依赖 StatefulSet", "名称", desired.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			return false, fmt.Errorf("创建 StatefulSet %s 失败: %w", desired.Name, err)
+		}
+		return false, nil
+	}
+
+	if !managedStatefulSetEqual(&existing.Spec, &desired.Spec) {
+		existing.Spec.Replicas = desired.Spec.Replicas
+		existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
+		existing.Spec.Template.Spec.ImagePullSecrets = desired.Spec.Template.Spec.ImagePullSecrets
+		logger.Info("更新托管依赖 StatefulSet", "名称", desired.Name)
+		if err := r.Update(ctx, existing); err != nil {
+			return false, fmt.Errorf("更新 StatefulSet %s 失败: %w", desired.Name, err)
+		}
+	}
+	return existing.Status.ReadyReplicas > 0, nil
+}
+
+// managedStatefulSetEqual 比较托管依赖 StatefulSet 的副本数、镜像拉取密钥和容器
+func managedStatefulSetEqual(existing, desired *appsv1.StatefulSetSpec) bool {
+	if !compareInt32Ptr(existing.Replicas, desired.Replicas) {
+		return false
+	}
+	if !slices.Equal(existing.Template.Spec.ImagePullSecrets, desired.Template.Spec.ImagePullSecrets) {
+		return false
+	}
+	return slices.EqualFunc(existing.Template.Spec.Containers, desired.Template.Spec.Containers, containerEqual)
+}
+
+// reconcileManagedService 创建或更新托管依赖的 Service
+func (r *KubeNovaReconciler) reconcileManagedService(ctx context.Context, kubenova *kubenovav1.KubeNova, desired *corev1.Service) error {
+	if err := r.setOwnership(kubenova, desired); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+
+	existing := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取 Service %s 失败: %w", desired.Name, err)
+		}
+		log.FromContext(ctx).Info("创建托管依赖 Service", "名称", desired.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("创建 Service %s 失败: %w", desired.Name, err)
+		}
+		return nil
+	}
+
+	if !servicePortsEqual(existing.Spec.Ports, desired.Spec.Ports) {
+		existing.Spec.Ports = desired.Spec.Ports
+		log.FromContext(ctx).Info("更新托管依赖 Service", "名称", desired.Name)
+		if err := r.Update(ctx, existing); err != nil {
+			return fmt.Errorf("更新 Service %s 失败: %w", desired.Name, err)
+		}
+	}
+	return nil
+}
+
+// deleteManagedDependency 删除关闭 managed 后不再需要的 StatefulSet 和 Service
+// 只删除由当前 KubeNova 管理的资源，PVC 保留
+func (r *KubeNovaReconciler) deleteManagedDependency(ctx context.Context, kubenova *kubenovav1.KubeNova, namespace, name string) error {
+	for _, obj := range []client.Object{&appsv1.StatefulSet{}, &corev1.Service{}} {
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, obj); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("获取 %s 失败: %w", name, err)
+		}
+		if !isManagedBy(kubenova, obj) {
+			continue
+		}
+		log.FromContext(ctx).Info("删除托管依赖资源", "类型", fmt.Sprintf("%T", obj), "名称", name)
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("删除 %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// 已写入键的记录注解
+// 标签和注解只合并不覆盖，以免删除其他控制器或 kubectl 写入的键(如 kubectl.kubernetes.io/restartedAt)；
+// 记录 Operator 上次写入的键后，才能删除用户从配置中移除的键
+const (
+	// appliedLabelsAnnotation 记录 Operator 写入对象的标签键
+	appliedLabelsAnnotation = "kubenova.io/applied-labels"
+	// appliedAnnotationsAnnotation 记录 Operator 写入对象的注解键
+	appliedAnnotationsAnnotation = "kubenova.io/applied-annotations"
+	// appliedPodLabelsAnnotation 记录 Operator 写入 Pod 模板的标签键
+	appliedPodLabelsAnnotation = "kubenova.io/applied-pod-labels"
+	// appliedPodAnnotationsAnnotation 记录 Operator 写入 Pod 模板的注解键
+	appliedPodAnnotationsAnnotation = "kubenova.io/applied-pod-annotations"
+)
+
+// appliedKeys 读取记录注解中上次写入的键
+func appliedKeys(annotations map[string]string, recordKey string) []string {
+	if annotations[recordKey] == "" {
+		return nil
+	}
+	return strings.Split(annotations[recordKey], ",")
+}
+
+// recordAppliedKeys 在记录注解中写入本次期望的键，没有期望的键时删除记录注解
+func recordAppliedKeys(annotations map[string]string, recordKey string, desired map[string]string) map[string]string {
+	keys := slices.Sorted(maps.Keys(desired))
+	if len(keys) == 0 {
+		delete(annotations, recordKey)
+		return annotations
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[recordKey] = strings.Join(keys, ",")
+	return annotations
+}
+
+// hasStaleKeys 检查是否存在上次写入但已不再期望的键
+func hasStaleKeys(existing, desired map[string]string, applied []string) bool {
+	for _, k := range applied {
+		if _, ok := desired[k]; ok {
+			continue
+		}
+		if _, ok := existing[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// syncStringMap 合并期望的键值，并删除上次写入但已不再期望的键
+func syncStringMap(existing, desired map[string]string, applied []string) map[string]string {
+	existing = mergeStringMap(existing, desired)
+	for _, k := range applied {
+		if _, ok := desired[k]; !ok {
+			delete(existing, k)
+		}
+	}
+	return existing
+}
+
+// recordObjectMetadata 在创建前记录对象的标签和注解键
+func recordObjectMetadata(obj metav1.Object) {
+	desiredAnnotations := withoutRecordKeys(obj.GetAnnotations())
+	annotations := recordAppliedKeys(obj.GetAnnotations(), appliedLabelsAnnotation, obj.GetLabels())
+	obj.SetAnnotations(recordAppliedKeys(annotations, appliedAnnotationsAnnotation, desiredAnnotations))
+}
+
+// objectMetadataSynced 比较对象的标签和注解，包括已从配置中移除的键
+func objectMetadataSynced(existing, desired metav1.Object) bool {
+	existingAnnotations := existing.GetAnnotations()
+	return compareStringMap(existing.GetLabels(), desired.GetLabels()) &&
+		compareStringMap(existingAnnotations, desired.GetAnnotations()) &&
+		!hasStaleKeys(existing.GetLabels(), desired.GetLabels(), appliedKeys(existingAnnotations, appliedLabelsAnnotation)) &&
+		!hasStaleKeys(existingAnnotations, desired.GetAnnotations(), appliedKeys(existingAnnotations, appliedAnnotationsAnnotation))
+}
+
+// syncObjectMetadata 将期望的标签和注解同步到已有对象，并更新记录注解
+func syncObjectMetadata(existing, desired metav1.Object) {
+	existingAnnotations := existing.GetAnnotations()
+	appliedLabels := appliedKeys(existingAnnotations, appliedLabelsAnnotation)
+	appliedAnnotations := appliedKeys(existingAnnotations, appliedAnnotationsAnnotation)
+	desiredAnnotations := withoutRecordKeys(desired.GetAnnotations())
+
+	existing.SetLabels(syncStringMap(existing.GetLabels(), desired.GetLabels(), appliedLabels))
+	annotations := syncStringMap(existingAnnotations, desiredAnnotations, appliedAnnotations)
+	annotations = recordAppliedKeys(annotations, appliedLabelsAnnotation, desired.GetLabels())
+	existing.SetAnnotations(recordAppliedKeys(annotations, appliedAnnotationsAnnotation, desiredAnnotations))
+}
+
+// recordTemplateMetadata 在创建前记录 Deployment Pod 模板的标签和注解键
+func recordTemplateMetadata(deployment *appsv1.Deployment) {
+	annotations := recordAppliedKeys(deployment.Annotations, appliedPodLabelsAnnotation, deployment.Spec.Template.Labels)
+	deployment.Annotations = recordAppliedKeys(annotations, appliedPodAnnotationsAnnotation, deployment.Spec.Template.Annotations)
+}
+
+// templateMetadataSynced 检查 Pod 模板中已从配置中移除的标签和注解是否已删除
+func templateMetadataSynced(existing, desired *appsv1.Deployment) bool {
+	return !hasStaleKeys(existing.Spec.Template.Labels, desired.Spec.Template.Labels,
+		appliedKeys(existing.Annotations, appliedPodLabelsAnnotation)) &&
+		!hasStaleKeys(existing.Spec.Template.Annotations, desired.Spec.Template.Annotations,
+			appliedKeys(existing.Annotations, appliedPodAnnotationsAnnotation))
+}
+
+// syncTemplateMetadata 将期望的 Pod 模板标签和注解同步到已有 Deployment，并更新记录注解
+func syncTemplateMetadata(existing, desired *appsv1.Deployment) {
+	template, desiredTemplate := &existing.Spec.Template, &desired.Spec.Template
+	template.Labels = syncStringMap(template.Labels, desiredTemplate.Labels,
+		appliedKeys(existing.Annotations, appliedPodLabelsAnnotation))
+	template.Annotations = syncStringMap(template.Annotations, desiredTemplate.Annotations,
+		appliedKeys(existing.Annotations, appliedPodAnnotationsAnnotation))
+	existing.Annotations = recordAppliedKeys(existing.Annotations, appliedPodLabelsAnnotation, desiredTemplate.Labels)
+	existing.Annotations = recordAppliedKeys(existing.Annotations, appliedPodAnnotationsAnnotation, desiredTemplate.Annotations)
+}
+
+// withoutRecordKeys 返回去除记录注解后的注解
+func withoutRecordKeys(annotations map[string]string) map[string]string {
+	if len(annotations) == 0 {
+		return annotations
+	}
+	result := maps.Clone(annotations)
+	for _, k := range []string{appliedLabelsAnnotation, appliedAnnotationsAnnotation,
+		appliedPodLabelsAnnotation, appliedPodAnnotationsAnnotation} {
+		delete(result, k)
+	}
+	return result
+}
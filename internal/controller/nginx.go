@@ -0,0 +1,257 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+	"github.com/yanshicheng/kube-nova-operator/internal/nginxconf"
+	"github.com/yanshicheng/kube-nova-operator/internal/validator"
+)
+
+// buildWebResources 构建 Web 资源
+// 引用了片段 ConfigMap 时将其内容合并到生成的配置中；
+// 使用自定义 Nginx ConfigMap 时将其内容复制到 frontend-nginx-config，ConfigMap 变化后滚动更新 Web
+func (r *KubeNovaReconciler) buildWebResources(ctx context.Context, kubenova *kubenovav1.KubeNova) (*builder.WebResources, error) {
+	namespace := kubenova.GetTargetNamespace()
+
+	desired, err := r.withNginxSnippetsFromConfigMap(ctx, kubenova)
+	if err != nil {
+		return nil, err
+	}
+	resources := builder.BuildWebResources(desired, namespace)
+
+	if name := kubenova.Spec.Web.CustomNginxConfigMap; name != "" {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cm); err != nil {
+			return nil, fmt.Errorf("获取自定义 Nginx ConfigMap %s 失败: %w", name, err)
+		}
+		resources.SetNginxConfigMap(kubenova, builder.BuildCustomNginxConfigMap(kubenova, namespace, cm))
+	}
+
+	return resources, nil
+}
+
+// withNginxSnippetsFromConfigMap 读取片段 ConfigMap，返回合并片段后的 KubeNova 副本
+// 未引用片段 ConfigMap 时直接返回原对象
+func (r *KubeNovaReconciler) withNginxSnippetsFromConfigMap(ctx context.Context, kubenova *kubenovav1.KubeNova) (*kubenovav1.KubeNova, error) {
+	if kubenova.Spec.Web.Nginx == nil || kubenova.Spec.Web.Nginx.Snippets == nil ||
+		kubenova.Spec.Web.Nginx.Snippets.ConfigMapName == "" {
+		return kubenova, nil
+	}
+
+	name := kubenova.Spec.Web.Nginx.Snippets.ConfigMapName
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: kubenova.GetTargetNamespace()}, cm); err != nil {
+		return nil, fmt.Errorf("获取 Nginx 片段 ConfigMap %s 失败: %w", name, err)
+	}
+
+	snippets, err := validator.MergeNginxSnippets(kubenova, cm)
+	if err != nil {
+		return nil, err
+	}
+	merged := kubenova.DeepCopy()
+	merged.Spec.Web.Nginx.Snippets = snippets
+	return merged, nil
+}
+
+// isNginxConfigChanged 检查引用的 Nginx ConfigMap 是否已变化但尚未同步到 frontend-nginx-config
+// 引用的 ConfigMap 不属于 Operator，变化不会触发协调，需要定期检查
+func (r *KubeNovaReconciler) isNginxConfigChanged(ctx context.Context, kubenova *kubenovav1.KubeNova) bool {
+	web := kubenova.Spec.Web
+	if web.CustomNginxConfigMap == "" &&
+		(web.Nginx == nil || web.Nginx.Snippets == nil || web.Nginx.Snippets.ConfigMapName == "") {
+		return false
+	}
+
+	resources, err := r.buildWebResources(ctx, kubenova)
+	if err != nil || resources.NginxConfigMap == nil {
+		return false
+	}
+
+	existing := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      resources.NginxConfigMap.Name,
+		Namespace: resources.NginxConfigMap.Namespace,
+	}, existing); err != nil {
+		return false
+	}
+	return builder.CalculateConfigMapChecksum(existing) != builder.CalculateConfigMapChecksum(resources.NginxConfigMap)
+}
+
+// reconcileNginxConfigValidation 在更新 Nginx ConfigMap 之前校验渲染后的配置
+// 校验失败或 nginx -t 仍在运行时保留当前生效的配置，结果记录到 WebConfigValid Condition
+func (r *KubeNovaReconciler) reconcileNginxConfigValidation(ctx context.Context, kubenova *kubenovav1.KubeNova, resources *builder.WebResources) error {
+	logger := log.FromContext(ctx)
+	cm := resources.NginxConfigMap
+
+	condition := metav1.Condition{
+		Type:               kubenovav1.ConditionTypeWebConfigValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ConfigValid",
+		Message:            "Nginx 配置校验通过",
+		ObservedGeneration: kubenova.Generation,
+	}
+	if err := nginxconf.Validate(cm.Data["nginx.conf"], cm.Data["default.conf"]); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SyntaxError"
+		condition.Message = err.Error()
+	} else if kubenova.Spec.Web.IsNginxConfigTestEnabled() {
+		state, message, err := r.runNginxConfigTest(ctx, kubenova, cm)
+		if err != nil {
+			return err
+		}
+		switch state {
+		case nginxConfigTestRunning:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "ConfigTestRunning"
+			condition.Message = "nginx -t 校验中"
+		case nginxConfigTestFailed:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "ConfigTestFailed"
+			condition.Message = message
+		}
+	}
+
+	previous := meta.FindStatusCondition(kubenova.Status.Conditions, kubenovav1.ConditionTypeWebConfigValid)
+	meta.SetStatusCondition(&kubenova.Status.Conditions, condition)
+	if condition.Status == metav1.ConditionTrue {
+		return nil
+	}
+
+	if condition.Status == metav1.ConditionFalse &&
+		(previous == nil || previous.Status != condition.Status || previous.Message != condition.Message) {
+		r.recordWarning(kubenova, "NginxConfigInvalid", condition.Message)
+	}
+
+	// 保留当前生效的配置，其他 Web 资源照常更新
+	existing := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取 Web ConfigMap 失败: %w", err)
+		}
+		// 首次部署没有可保留的配置
+		if condition.Status == metav1.ConditionFalse {
+			return fmt.Errorf("nginx 配置校验失败: %s", condition.Message)
+		}
+		resources.NginxConfigMap = nil
+		return nil
+	}
+
+	logger.Info("Nginx 配置未通过校验，保留当前配置", "原因", condition.Reason, "信息", condition.Message)
+	cm.Data = existing.Data
+	resources.SetNginxConfigMap(kubenova, cm)
+	return nil
+}
+
+// nginx -t 校验状态
+const (
+	nginxConfigTestRunning = "Running"
+	nginxConfigTestPassed  = "Passed"
+	nginxConfigTestFailed  = "Failed"
+)
+
+// runNginxConfigTest 运行 nginx -t 校验 Job 并返回校验状态
+// Job 名称由配置 checksum 决定，同一份配置只校验一次
+func (r *KubeNovaReconciler) runNginxConfigTest(ctx context.Context, kubenova *kubenovav1.KubeNova, cm *corev1.ConfigMap) (string, string, error) {
+	logger := log.FromContext(ctx)
+	namespace := kubenova.GetTargetNamespace()
+
+	desired := builder.BuildNginxConfigTestJob(kubenova, namespace, cm)
+	if err := r.setOwnership(kubenova, desired); err != nil {
+		return "", "", fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: namespace}, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", "", fmt.Errorf("获取 nginx -t 校验 Job 失败: %w", err)
+		}
+		logger.Info("创建 nginx -t 校验 Job", "名称", desired.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			return "", "", fmt.Errorf("创建 nginx -t 校验 Job 失败: %w", err)
+		}
+		r.cleanupNginxConfigTestJobs(ctx, kubenova, desired.Name)
+		return nginxConfigTestRunning, "", nil
+	}
+
+	if job.Status.Succeeded > 0 {
+		return nginxConfigTestPassed, "", nil
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			message := r.getNginxConfigTestOutput(ctx, job)
+			if message == "" {
+				message = c.Message
+			}
+			return nginxConfigTestFailed, fmt.Sprintf("nginx -t 校验失败: %s", message), nil
+		}
+	}
+	return nginxConfigTestRunning, "", nil
+}
+
+// getNginxConfigTestOutput 从校验 Pod 的终止信息中获取 nginx -t 输出
+func (r *KubeNovaReconciler) getNginxConfigTestOutput(ctx context.Context, job *batchv1.Job) string {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return ""
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				return strings.TrimSpace(status.State.Terminated.Message)
+			}
+		}
+	}
+	return ""
+}
+
+// cleanupNginxConfigTestJobs 删除旧配置的 nginx -t 校验 Job
+func (r *KubeNovaReconciler) cleanupNginxConfigTestJobs(ctx context.Context, kubenova *kubenovav1.KubeNova, current string) {
+	logger := log.FromContext(ctx)
+
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(kubenova.GetTargetNamespace()), client.MatchingLabels{
+		"app.kubernetes.io/instance":  kubenova.Name,
+		"app.kubernetes.io/component": builder.NginxConfigTestComponent,
+	}); err != nil {
+		logger.Error(err, "列出 nginx -t 校验 Job 失败")
+		return
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Name == current || !isManagedBy(kubenova, job) {
+			continue
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "删除旧的 nginx -t 校验 Job 失败", "名称", job.Name)
+		}
+	}
+}
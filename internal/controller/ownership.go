@@ -0,0 +1,91 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+const (
+	// ownerNameLabel 跨命名空间子资源记录所属 KubeNova 名称的标签
+	ownerNameLabel = "kubenova.io/owner-name"
+	// ownerNamespaceLabel 跨命名空间子资源记录所属 KubeNova 命名空间的标签
+	ownerNamespaceLabel = "kubenova.io/owner-namespace"
+)
+
+// setOwnership 设置子资源与 KubeNova 的归属关系
+// 同命名空间使用 OwnerReference；OwnerReference 不能跨命名空间，
+// 此时改用标签记录归属，删除时由 Finalizer 根据标签清理
+func (r *KubeNovaReconciler) setOwnership(kubenova *kubenovav1.KubeNova, obj client.Object) error {
+	if obj.GetNamespace() == kubenova.Namespace {
+		return controllerutil.SetControllerReference(kubenova, obj, r.Scheme)
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[ownerNameLabel] = kubenova.Name
+	labels[ownerNamespaceLabel] = kubenova.Namespace
+	obj.SetLabels(labels)
+	return nil
+}
+
+// isManagedBy 检查子资源是否归属于 KubeNova(OwnerReference 或归属标签)
+func isManagedBy(kubenova *kubenovav1.KubeNova, obj client.Object) bool {
+	if metav1.IsControlledBy(obj, kubenova) {
+		return true
+	}
+	labels := obj.GetLabels()
+	return labels[ownerNameLabel] == kubenova.Name && labels[ownerNamespaceLabel] == kubenova.Namespace
+}
+
+// removeOwnership 移除子资源上的 OwnerReference 和归属标签
+func removeOwnership(kubenova *kubenovav1.KubeNova, obj client.Object) {
+	refs := make([]metav1.OwnerReference, 0, len(obj.GetOwnerReferences()))
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID != kubenova.UID {
+			refs = append(refs, ref)
+		}
+	}
+	obj.SetOwnerReferences(refs)
+
+	labels := obj.GetLabels()
+	delete(labels, ownerNameLabel)
+	delete(labels, ownerNamespaceLabel)
+	obj.SetLabels(labels)
+}
+
+// mapOwnerLabels 根据归属标签将子资源事件映射到所属 KubeNova
+func (r *KubeNovaReconciler) mapOwnerLabels(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name, namespace := labels[ownerNameLabel], labels[ownerNamespaceLabel]
+	if name == "" || namespace == "" {
+		return nil
+	}
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}},
+	}
+}
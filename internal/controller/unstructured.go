@@ -0,0 +1,105 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/builder"
+)
+
+// reconcileUnstructured 创建或更新第三方 CRD 资源(如 Gateway API)
+// 通过 spec checksum 注解判断是否需要更新，避免与服务端填充的默认值反复比较
+func (r *KubeNovaReconciler) reconcileUnstructured(ctx context.Context, kubenova *kubenovav1.KubeNova, desired *unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+	kind := desired.GetKind()
+
+	if err := r.setOwnership(kubenova, desired); err != nil {
+		return fmt.Errorf("设置 OwnerReference 失败: %w", err)
+	}
+
+	checksum := builder.CalculateSpecChecksum(desired)
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[builder.SpecChecksumAnnotation] = checksum
+	desired.SetAnnotations(annotations)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	if err := r.Get(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, existing); err != nil {
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("集群未安装 %s 对应的 CRD(%s): %w", kind, desired.GetAPIVersion(), err)
+		}
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("获取 %s 失败: %w", kind, err)
+		}
+		logger.Info("创建 "+kind, "名称", desired.GetName())
+		recordObjectMetadata(desired)
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("创建 %s 失败: %w", kind, err)
+		}
+		return nil
+	}
+
+	// 只在期望 spec、标签或注解变化时才更新
+	if existing.GetAnnotations()[builder.SpecChecksumAnnotation] == checksum && objectMetadataSynced(existing, desired) {
+		return nil
+	}
+
+	existing.Object["spec"] = desired.Object["spec"]
+	syncObjectMetadata(existing, desired)
+	existing.SetOwnerReferences(desired.GetOwnerReferences())
+
+	logger.Info("更新 "+kind, "名称", desired.GetName())
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("更新 %s 失败: %w", kind, err)
+	}
+	return nil
+}
+
+// deleteUnstructured 删除不再需要的第三方 CRD 资源，只删除由当前 KubeNova 管理的对象
+// 集群未安装对应 CRD 时直接跳过
+func (r *KubeNovaReconciler) deleteUnstructured(ctx context.Context, kubenova *kubenovav1.KubeNova, gvk schema.GroupVersionKind, namespace, name string) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, existing); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("获取 %s 失败: %w", gvk.Kind, err)
+	}
+	if !isManagedBy(kubenova, existing) {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("删除 "+gvk.Kind, "名称", name)
+	if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("删除 %s 失败: %w", gvk.Kind, err)
+	}
+	return nil
+}
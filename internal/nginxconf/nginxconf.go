@@ -0,0 +1,394 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nginxconf 提供 Nginx 配置的结构化解析和校验
+// 只检查语法结构和常见的上下文错误，不替代 nginx -t
+package nginxconf
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// contextMain 顶层上下文
+	contextMain = "main"
+)
+
+// Directive Nginx 指令
+type Directive struct {
+	// Name 指令名称
+	Name string
+	// Args 指令参数(已去除引号)
+	Args []string
+	// Line 指令所在行号
+	Line int
+	// Block 块指令的子指令，简单指令为 nil
+	Block []*Directive
+	// IsBlock 是否为块指令
+	IsBlock bool
+}
+
+// SyntaxError 配置语法错误
+type SyntaxError struct {
+	File string
+	Line int
+	Msg  string
+}
+
+// Error 实现 error 接口
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// blockContexts 块指令允许出现的上下文
+// 未列出的块指令(例如第三方模块的块指令)不做上下文检查
+var blockContexts = map[string][]string{
+	"events":        {contextMain},
+	"http":          {contextMain},
+	"stream":        {contextMain},
+	"mail":          {contextMain},
+	"server":        {"http", "stream", "mail"},
+	"location":      {"server", "location"},
+	"upstream":      {"http", "stream"},
+	"if":            {"server", "location"},
+	"limit_except":  {"location"},
+	"map":           {"http", "stream"},
+	"geo":           {"http", "stream"},
+	"split_clients": {"http", "stream"},
+}
+
+// simpleInBlock 在指定上下文中是简单指令而不是块指令
+var simpleInBlock = map[string]string{
+	"server": "upstream",
+}
+
+// Parse 解析 Nginx 配置内容
+func Parse(file, content string) ([]*Directive, error) {
+	tokens, err := tokenize(file, content)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{file: file, tokens: tokens}
+	directives, err := p.parseBlock(false)
+	if err != nil {
+		return nil, err
+	}
+	return directives, nil
+}
+
+// Validate 校验 nginx.conf 和 default.conf
+// default.conf 通过 include 引入 http 块，按 http 上下文校验
+func Validate(nginxConf, defaultConf string) error {
+	mainDirectives, err := Parse("nginx.conf", nginxConf)
+	if err != nil {
+		return err
+	}
+	defaultDirectives, err := Parse("default.conf", defaultConf)
+	if err != nil {
+		return err
+	}
+
+	v := &validator{
+		upstreams: make(map[string]bool),
+		reqZones:  make(map[string]bool),
+		connZones: make(map[string]bool),
+	}
+	if err := v.check("nginx.conf", mainDirectives, contextMain); err != nil {
+		return err
+	}
+	if err := v.check("default.conf", defaultDirectives, "http"); err != nil {
+		return err
+	}
+	return v.checkZoneReferences()
+}
+
+// token 词法单元
+type token struct {
+	value  string
+	line   int
+	quoted bool
+}
+
+// tokenize 将配置内容拆分为词法单元
+func tokenize(file, content string) ([]token, error) {
+	var tokens []token
+	line := 1
+	runes := []rune(content)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\n':
+			line++
+		case c == ' ' || c == '\t' || c == '\r':
+		case c == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			i--
+		case c == '{' || c == '}' || c == ';':
+			tokens = append(tokens, token{value: string(c), line: line})
+		case c == '"' || c == '\'':
+			start := line
+			var b strings.Builder
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					b.WriteRune(runes[i])
+					i++
+					b.WriteRune(runes[i])
+					if runes[i] == '\n' {
+						line++
+					}
+					continue
+				}
+				if runes[i] == c {
+					closed = true
+					break
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				b.WriteRune(runes[i])
+			}
+			if !closed {
+				return nil, &SyntaxError{File: file, Line: start, Msg: "引号未闭合"}
+			}
+			tokens = append(tokens, token{value: b.String(), line: start, quoted: true})
+		default:
+			var b strings.Builder
+			for ; i < len(runes); i++ {
+				r := runes[i]
+				if r == ' ' || r == '\t' || r == '\r' || r == '\n' || r == ';' || r == '}' {
+					break
+				}
+				// ${var} 形式的变量中的花括号属于参数本身
+				if r == '{' {
+					if i > 0 && runes[i-1] == '$' {
+						for ; i < len(runes) && runes[i] != '}'; i++ {
+							b.WriteRune(runes[i])
+						}
+						if i < len(runes) {
+							b.WriteRune(runes[i])
+						}
+						continue
+					}
+					break
+				}
+				if r == '\\' && i+1 < len(runes) {
+					b.WriteRune(r)
+					i++
+					r = runes[i]
+				}
+				b.WriteRune(r)
+			}
+			i--
+			tokens = append(tokens, token{value: b.String(), line: line})
+		}
+	}
+	return tokens, nil
+}
+
+// parser 语法分析器
+type parser struct {
+	file   string
+	tokens []token
+	pos    int
+}
+
+// parseBlock 解析指令列表，nested 表示是否位于块内(需要以 } 结束)
+func (p *parser) parseBlock(nested bool) ([]*Directive, error) {
+	var directives []*Directive
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		if !tok.quoted {
+			switch tok.value {
+			case "}":
+				if !nested {
+					return nil, &SyntaxError{File: p.file, Line: tok.line, Msg: "多余的 \"}\""}
+				}
+				p.pos++
+				return directives, nil
+			case "{", ";":
+				return nil, &SyntaxError{File: p.file, Line: tok.line, Msg: fmt.Sprintf("意外的 %q", tok.value)}
+			}
+		}
+
+		d := &Directive{Name: tok.value, Line: tok.line}
+		p.pos++
+		for {
+			if p.pos >= len(p.tokens) {
+				return nil, &SyntaxError{File: p.file, Line: d.Line, Msg: fmt.Sprintf("指令 %q 缺少结尾的 \";\" 或 \"}\"", d.Name)}
+			}
+			next := p.tokens[p.pos]
+			if !next.quoted && next.value == ";" {
+				p.pos++
+				break
+			}
+			if !next.quoted && next.value == "{" {
+				p.pos++
+				block, err := p.parseBlock(true)
+				if err != nil {
+					return nil, err
+				}
+				d.IsBlock = true
+				d.Block = block
+				break
+			}
+			if !next.quoted && next.value == "}" {
+				return nil, &SyntaxError{File: p.file, Line: next.line, Msg: fmt.Sprintf("指令 %q 缺少结尾的 \";\"", d.Name)}
+			}
+			d.Args = append(d.Args, next.value)
+			p.pos++
+		}
+		directives = append(directives, d)
+	}
+
+	if nested {
+		line := 1
+		if len(p.tokens) > 0 {
+			line = p.tokens[len(p.tokens)-1].line
+		}
+		return nil, &SyntaxError{File: p.file, Line: line, Msg: "缺少 \"}\""}
+	}
+	return directives, nil
+}
+
+// validator 上下文和引用校验
+type validator struct {
+	upstreams map[string]bool
+	reqZones  map[string]bool
+	connZones map[string]bool
+	// zoneRefs 引用的限流 zone，按出现顺序记录，定义可以出现在引用之后
+	zoneRefs []zoneRef
+}
+
+// zoneRef 限流 zone 引用
+type zoneRef struct {
+	file string
+	line int
+	kind string
+	name string
+}
+
+// check 校验指令列表
+func (v *validator) check(file string, directives []*Directive, context string) error {
+	locations := make(map[string]bool)
+
+	for _, d := range directives {
+		if err := v.checkContext(file, d, context); err != nil {
+			return err
+		}
+
+		switch d.Name {
+		case "location":
+			if len(d.Args) == 0 {
+				return &SyntaxError{File: file, Line: d.Line, Msg: "location 缺少匹配规则"}
+			}
+			key := strings.Join(d.Args, " ")
+			if locations[key] {
+				return &SyntaxError{File: file, Line: d.Line, Msg: fmt.Sprintf("重复的 location %q", key)}
+			}
+			locations[key] = true
+		case "upstream":
+			if len(d.Args) != 1 {
+				return &SyntaxError{File: file, Line: d.Line, Msg: "upstream 必须指定一个名称"}
+			}
+			if v.upstreams[d.Args[0]] {
+				return &SyntaxError{File: file, Line: d.Line, Msg: fmt.Sprintf("重复的 upstream %q", d.Args[0])}
+			}
+			v.upstreams[d.Args[0]] = true
+		case "limit_req_zone":
+			if name := zoneArg(d.Args); name != "" {
+				v.reqZones[name] = true
+			}
+		case "limit_conn_zone":
+			if name := zoneArg(d.Args); name != "" {
+				v.connZones[name] = true
+			}
+		case "limit_req":
+			if name := zoneArg(d.Args); name != "" {
+				v.zoneRefs = append(v.zoneRefs, zoneRef{file: file, line: d.Line, kind: "limit_req", name: name})
+			}
+		case "limit_conn":
+			if len(d.Args) > 0 {
+				v.zoneRefs = append(v.zoneRefs, zoneRef{file: file, line: d.Line, kind: "limit_conn", name: d.Args[0]})
+			}
+		}
+
+		if d.IsBlock {
+			child := d.Name
+			if _, known := blockContexts[d.Name]; !known {
+				child = context
+			}
+			if err := v.check(file, d.Block, child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkContext 检查块指令是否出现在允许的上下文中
+func (v *validator) checkContext(file string, d *Directive, context string) error {
+	if parent, ok := simpleInBlock[d.Name]; ok && context == parent {
+		if d.IsBlock {
+			return &SyntaxError{File: file, Line: d.Line, Msg: fmt.Sprintf("%s 块中的 %q 不能包含 \"{\"", parent, d.Name)}
+		}
+		return nil
+	}
+
+	contexts, ok := blockContexts[d.Name]
+	if !ok {
+		return nil
+	}
+	if !d.IsBlock {
+		return &SyntaxError{File: file, Line: d.Line, Msg: fmt.Sprintf("指令 %q 缺少 \"{\"", d.Name)}
+	}
+	for _, allowed := range contexts {
+		if allowed == context {
+			return nil
+		}
+	}
+	return &SyntaxError{File: file, Line: d.Line, Msg: fmt.Sprintf("指令 %q 不能出现在 %s 上下文中", d.Name, context)}
+}
+
+// checkZoneReferences 检查限流 zone 引用是否已定义
+func (v *validator) checkZoneReferences() error {
+	for _, ref := range v.zoneRefs {
+		defined := v.reqZones[ref.name]
+		if ref.kind == "limit_conn" {
+			defined = v.connZones[ref.name]
+		}
+		if !defined {
+			return &SyntaxError{File: ref.file, Line: ref.line, Msg: fmt.Sprintf("%s 引用的 zone %q 未定义", ref.kind, ref.name)}
+		}
+	}
+	return nil
+}
+
+// zoneArg 从参数中提取 zone 名称(zone=name 或 zone=name:size)
+func zoneArg(args []string) string {
+	for _, arg := range args {
+		if name, ok := strings.CutPrefix(arg, "zone="); ok {
+			name, _, _ = strings.Cut(name, ":")
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxconf
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testNginxConf = `
+events { worker_connections 1024; }
+http {
+    limit_req_zone $binary_remote_addr zone=general:10m rate=100r/s;
+    include /etc/nginx/conf.d/*.conf;
+}
+`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		nginxConf   string
+		defaultConf string
+		// wantErr 期望错误信息中包含的内容，为空表示校验通过
+		wantErr string
+		// wantFile 期望出错的文件
+		wantFile string
+	}{
+		{
+			name:      "valid",
+			nginxConf: testNginxConf,
+			defaultConf: `
+upstream portal_api { server portal:8080; keepalive 32; }
+limit_conn_zone $binary_remote_addr zone=perip:10m;
+map $http_upgrade $connection_upgrade { default upgrade; '' close; }
+server {
+    listen 80;
+    limit_conn perip 10;
+    location / { limit_req zone=general burst=200 nodelay; }
+    location = /health { return 200 "ok\n"; }
+    location ~ ^/portal/(.*)$ {
+        if ($request_method = OPTIONS) { return 204; }
+        proxy_pass http://portal_api;
+        location /portal/inner { limit_except GET { deny all; } }
+    }
+}`,
+		},
+		{
+			name:        "server in main context",
+			nginxConf:   testNginxConf + "server { listen 80; }\n",
+			defaultConf: "",
+			wantErr:     `指令 "server" 不能出现在 main 上下文中`,
+			wantFile:    "nginx.conf",
+		},
+		{
+			name:        "http in http context",
+			nginxConf:   testNginxConf,
+			defaultConf: "http { }",
+			wantErr:     `指令 "http" 不能出现在 http 上下文中`,
+			wantFile:    "default.conf",
+		},
+		{
+			name:        "location in http context",
+			nginxConf:   testNginxConf,
+			defaultConf: "location / { }",
+			wantErr:     `指令 "location" 不能出现在 http 上下文中`,
+			wantFile:    "default.conf",
+		},
+		{
+			name:        "upstream in server context",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { upstream a { server b:80; } }",
+			wantErr:     `指令 "upstream" 不能出现在 server 上下文中`,
+		},
+		{
+			name:        "if in http context",
+			nginxConf:   testNginxConf,
+			defaultConf: "if ($host) { return 403; }",
+			wantErr:     `指令 "if" 不能出现在 http 上下文中`,
+		},
+		{
+			name:        "limit_except in server context",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { limit_except GET { deny all; } }",
+			wantErr:     `指令 "limit_except" 不能出现在 server 上下文中`,
+		},
+		{
+			name:        "events in http context",
+			nginxConf:   testNginxConf,
+			defaultConf: "events { }",
+			wantErr:     `指令 "events" 不能出现在 http 上下文中`,
+		},
+		{
+			name:        "block directive without block",
+			nginxConf:   testNginxConf,
+			defaultConf: "server;",
+			wantErr:     `指令 "server" 缺少 "{"`,
+		},
+		{
+			name:        "server with block inside upstream",
+			nginxConf:   testNginxConf,
+			defaultConf: "upstream a { server b:80 { } }",
+			wantErr:     `upstream 块中的 "server" 不能包含 "{"`,
+		},
+		{
+			name:        "duplicate location",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { location /a { } location  /a { } }",
+			wantErr:     `重复的 location "/a"`,
+		},
+		{
+			name:        "duplicate location with modifier",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { location = /a { } location = /a { } }",
+			wantErr:     `重复的 location "= /a"`,
+		},
+		{
+			name:        "same location path in different servers",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { location /a { } } server { location /a { } }",
+		},
+		{
+			name:        "location without match",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { location { } }",
+			wantErr:     "location 缺少匹配规则",
+		},
+		{
+			name:        "duplicate upstream across files",
+			nginxConf:   "http { upstream a { server b:80; } }",
+			defaultConf: "upstream a { server c:80; }",
+			wantErr:     `重复的 upstream "a"`,
+			wantFile:    "default.conf",
+		},
+		{
+			name:        "upstream without name",
+			nginxConf:   testNginxConf,
+			defaultConf: "upstream { server b:80; }",
+			wantErr:     "upstream 必须指定一个名称",
+		},
+		{
+			name:        "undefined limit_req zone",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { limit_req zone=api burst=20; }",
+			wantErr:     `limit_req 引用的 zone "api" 未定义`,
+		},
+		{
+			name:        "undefined limit_conn zone",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { limit_conn perip 10; }",
+			wantErr:     `limit_conn 引用的 zone "perip" 未定义`,
+		},
+		{
+			name:        "limit_conn cannot use limit_req zone",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { limit_conn general 10; }",
+			wantErr:     `limit_conn 引用的 zone "general" 未定义`,
+		},
+		{
+			name:        "zone defined after reference",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { limit_req zone=late; } limit_req_zone $uri zone=late:1m rate=1r/s;",
+		},
+		{
+			name:        "missing semicolon",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { listen 80 }",
+			wantErr:     `指令 "listen" 缺少结尾的 ";"`,
+		},
+		{
+			name:        "missing closing brace",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { listen 80;",
+			wantErr:     `缺少 "}"`,
+		},
+		{
+			name:        "extra closing brace",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { } }",
+			wantErr:     `多余的 "}"`,
+		},
+		{
+			name:        "unexpected semicolon",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { ; }",
+			wantErr:     `意外的 ";"`,
+		},
+		{
+			name:        "unterminated quote",
+			nginxConf:   testNginxConf,
+			defaultConf: "server {\n  return 200 \"ok;\n}",
+			wantErr:     "引号未闭合",
+		},
+		{
+			name:        "braces and semicolons inside quotes",
+			nginxConf:   testNginxConf,
+			defaultConf: `server { add_header X-Test "a { b; } c"; return 200 'd } e;'; }`,
+		},
+		{
+			name:        "escaped quote inside quotes",
+			nginxConf:   testNginxConf,
+			defaultConf: `server { return 200 "say \"hi\"; }"; }`,
+		},
+		{
+			name:        "escaped semicolon outside quotes",
+			nginxConf:   testNginxConf,
+			defaultConf: `server { rewrite ^/a\;b$ /c; }`,
+		},
+		{
+			name:        "variable with braces",
+			nginxConf:   testNginxConf,
+			defaultConf: `server { set $a "x"; return 200 ${a}suffix; }`,
+		},
+		{
+			name:        "hash inside quotes is not a comment",
+			nginxConf:   testNginxConf,
+			defaultConf: `server { return 200 "#not-a-comment"; } # trailing comment {`,
+		},
+		{
+			name:        "unknown block directive is not checked",
+			nginxConf:   testNginxConf,
+			defaultConf: "server { custom_block { location /a { } } }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.nginxConf, tt.defaultConf)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() error = nil, want %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %q, want containing %q", err, tt.wantErr)
+			}
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Validate() error type = %T, want *SyntaxError", err)
+			}
+			if tt.wantFile != "" && syntaxErr.File != tt.wantFile {
+				t.Fatalf("Validate() error file = %s, want %s", syntaxErr.File, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "plain",
+			content: "listen 80 default_server;",
+			want:    []string{"80", "default_server"},
+		},
+		{
+			name:    "double quoted",
+			content: `return 200 "hello world";`,
+			want:    []string{"200", "hello world"},
+		},
+		{
+			name:    "single quoted",
+			content: `add_header X-Test 'a "b"';`,
+			want:    []string{"X-Test", `a "b"`},
+		},
+		{
+			name:    "empty quoted",
+			content: `map_hash_key '';`,
+			want:    []string{""},
+		},
+		{
+			name:    "escape kept in quoted",
+			content: `return 200 "a\"b";`,
+			want:    []string{"200", `a\"b`},
+		},
+		{
+			name:    "variable with braces",
+			content: "return 200 ${host}x;",
+			want:    []string{"200", "${host}x"},
+		},
+		{
+			name:    "comment ignored",
+			content: "listen 80; # listen 443;",
+			want:    []string{"80"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directives, err := Parse("test.conf", tt.content)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(directives) != 1 {
+				t.Fatalf("Parse() returned %d directives, want 1", len(directives))
+			}
+			if !reflect.DeepEqual(directives[0].Args, tt.want) {
+				t.Fatalf("Parse() args = %q, want %q", directives[0].Args, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLineNumbers(t *testing.T) {
+	content := "server {\n    listen 80;\n    return 200 \"multi\nline\";\n    location / { }\n}\n"
+	directives, err := Parse("test.conf", content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	block := directives[0].Block
+	want := map[string]int{"listen": 2, "return": 3, "location": 5}
+	for _, d := range block {
+		if d.Line != want[d.Name] {
+			t.Errorf("%s line = %d, want %d", d.Name, d.Line, want[d.Name])
+		}
+	}
+	if !block[2].IsBlock {
+		t.Errorf("location IsBlock = %v, want true", block[2].IsBlock)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pki 提供内部 TLS 使用的证书生成和解析工具
+package pki
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// clockSkew 证书生效时间提前量，避免节点时钟偏差导致证书尚未生效
+const clockSkew = 5 * time.Minute
+
+// KeyPair PEM 编码的证书和私钥
+type KeyPair struct {
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// GenerateCA 生成自签名 CA 证书
+func GenerateCA(commonName string, validity time.Duration) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("生成 CA 私钥失败: %w", err)
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"kube-nova"}},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("签发 CA 证书失败: %w", err)
+	}
+
+	return encodeKeyPair(der, key)
+}
+
+// GenerateServingCert 使用 CA 签发服务端证书
+// 证书同时包含 ServerAuth 和 ClientAuth 用途，便于后续启用双向认证
+func GenerateServingCert(ca *KeyPair, commonName string, dnsNames []string, validity time.Duration) (*KeyPair, error) {
+	caCert, err := ParseCertificate(ca.CertPEM)
+	if err != nil {
+		return nil, fmt.Errorf("解析 CA 证书失败: %w", err)
+	}
+	caKey, err := parsePrivateKey(ca.KeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("解析 CA 私钥失败: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("生成服务私钥失败: %w", err)
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	// 服务证书不能晚于 CA 过期
+	now := time.Now()
+	notAfter := now.Add(validity)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"kube-nova"}},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-clockSkew),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("签发服务证书失败: %w", err)
+	}
+
+	return encodeKeyPair(der, key)
+}
+
+// ParseCertificate 解析 PEM 编码数据中的第一个证书
+func ParseCertificate(data []byte) (*x509.Certificate, error) {
+	certs, err := ParseCertificates(data)
+	if err != nil {
+		return nil, err
+	}
+	return certs[0], nil
+}
+
+// ParseCertificates 解析 PEM 编码数据中的所有证书
+func ParseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析证书失败: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("未找到 PEM 格式的证书")
+	}
+	return certs, nil
+}
+
+// NeedsRenewal 检查证书是否需要轮换(无法解析或即将过期)
+func NeedsRenewal(certPEM []byte, renewBefore time.Duration, now time.Time) bool {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return true
+	}
+	return now.Add(renewBefore).After(cert.NotAfter)
+}
+
+// IsSignedBy 检查证书是否由指定 CA 签发且包含所需的 DNS 名称
+func IsSignedBy(certPEM, caPEM []byte, dnsNames []string) bool {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return false
+	}
+	caCert, err := ParseCertificate(caPEM)
+	if err != nil {
+		return false
+	}
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return false
+	}
+	for _, name := range dnsNames {
+		if err := cert.VerifyHostname(name); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildCABundle 构建 CA 信任链：当前 CA 在前，追加仍未过期的旧 CA
+// 轮换 CA 期间旧证书签发的服务仍可被信任
+func BuildCABundle(current []byte, previous []byte, now time.Time) []byte {
+	bundle := bytes.TrimSpace(current)
+	if len(previous) == 0 {
+		return append(bundle, '\n')
+	}
+	if cert, err := ParseCertificate(previous); err == nil && now.Before(cert.NotAfter) && !bytes.Equal(bytes.TrimSpace(previous), bundle) {
+		bundle = append(bundle, '\n')
+		bundle = append(bundle, bytes.TrimSpace(previous)...)
+	}
+	return append(bundle, '\n')
+}
+
+// newSerialNumber 生成随机证书序列号
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("生成证书序列号失败: %w", err)
+	}
+	return serial, nil
+}
+
+// encodeKeyPair 将证书和私钥编码为 PEM
+func encodeKeyPair(der []byte, key *ecdsa.PrivateKey) (*KeyPair, error) {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("编码私钥失败: %w", err)
+	}
+	return &KeyPair{
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// parsePrivateKey 解析 PEM 编码的 EC 私钥
+func parsePrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("未找到 PEM 格式的私钥")
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func mustGenerateCA(t *testing.T, validity time.Duration) *KeyPair {
+	t.Helper()
+	ca, err := GenerateCA("test-ca", validity)
+	if err != nil {
+		t.Fatalf("GenerateCA() error = %v", err)
+	}
+	return ca
+}
+
+func TestGenerateCA(t *testing.T) {
+	ca := mustGenerateCA(t, 24*time.Hour)
+
+	cert, err := ParseCertificate(ca.CertPEM)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+	if !cert.IsCA || cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		t.Errorf("CA certificate IsCA = %v, KeyUsage = %v", cert.IsCA, cert.KeyUsage)
+	}
+	if cert.Subject.CommonName != "test-ca" {
+		t.Errorf("CommonName = %s, want test-ca", cert.Subject.CommonName)
+	}
+	if !cert.NotBefore.Before(time.Now().Add(-clockSkew + time.Minute)) {
+		t.Errorf("NotBefore = %v, want backdated by clock skew", cert.NotBefore)
+	}
+	if _, err := tls.X509KeyPair(ca.CertPEM, ca.KeyPEM); err != nil {
+		t.Errorf("CA key pair does not match: %v", err)
+	}
+}
+
+func TestGenerateServingCert(t *testing.T) {
+	ca := mustGenerateCA(t, time.Hour)
+	dnsNames := []string{"portal-api", "portal-api.kube-nova.svc"}
+
+	serving, err := GenerateServingCert(ca, "portal-api", dnsNames, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateServingCert() error = %v", err)
+	}
+	if _, err := tls.X509KeyPair(serving.CertPEM, serving.KeyPEM); err != nil {
+		t.Fatalf("serving key pair does not match: %v", err)
+	}
+	if !IsSignedBy(serving.CertPEM, ca.CertPEM, dnsNames) {
+		t.Errorf("IsSignedBy() = false, want true")
+	}
+
+	cert, _ := ParseCertificate(serving.CertPEM)
+	caCert, _ := ParseCertificate(ca.CertPEM)
+	if cert.NotAfter.After(caCert.NotAfter) {
+		t.Errorf("serving NotAfter %v is after CA NotAfter %v", cert.NotAfter, caCert.NotAfter)
+	}
+	if cert.IsCA {
+		t.Errorf("serving certificate IsCA = true")
+	}
+
+	if _, err := GenerateServingCert(&KeyPair{CertPEM: []byte("invalid")}, "x", nil, time.Hour); err == nil {
+		t.Errorf("GenerateServingCert() with invalid CA error = nil")
+	}
+}
+
+func TestIsSignedBy(t *testing.T) {
+	ca := mustGenerateCA(t, time.Hour)
+	other := mustGenerateCA(t, time.Hour)
+	serving, err := GenerateServingCert(ca, "svc", []string{"svc.ns.svc"}, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateServingCert() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		certPEM  []byte
+		caPEM    []byte
+		dnsNames []string
+		want     bool
+	}{
+		{name: "signed with matching names", certPEM: serving.CertPEM, caPEM: ca.CertPEM, dnsNames: []string{"svc.ns.svc"}, want: true},
+		{name: "signed without names", certPEM: serving.CertPEM, caPEM: ca.CertPEM, want: true},
+		{name: "missing dns name", certPEM: serving.CertPEM, caPEM: ca.CertPEM, dnsNames: []string{"other.ns.svc"}},
+		{name: "different ca", certPEM: serving.CertPEM, caPEM: other.CertPEM},
+		{name: "invalid certificate", certPEM: []byte("invalid"), caPEM: ca.CertPEM},
+		{name: "invalid ca", certPEM: serving.CertPEM, caPEM: []byte("invalid")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSignedBy(tt.certPEM, tt.caPEM, tt.dnsNames); got != tt.want {
+				t.Errorf("IsSignedBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCertificates(t *testing.T) {
+	ca := mustGenerateCA(t, time.Hour)
+	other := mustGenerateCA(t, time.Hour)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    int
+		wantErr bool
+	}{
+		{name: "single", data: ca.CertPEM, want: 1},
+		{name: "bundle", data: append(append([]byte{}, ca.CertPEM...), other.CertPEM...), want: 2},
+		{name: "skips private key", data: append(append([]byte{}, ca.KeyPEM...), ca.CertPEM...), want: 1},
+		{name: "only private key", data: ca.KeyPEM, wantErr: true},
+		{name: "empty", data: nil, wantErr: true},
+		{name: "not pem", data: []byte("not a certificate"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, err := ParseCertificates(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCertificates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(certs) != tt.want {
+				t.Errorf("ParseCertificates() returned %d certificates, want %d", len(certs), tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsRenewal(t *testing.T) {
+	ca := mustGenerateCA(t, 10*24*time.Hour)
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		certPEM     []byte
+		renewBefore time.Duration
+		now         time.Time
+		want        bool
+	}{
+		{name: "valid", certPEM: ca.CertPEM, renewBefore: 24 * time.Hour, now: now},
+		{name: "within renew window", certPEM: ca.CertPEM, renewBefore: 11 * 24 * time.Hour, now: now, want: true},
+		{name: "expired", certPEM: ca.CertPEM, now: now.Add(11 * 24 * time.Hour), want: true},
+		{name: "invalid", certPEM: []byte("invalid"), now: now, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeedsRenewal(tt.certPEM, tt.renewBefore, tt.now); got != tt.want {
+				t.Errorf("NeedsRenewal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCABundle(t *testing.T) {
+	current := mustGenerateCA(t, 24*time.Hour)
+	previous := mustGenerateCA(t, time.Hour)
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		previous []byte
+		now      time.Time
+		want     int
+	}{
+		{name: "no previous", want: 1, now: now},
+		{name: "valid previous", previous: previous.CertPEM, now: now, want: 2},
+		{name: "expired previous", previous: previous.CertPEM, now: now.Add(2 * time.Hour), want: 1},
+		{name: "previous equals current", previous: current.CertPEM, now: now, want: 1},
+		{name: "invalid previous", previous: []byte("invalid"), now: now, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle := BuildCABundle(current.CertPEM, tt.previous, tt.now)
+			certs, err := ParseCertificates(bundle)
+			if err != nil {
+				t.Fatalf("ParseCertificates() error = %v", err)
+			}
+			if len(certs) != tt.want {
+				t.Fatalf("bundle contains %d certificates, want %d", len(certs), tt.want)
+			}
+			if !bytes.HasPrefix(bundle, bytes.TrimSpace(current.CertPEM)) {
+				t.Errorf("bundle does not start with current CA")
+			}
+			if !bytes.HasSuffix(bundle, []byte("\n")) {
+				t.Errorf("bundle does not end with newline")
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/pki"
+)
+
+// Nginx 片段 ConfigMap 支持的 key
+// 文本片段直接追加在 CR 中配置的片段之后，列表类片段使用与 CR 字段相同结构的 YAML 列表，追加在 CR 配置之后
+const (
+	// NginxHTTPSnippetKey http 块片段
+	NginxHTTPSnippetKey = "http-snippet"
+	// NginxServerSnippetKey server 块片段
+	NginxServerSnippetKey = "server-snippet"
+	// NginxLocationsKey 内置 location 的片段列表
+	NginxLocationsKey = "locations"
+	// NginxUpstreamsKey 额外的 upstream 列表
+	NginxUpstreamsKey = "upstreams"
+	// NginxExtraLocationsKey 额外的 location 块列表
+	NginxExtraLocationsKey = "extra-locations"
+)
+
+const (
+	// TLSSourceWeb Web 访问证书(Ingress TLS、NodePort HTTPS、Gateway 监听器)
+	TLSSourceWeb = "web"
+	// TLSSourceStorage MinIO 证书
+	TLSSourceStorage = "storage"
+)
+
+// TLSSecretRef 需要在运行时校验的 TLS Secret 引用
+type TLSSecretRef struct {
+	// Source 证书用途：web 或 storage
+	Source string
+	// Name Secret 名称
+	Name string
+	// CertKey 证书键名
+	CertKey string
+	// KeyKey 私钥键名
+	KeyKey string
+	// Hostnames 证书必须匹配的主机名(为空时不校验)
+	Hostnames []string
+}
+
+// RuntimeResult 运行时校验结果，每个结果对应一个 Condition
+type RuntimeResult struct {
+	// ConditionType 对应的 Condition 类型
+	ConditionType string
+	// Reason Condition 原因
+	Reason string
+	// Err 校验失败的原因，为 nil 表示通过
+	Err error
+}
+
+// ValidateReferences 运行时校验 KubeNova 引用的 Secret 和 ConfigMap
+// 只返回当前配置下适用的校验项
+func ValidateReferences(ctx context.Context, c client.Reader, kn *kubenovav1.KubeNova) []RuntimeResult {
+	namespace := kn.GetTargetNamespace()
+	now := time.Now()
+
+	var results []RuntimeResult
+
+	// 证书按用途分组校验，每类对应一个 Condition
+	refsBySource := make(map[string][]TLSSecretRef)
+	for _, ref := range TLSSecretRefs(kn) {
+		refsBySource[ref.Source] = append(refsBySource[ref.Source], ref)
+	}
+	if refs := refsBySource[TLSSourceWeb]; len(refs) > 0 {
+		results = append(results, tlsResult(kubenovav1.ConditionTypeWebTLSValid,
+			validateTLSSecrets(ctx, c, namespace, refs, now)))
+	}
+	if refs := refsBySource[TLSSourceStorage]; len(refs) > 0 {
+		results = append(results, tlsResult(kubenovav1.ConditionTypeStorageTLSValid,
+			validateTLSSecrets(ctx, c, namespace, refs, now)))
+	}
+
+	// 自定义 Nginx ConfigMap
+	if name := kn.Spec.Web.CustomNginxConfigMap; name != "" {
+		result := RuntimeResult{ConditionType: kubenovav1.ConditionTypeCustomNginxConfigValid, Reason: "ConfigMapValid"}
+		if err := ValidateNginxConfigMap(ctx, c, namespace, name); err != nil {
+			result.Reason = "ConfigMapInvalid"
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+
+	// Nginx 片段 ConfigMap
+	if snippets := kn.Spec.Web.GetNginxConfig().Snippets; snippets != nil && snippets.ConfigMapName != "" {
+		result := RuntimeResult{ConditionType: kubenovav1.ConditionTypeCustomNginxConfigValid, Reason: "ConfigMapValid"}
+		if err := ValidateNginxSnippetsConfigMap(ctx, c, kn); err != nil {
+			result.Reason = "ConfigMapInvalid"
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+
+	// oauth2-proxy Secret(sidecar 和 deployment 模式)
+	if name := oauth2ProxySecretName(kn); name != "" {
+		result := RuntimeResult{ConditionType: kubenovav1.ConditionTypeAuthProxySecretValid, Reason: "SecretValid"}
+		if err := ValidateOAuth2ProxySecret(ctx, c, namespace, name); err != nil {
+			result.Reason = "SecretInvalid"
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+
+	// 演示环境种子数据 ConfigMap
+	if demo := kn.GetDemoReset(); demo != nil && demo.Seed.ConfigMap != nil {
+		result := RuntimeResult{ConditionType: kubenovav1.ConditionTypeDemoSeedValid, Reason: "ConfigMapValid"}
+		if err := ValidateDemoSeedConfigMap(ctx, c, namespace, demo.Seed.ConfigMap); err != nil {
+			result.Reason = "ConfigMapInvalid"
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// FindTargetNamespaceConflict 查找与 kn 使用同一目标命名空间且更早创建的 KubeNova
+// kube-nova-secret、frontend-nginx-config 等子资源使用固定名称，同一命名空间只能部署一个实例，
+// 冲突时最早创建的实例继续使用该命名空间，没有冲突时返回 nil
+func FindTargetNamespaceConflict(ctx context.Context, c client.Reader, kn *kubenovav1.KubeNova) (*kubenovav1.KubeNova, error) {
+	list := &kubenovav1.KubeNovaList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("获取 KubeNova 列表失败: %w", err)
+	}
+
+	namespace := kn.GetTargetNamespace()
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.UID == kn.UID || other.GetTargetNamespace() != namespace {
+			continue
+		}
+		if createdBefore(other, kn) {
+			return other, nil
+		}
+	}
+	return nil, nil
+}
+
+// createdBefore 判断 a 是否早于 b 创建，创建时间相同时按 namespace/name 排序
+func createdBefore(a, b *kubenovav1.KubeNova) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+}
+
+// ValidateDemoSeedConfigMap 校验演示环境种子数据 ConfigMap 是否存在且包含 SQL 键(data 或 binaryData)
+func ValidateDemoSeedConfigMap(ctx context.Context, c client.Reader, namespace string, ref *kubenovav1.DemoSeedConfigMapRef) error {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("configMap %s 不存在", ref.Name)
+		}
+		return fmt.Errorf("获取 ConfigMap %s 失败: %w", ref.Name, err)
+	}
+
+	key := ref.GetKey()
+	if strings.TrimSpace(cm.Data[key]) == "" && len(cm.BinaryData[key]) == 0 {
+		return fmt.Errorf("configMap %s 缺少种子数据 %s", ref.Name, key)
+	}
+	return nil
+}
+
+// ValidateOAuth2ProxySecret 校验 oauth2-proxy Secret 是否存在且包含 client-id、client-secret、cookie-secret
+// cookie-secret 需要是 16、24 或 32 字节
+func ValidateOAuth2ProxySecret(ctx context.Context, c client.Reader, namespace, name string) error {
+	if err := validateSecretName(name); err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("secret %s 不存在", name)
+		}
+		return fmt.Errorf("获取 Secret %s 失败: %w", name, err)
+	}
+
+	var missing []string
+	for _, key := range []string{"client-id", "client-secret", "cookie-secret"} {
+		if len(secret.Data[key]) == 0 {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("secret %s 缺少 %s", name, strings.Join(missing, "、"))
+	}
+
+	switch len(secret.Data["cookie-secret"]) {
+	case 16, 24, 32:
+	default:
+		return fmt.Errorf("secret %s 的 cookie-secret 长度必须是 16、24 或 32 字节", name)
+	}
+	return nil
+}
+
+// oauth2ProxySecretName 获取 oauth2-proxy 引用的 Secret 名称，不由 Operator 部署 oauth2-proxy 时返回空
+func oauth2ProxySecretName(kn *kubenovav1.KubeNova) string {
+	if !kn.Spec.Web.IsExternalAuthEnabled() {
+		return ""
+	}
+	auth := kn.Spec.Web.AccessControl.Auth
+	if auth.GetAuthMode() == kubenovav1.AuthModeExternal {
+		return ""
+	}
+	return auth.GetOAuth2Proxy().SecretName
+}
+
+// ValidateTLSSecret 校验 TLS Secret 是否存在、包含所需键、证书可解析、未过期且匹配主机名
+func ValidateTLSSecret(ctx context.Context, c client.Reader, namespace string, ref TLSSecretRef, now time.Time) error {
+	if err := validateSecretName(ref.Name); err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("secret %s 不存在", ref.Name)
+		}
+		return fmt.Errorf("获取 Secret %s 失败: %w", ref.Name, err)
+	}
+
+	certPEM, keyPEM := secret.Data[ref.CertKey], secret.Data[ref.KeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return fmt.Errorf("secret %s 缺少 %s 或 %s", ref.Name, ref.CertKey, ref.KeyKey)
+	}
+
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return fmt.Errorf("secret %s 的证书与私钥无效或不匹配: %w", ref.Name, err)
+	}
+
+	cert, err := pki.ParseCertificate(certPEM)
+	if err != nil {
+		return fmt.Errorf("secret %s: %w", ref.Name, err)
+	}
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("secret %s 的证书尚未生效(生效时间 %s)", ref.Name, cert.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("secret %s 的证书已于 %s 过期", ref.Name, cert.NotAfter.Format(time.RFC3339))
+	}
+	for _, host := range ref.Hostnames {
+		if err := cert.VerifyHostname(host); err != nil {
+			return fmt.Errorf("secret %s 的证书与主机名 %s 不匹配", ref.Name, host)
+		}
+	}
+
+	return nil
+}
+
+// ValidateNginxConfigMap 校验自定义 Nginx ConfigMap 是否存在且包含 nginx.conf 和 default.conf
+func ValidateNginxConfigMap(ctx context.Context, c client.Reader, namespace, name string) error {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("configMap %s 不存在", name)
+		}
+		return fmt.Errorf("获取 ConfigMap %s 失败: %w", name, err)
+	}
+
+	var missing []string
+	for _, key := range []string{"nginx.conf", "default.conf"} {
+		if strings.TrimSpace(cm.Data[key]) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("configMap %s 缺少 %s", name, strings.Join(missing, "、"))
+	}
+	return nil
+}
+
+// ValidateNginxSnippetsConfigMap 校验 Nginx 片段 ConfigMap 是否存在，且与 CR 中的片段合并后配置有效
+func ValidateNginxSnippetsConfigMap(ctx context.Context, c client.Reader, kn *kubenovav1.KubeNova) error {
+	name := kn.Spec.Web.Nginx.Snippets.ConfigMapName
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: kn.GetTargetNamespace()}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("configMap %s 不存在", name)
+		}
+		return fmt.Errorf("获取 ConfigMap %s 失败: %w", name, err)
+	}
+	_, err := MergeNginxSnippets(kn, cm)
+	return err
+}
+
+// MergeNginxSnippets 将片段 ConfigMap 的内容合并到 CR 中配置的片段，返回合并后的副本
+// 合并后的片段按 CR 字段的规则重新校验，避免 ConfigMap 引入重复的 upstream 或 location
+func MergeNginxSnippets(kn *kubenovav1.KubeNova, cm *corev1.ConfigMap) (*kubenovav1.NginxSnippetsConfig, error) {
+	merged := kn.Spec.Web.Nginx.Snippets.DeepCopy()
+	merged.HTTP = joinSnippets(merged.HTTP, cm.Data[NginxHTTPSnippetKey])
+	merged.Server = joinSnippets(merged.Server, cm.Data[NginxServerSnippetKey])
+
+	var locations []kubenovav1.NginxLocationSnippet
+	if err := unmarshalSnippetList(cm, NginxLocationsKey, &locations); err != nil {
+		return nil, err
+	}
+	merged.Locations = append(merged.Locations, locations...)
+
+	var upstreams []kubenovav1.NginxUpstream
+	if err := unmarshalSnippetList(cm, NginxUpstreamsKey, &upstreams); err != nil {
+		return nil, err
+	}
+	merged.Upstreams = append(merged.Upstreams, upstreams...)
+
+	var extraLocations []kubenovav1.NginxExtraLocation
+	if err := unmarshalSnippetList(cm, NginxExtraLocationsKey, &extraLocations); err != nil {
+		return nil, err
+	}
+	merged.ExtraLocations = append(merged.ExtraLocations, extraLocations...)
+
+	if err := validateNginxSnippets(kn, merged); err != nil {
+		return nil, fmt.Errorf("configMap %s 中的片段无效: %w", cm.Name, err)
+	}
+	return merged, nil
+}
+
+// unmarshalSnippetList 解析片段 ConfigMap 中的 YAML 列表，key 不存在时保持为空
+func unmarshalSnippetList(cm *corev1.ConfigMap, key string, out any) error {
+	data := strings.TrimSpace(cm.Data[key])
+	if data == "" {
+		return nil
+	}
+	if err := yaml.UnmarshalStrict([]byte(data), out); err != nil {
+		return fmt.Errorf("configMap %s 的 %s 格式无效: %w", cm.Name, key, err)
+	}
+	return nil
+}
+
+// joinSnippets 拼接非空片段
+func joinSnippets(snippets ...string) string {
+	var parts []string
+	for _, snippet := range snippets {
+		if snippet = strings.TrimSpace(snippet); snippet != "" {
+			parts = append(parts, snippet)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// TLSSecretRefs 获取实例引用的所有 TLS Secret(Web 和 MinIO)
+func TLSSecretRefs(kn *kubenovav1.KubeNova) []TLSSecretRef {
+	refs := webTLSSecretRefs(kn)
+
+	if name := kn.GetMinIOTLSSecretName(); name != "" {
+		ref := TLSSecretRef{Source: TLSSourceStorage, Name: name, CertKey: "public.crt", KeyKey: "private.key"}
+		if kn.IsMinIOCertIssued() {
+			ref.CertKey, ref.KeyKey = corev1.TLSCertKey, corev1.TLSPrivateKeyKey
+		}
+		if host := endpointHost(kn.Spec.Storage.Endpoint); host != "" {
+			ref.Hostnames = []string{host}
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// webTLSSecretRefs 获取 Web 暴露方式引用的 TLS Secret
+func webTLSSecretRefs(kn *kubenovav1.KubeNova) []TLSSecretRef {
+	switch kn.Spec.Web.ExposeType {
+	case "ingress", "nodeport":
+		name := kn.GetWebTLSSecretName()
+		if name == "" {
+			return nil
+		}
+		ref := TLSSecretRef{Source: TLSSourceWeb, Name: name, CertKey: corev1.TLSCertKey, KeyKey: corev1.TLSPrivateKeyKey}
+		if kn.Spec.Web.ExposeType == "ingress" {
+			ref.Hostnames = []string{kn.Spec.Web.Ingress.Host}
+		}
+		return []TLSSecretRef{ref}
+	case "gateway":
+		// 只有 Operator 创建的 Gateway 监听器引用本命名空间的证书
+		gw := kn.Spec.Web.Gateway
+		if !kn.IsGatewayListenerEnabled() || gw.TLS == nil || !gw.TLS.Enabled {
+			return nil
+		}
+		refs := make([]TLSSecretRef, 0, len(gw.TLS.CertificateRefs))
+		for _, name := range gw.TLS.CertificateRefs {
+			refs = append(refs, TLSSecretRef{Source: TLSSourceWeb, Name: name, CertKey: corev1.TLSCertKey, KeyKey: corev1.TLSPrivateKeyKey})
+		}
+		return refs
+	}
+	return nil
+}
+
+// validateTLSSecrets 依次校验多个 TLS Secret
+func validateTLSSecrets(ctx context.Context, c client.Reader, namespace string, refs []TLSSecretRef, now time.Time) error {
+	for _, ref := range refs {
+		if err := ValidateTLSSecret(ctx, c, namespace, ref, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tlsResult 将证书校验结果转换为 RuntimeResult
+func tlsResult(conditionType string, err error) RuntimeResult {
+	if err != nil {
+		return RuntimeResult{ConditionType: conditionType, Reason: "SecretInvalid", Err: err}
+	}
+	return RuntimeResult{ConditionType: conditionType, Reason: "SecretValid"}
+}
+
+// endpointHost 从 host:port 或 URL 形式的端点中提取主机名
+func endpointHost(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		if u, err := url.Parse(endpoint); err == nil {
+			return u.Hostname()
+		}
+	}
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host
+	}
+	return endpoint
+}
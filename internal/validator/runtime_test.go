@@ -0,0 +1,450 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+	"github.com/yanshicheng/kube-nova-operator/internal/pki"
+)
+
+const testNamespace = "kube-nova"
+
+func newTestSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Data:       data,
+	}
+}
+
+func newTestConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Data:       data,
+	}
+}
+
+func newTestClient(objects ...client.Object) client.Reader {
+	return fake.NewClientBuilder().WithObjects(objects...).Build()
+}
+
+// checkError 检查错误是否符合预期，wantErr 为空表示期望成功
+func checkError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Fatalf("error = %v, want nil", err)
+		}
+		return
+	}
+	if err == nil || !strings.Contains(err.Error(), wantErr) {
+		t.Fatalf("error = %v, want containing %q", err, wantErr)
+	}
+}
+
+func TestValidateTLSSecret(t *testing.T) {
+	ca, err := pki.GenerateCA("test-ca", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateCA() error = %v", err)
+	}
+	serving, err := pki.GenerateServingCert(ca, "kube-nova.example.com", []string{"kube-nova.example.com"}, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateServingCert() error = %v", err)
+	}
+	other, err := pki.GenerateServingCert(ca, "other", []string{"other"}, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateServingCert() error = %v", err)
+	}
+
+	c := newTestClient(
+		newTestSecret("valid", map[string][]byte{"tls.crt": serving.CertPEM, "tls.key": serving.KeyPEM}),
+		newTestSecret("missing-key", map[string][]byte{"tls.crt": serving.CertPEM}),
+		newTestSecret("mismatch", map[string][]byte{"tls.crt": serving.CertPEM, "tls.key": other.KeyPEM}),
+		newTestSecret("minio", map[string][]byte{"public.crt": serving.CertPEM, "private.key": serving.KeyPEM}),
+	)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		ref     TLSSecretRef
+		now     time.Time
+		wantErr string
+	}{
+		{
+			name: "valid",
+			ref:  TLSSecretRef{Name: "valid", CertKey: "tls.crt", KeyKey: "tls.key", Hostnames: []string{"kube-nova.example.com"}},
+			now:  now,
+		},
+		{
+			name: "custom keys",
+			ref:  TLSSecretRef{Name: "minio", CertKey: "public.crt", KeyKey: "private.key"},
+			now:  now,
+		},
+		{
+			name:    "not found",
+			ref:     TLSSecretRef{Name: "absent", CertKey: "tls.crt", KeyKey: "tls.key"},
+			now:     now,
+			wantErr: "secret absent 不存在",
+		},
+		{
+			name:    "empty name",
+			ref:     TLSSecretRef{CertKey: "tls.crt", KeyKey: "tls.key"},
+			now:     now,
+			wantErr: "secret 名称不能为空",
+		},
+		{
+			name:    "missing key",
+			ref:     TLSSecretRef{Name: "missing-key", CertKey: "tls.crt", KeyKey: "tls.key"},
+			now:     now,
+			wantErr: "缺少 tls.crt 或 tls.key",
+		},
+		{
+			name:    "key mismatch",
+			ref:     TLSSecretRef{Name: "mismatch", CertKey: "tls.crt", KeyKey: "tls.key"},
+			now:     now,
+			wantErr: "证书与私钥无效或不匹配",
+		},
+		{
+			name:    "not yet valid",
+			ref:     TLSSecretRef{Name: "valid", CertKey: "tls.crt", KeyKey: "tls.key"},
+			now:     now.Add(-time.Hour),
+			wantErr: "尚未生效",
+		},
+		{
+			name:    "expired",
+			ref:     TLSSecretRef{Name: "valid", CertKey: "tls.crt", KeyKey: "tls.key"},
+			now:     now.Add(2 * time.Hour),
+			wantErr: "过期",
+		},
+		{
+			name:    "hostname mismatch",
+			ref:     TLSSecretRef{Name: "valid", CertKey: "tls.crt", KeyKey: "tls.key", Hostnames: []string{"other.example.com"}},
+			now:     now,
+			wantErr: "与主机名 other.example.com 不匹配",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, ValidateTLSSecret(context.Background(), c, testNamespace, tt.ref, tt.now), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateOAuth2ProxySecret(t *testing.T) {
+	c := newTestClient(
+		newTestSecret("valid", map[string][]byte{
+			"client-id": []byte("id"), "client-secret": []byte("secret"), "cookie-secret": []byte(strings.Repeat("a", 32)),
+		}),
+		newTestSecret("missing", map[string][]byte{"client-id": []byte("id")}),
+		newTestSecret("short-cookie", map[string][]byte{
+			"client-id": []byte("id"), "client-secret": []byte("secret"), "cookie-secret": []byte("short"),
+		}),
+	)
+
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr string
+	}{
+		{name: "valid", secret: "valid"},
+		{name: "not found", secret: "absent", wantErr: "secret absent 不存在"},
+		{name: "missing keys", secret: "missing", wantErr: "缺少 client-secret、cookie-secret"},
+		{name: "invalid cookie secret length", secret: "short-cookie", wantErr: "cookie-secret 长度必须是 16、24 或 32 字节"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, ValidateOAuth2ProxySecret(context.Background(), c, testNamespace, tt.secret), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateNginxConfigMap(t *testing.T) {
+	c := newTestClient(
+		newTestConfigMap("valid", map[string]string{"nginx.conf": "events {}", "default.conf": "server {}"}),
+		newTestConfigMap("partial", map[string]string{"nginx.conf": "events {}", "default.conf": "  "}),
+		newTestConfigMap("empty", nil),
+	)
+
+	tests := []struct {
+		name    string
+		cm      string
+		wantErr string
+	}{
+		{name: "valid", cm: "valid"},
+		{name: "not found", cm: "absent", wantErr: "configMap absent 不存在"},
+		{name: "blank default.conf", cm: "partial", wantErr: "缺少 default.conf"},
+		{name: "empty", cm: "empty", wantErr: "缺少 nginx.conf、default.conf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, ValidateNginxConfigMap(context.Background(), c, testNamespace, tt.cm), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateDemoSeedConfigMap(t *testing.T) {
+	c := newTestClient(
+		newTestConfigMap("sql", map[string]string{"seed.sql": "CREATE TABLE t (id INT);"}),
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "gzip", Namespace: testNamespace},
+			BinaryData: map[string][]byte{"seed.sql.gz": {0x1f, 0x8b}},
+		},
+	)
+
+	tests := []struct {
+		name    string
+		ref     kubenovav1.DemoSeedConfigMapRef
+		wantErr string
+	}{
+		{name: "default key", ref: kubenovav1.DemoSeedConfigMapRef{Name: "sql"}},
+		{name: "binary data", ref: kubenovav1.DemoSeedConfigMapRef{Name: "gzip", Key: "seed.sql.gz"}},
+		{name: "missing key", ref: kubenovav1.DemoSeedConfigMapRef{Name: "sql", Key: "other.sql"}, wantErr: "缺少种子数据 other.sql"},
+		{name: "not found", ref: kubenovav1.DemoSeedConfigMapRef{Name: "absent"}, wantErr: "configMap absent 不存在"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, ValidateDemoSeedConfigMap(context.Background(), c, testNamespace, &tt.ref), tt.wantErr)
+		})
+	}
+}
+
+func TestMergeNginxSnippets(t *testing.T) {
+	newKubeNova := func() *kubenovav1.KubeNova {
+		return &kubenovav1.KubeNova{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-nova", Namespace: testNamespace},
+			Spec: kubenovav1.KubeNovaSpec{
+				Web: kubenovav1.WebConfig{
+					Nginx: &kubenovav1.NginxConfig{
+						Snippets: &kubenovav1.NginxSnippetsConfig{
+							HTTP:          "map $a $b { default 1; }",
+							Upstreams:     []kubenovav1.NginxUpstream{{Name: "docs", Servers: []string{"docs:80"}}},
+							ConfigMapName: "snippets",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr string
+		check   func(t *testing.T, merged *kubenovav1.NginxSnippetsConfig)
+	}{
+		{
+			name: "all keys",
+			data: map[string]string{
+				NginxHTTPSnippetKey:   "client_body_timeout 30s;",
+				NginxServerSnippetKey: "add_header X-Test 1;",
+				NginxLocationsKey:     "- path: /portal\n  snippet: client_max_body_size 10m;\n",
+				NginxUpstreamsKey:     "- name: extra\n  servers: [\"extra:8080\"]\n",
+				NginxExtraLocationsKey: "- path: /extra\n  upstream: extra\n" +
+					"- path: /docs\n  upstream: docs\n",
+			},
+			check: func(t *testing.T, merged *kubenovav1.NginxSnippetsConfig) {
+				if merged.HTTP != "map $a $b { default 1; }\nclient_body_timeout 30s;" {
+					t.Errorf("HTTP = %q", merged.HTTP)
+				}
+				if merged.Server != "add_header X-Test 1;" {
+					t.Errorf("Server = %q", merged.Server)
+				}
+				if len(merged.Locations) != 1 || merged.Locations[0].Path != "/portal" {
+					t.Errorf("Locations = %+v", merged.Locations)
+				}
+				if len(merged.Upstreams) != 2 || merged.Upstreams[1].Name != "extra" {
+					t.Errorf("Upstreams = %+v", merged.Upstreams)
+				}
+				if len(merged.ExtraLocations) != 2 {
+					t.Errorf("ExtraLocations = %+v", merged.ExtraLocations)
+				}
+			},
+		},
+		{
+			name: "empty configmap",
+			check: func(t *testing.T, merged *kubenovav1.NginxSnippetsConfig) {
+				if merged.HTTP != "map $a $b { default 1; }" || len(merged.Upstreams) != 1 {
+					t.Errorf("merged = %+v", merged)
+				}
+			},
+		},
+		{
+			name:    "duplicate upstream with spec",
+			data:    map[string]string{NginxUpstreamsKey: "- name: docs\n  servers: [\"docs2:80\"]\n"},
+			wantErr: "upstream 名称 docs 重复",
+		},
+		{
+			name:    "builtin upstream",
+			data:    map[string]string{NginxUpstreamsKey: "- name: portal_api\n  servers: [\"x:80\"]\n"},
+			wantErr: "与内置 upstream 冲突",
+		},
+		{
+			name:    "unknown builtin location",
+			data:    map[string]string{NginxLocationsKey: "- path: /unknown\n  snippet: return 404;\n"},
+			wantErr: "不是内置 location",
+		},
+		{
+			name:    "extra location with undefined upstream",
+			data:    map[string]string{NginxExtraLocationsKey: "- path: /x\n  upstream: missing\n"},
+			wantErr: "引用的 upstream missing 未定义",
+		},
+		{
+			name:    "unknown field",
+			data:    map[string]string{NginxUpstreamsKey: "- name: extra\n  server: x:80\n"},
+			wantErr: "upstreams 格式无效",
+		},
+		{
+			name:    "not a list",
+			data:    map[string]string{NginxLocationsKey: "path: /portal"},
+			wantErr: "locations 格式无效",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kn := newKubeNova()
+			merged, err := MergeNginxSnippets(kn, newTestConfigMap("snippets", tt.data))
+			checkError(t, err, tt.wantErr)
+			if tt.check != nil {
+				tt.check(t, merged)
+			}
+			if len(kn.Spec.Web.Nginx.Snippets.Upstreams) != 1 {
+				t.Errorf("MergeNginxSnippets() modified the spec")
+			}
+		})
+	}
+}
+
+func TestTLSSecretRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		kn   *kubenovav1.KubeNova
+		want []TLSSecretRef
+	}{
+		{
+			name: "ingress without tls",
+			kn: &kubenovav1.KubeNova{Spec: kubenovav1.KubeNovaSpec{Web: kubenovav1.WebConfig{
+				ExposeType: "ingress",
+				Ingress:    &kubenovav1.IngressConfig{Host: "kube-nova.example.com"},
+			}}},
+		},
+		{
+			name: "ingress with issuer and minio",
+			kn: &kubenovav1.KubeNova{Spec: kubenovav1.KubeNovaSpec{
+				Web: kubenovav1.WebConfig{
+					ExposeType: "ingress",
+					Ingress: &kubenovav1.IngressConfig{
+						Host: "kube-nova.example.com",
+						TLS:  &kubenovav1.IngressTLSConfig{Enabled: true, IssuerRef: &kubenovav1.CertIssuerRef{Name: "letsencrypt"}},
+					},
+				},
+				Storage: kubenovav1.StorageConfig{
+					Endpoint: "https://minio.example.com:9000",
+					TLS:      &kubenovav1.MinIOTLSConfig{Enabled: true, SecretName: "minio-tls"},
+				},
+			}},
+			want: []TLSSecretRef{
+				{Source: TLSSourceWeb, Name: "kube-nova-web-tls", CertKey: "tls.crt", KeyKey: "tls.key", Hostnames: []string{"kube-nova.example.com"}},
+				{Source: TLSSourceStorage, Name: "minio-tls", CertKey: "public.crt", KeyKey: "private.key", Hostnames: []string{"minio.example.com"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TLSSecretRefs(tt.kn); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TLSSecretRefs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndpointHost(t *testing.T) {
+	tests := map[string]string{
+		"minio.example.com:9000":         "minio.example.com",
+		"https://minio.example.com:9000": "minio.example.com",
+		"http://minio.example.com":       "minio.example.com",
+		"minio.example.com":              "minio.example.com",
+		"10.0.0.1:9000":                  "10.0.0.1",
+	}
+	for endpoint, want := range tests {
+		if got := endpointHost(endpoint); got != want {
+			t.Errorf("endpointHost(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
+
+func TestFindTargetNamespaceConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubenovav1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	created := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	newKubeNova := func(namespace, name, target string, offset time.Duration) *kubenovav1.KubeNova {
+		return &kubenovav1.KubeNova{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				UID:               types.UID(namespace + "/" + name),
+				CreationTimestamp: metav1.NewTime(created.Add(offset)),
+			},
+			Spec: kubenovav1.KubeNovaSpec{TargetNamespace: target},
+		}
+	}
+
+	first := newKubeNova("team-a", "kube-nova", testNamespace, 0)
+	second := newKubeNova("team-b", "kube-nova", testNamespace, time.Minute)
+	sameTime := newKubeNova("team-c", "kube-nova", testNamespace, 0)
+	other := newKubeNova("team-d", "kube-nova", "other", -time.Minute)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(first, second, sameTime, other).Build()
+
+	tests := []struct {
+		name string
+		kn   *kubenovav1.KubeNova
+		want string
+	}{
+		{name: "oldest instance", kn: first},
+		{name: "newer instance", kn: second, want: "team-a/kube-nova"},
+		{name: "same creation time", kn: sameTime, want: "team-a/kube-nova"},
+		{name: "different target namespace", kn: other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflict, err := FindTargetNamespaceConflict(context.Background(), c, tt.kn)
+			if err != nil {
+				t.Fatalf("FindTargetNamespaceConflict() error = %v", err)
+			}
+			got := ""
+			if conflict != nil {
+				got = conflict.Namespace + "/" + conflict.Name
+			}
+			if got != tt.want {
+				t.Errorf("FindTargetNamespaceConflict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
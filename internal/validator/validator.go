@@ -6,7 +6,17 @@ package validator
 
 import (
 	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	// 内嵌时区数据，Operator 镜像中没有 zoneinfo 时也能校验时区名称
+	_ "time/tzdata"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
 )
@@ -40,16 +50,104 @@ func ValidateKubeNova(kn *kubenovav1.KubeNova) error {
 		return fmt.Errorf("JWT 配置错误: %w", err)
 	}
 
+	// 验证 RPC 客户端配置
+	if err := validateRPCClients(kn); err != nil {
+		return fmt.Errorf("RPC 客户端配置错误: %w", err)
+	}
+
+	// 验证 RPC 服务配置
+	if err := validateRPCServices(kn); err != nil {
+		return fmt.Errorf("RPC 服务配置错误: %w", err)
+	}
+
+	// 验证优雅停止配置
+	if err := validateLifecycles(kn); err != nil {
+		return fmt.Errorf("优雅停止配置错误: %w", err)
+	}
+
+	// 验证时区和语言环境配置
+	if err := validateLocale(kn); err != nil {
+		return fmt.Errorf("时区配置错误: %w", err)
+	}
+
+	// 验证 Pod 扩展配置
+	if err := validatePodExtensions(kn); err != nil {
+		return fmt.Errorf("pod 扩展配置错误: %w", err)
+	}
+
+	// 验证日志和缓存目录的卷配置
+	if err := validateDataVolumes(kn); err != nil {
+		return fmt.Errorf("卷配置错误: %w", err)
+	}
+
+	// 验证演示环境配置
+	if err := validateDemo(kn); err != nil {
+		return fmt.Errorf("演示环境配置错误: %w", err)
+	}
+
 	// 验证 Web 配置
 	if err := kn.Spec.Web.ValidateWebConfig(); err != nil {
 		return fmt.Errorf("web 配置错误: %w", err)
 	}
 
+	// 验证 Nginx 配置
+	if err := validateNginx(kn); err != nil {
+		return fmt.Errorf("nginx 配置错误: %w", err)
+	}
+
+	// 验证安全响应头配置
+	if err := validateSecurityHeaders(kn); err != nil {
+		return fmt.Errorf("安全响应头配置错误: %w", err)
+	}
+
+	// 验证访问控制配置
+	if err := validateAccessControl(kn); err != nil {
+		return fmt.Errorf("访问控制配置错误: %w", err)
+	}
+
+	// 验证维护模式配置
+	if err := validateMaintenance(kn.Spec.Maintenance); err != nil {
+		return fmt.Errorf("维护模式配置错误: %w", err)
+	}
+
+	// 验证删除选项
+	if err := validateDeletionOptions(kn.Spec.DeletionOptions); err != nil {
+		return fmt.Errorf("删除选项配置错误: %w", err)
+	}
+
+	// 验证内部 TLS 配置
+	if err := validateInternalTLS(kn.Spec.InternalTLS); err != nil {
+		return fmt.Errorf("内部 TLS 配置错误: %w", err)
+	}
+
+	// 验证告警接入配置
+	if err := validateAlerting(kn); err != nil {
+		return fmt.Errorf("告警接入配置错误: %w", err)
+	}
+
 	return nil
 }
 
 // validateDatabase 验证数据库配置
 func validateDatabase(db *kubenovav1.DatabaseConfig) error {
+	if db.Managed {
+		if db.Host != "" || db.Password != "" {
+			return fmt.Errorf("托管 MySQL 由 Operator 部署并生成密码，不能同时配置 host 和 password")
+		}
+		if db.User == "root" {
+			return fmt.Errorf("托管 MySQL 的业务用户不能是 root")
+		}
+		if db.Database != "" && !mysqlIdentifierPattern.MatchString(db.Database) {
+			return fmt.Errorf("数据库名称格式无效: %s", db.Database)
+		}
+		if db.User != "" && !mysqlIdentifierPattern.MatchString(db.User) {
+			return fmt.Errorf("数据库用户名格式无效: %s", db.User)
+		}
+		return validateManagedInstance(db.Instance)
+	}
+	if db.Instance != nil {
+		return fmt.Errorf("instance 仅在 managed 模式下生效")
+	}
 	if db.Host == "" {
 		return fmt.Errorf("数据库主机地址不能为空")
 	}
@@ -70,6 +168,21 @@ func validateDatabase(db *kubenovav1.DatabaseConfig) error {
 
 // validateCache 验证缓存配置
 func validateCache(cache *kubenovav1.CacheConfig) error {
+	if cache.Managed {
+		if cache.Host != "" || cache.Password != "" {
+			return fmt.Errorf("托管 Redis 由 Operator 部署并生成密码，不能同时配置 host 和 password")
+		}
+		if cache.Type != "" && cache.Type != "node" {
+			return fmt.Errorf("托管 Redis 为单节点部署，type 只能是 node")
+		}
+		if cache.TLS {
+			return fmt.Errorf("托管 Redis 不支持 TLS")
+		}
+		return validateManagedInstance(cache.Instance)
+	}
+	if cache.Instance != nil {
+		return fmt.Errorf("instance 仅在 managed 模式下生效")
+	}
 	if cache.Host == "" {
 		return fmt.Errorf("redis 主机地址不能为空")
 	}
@@ -84,6 +197,21 @@ func validateCache(cache *kubenovav1.CacheConfig) error {
 
 // validateStorage 验证存储配置
 func validateStorage(storage *kubenovav1.StorageConfig) error {
+	if storage.Managed {
+		if storage.Endpoint != "" || storage.AccessKey != "" || storage.SecretKey != "" {
+			return fmt.Errorf("托管 MinIO 由 Operator 部署并生成密钥，不能同时配置 endpoint、accessKey 和 secretKey")
+		}
+		if storage.TLS != nil && storage.TLS.Enabled {
+			return fmt.Errorf("托管 MinIO 不支持 TLS")
+		}
+		if storage.Bucket != "" && !bucketNamePattern.MatchString(storage.Bucket) {
+			return fmt.Errorf("存储桶名称格式无效: %s", storage.Bucket)
+		}
+		return validateManagedInstance(storage.Instance)
+	}
+	if storage.Instance != nil {
+		return fmt.Errorf("instance 仅在 managed 模式下生效")
+	}
 	if storage.Endpoint == "" {
 		return fmt.Errorf("存储端点地址不能为空")
 	}
@@ -107,27 +235,1066 @@ func validateStorage(storage *kubenovav1.StorageConfig) error {
 	return nil
 }
 
+// mysqlIdentifierPattern 托管 MySQL 的数据库名称和用户名格式
+var mysqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,32}$`)
+
+// bucketNamePattern S3 存储桶名称格式
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// validateManagedInstance 验证托管依赖的部署配置
+func validateManagedInstance(instance *kubenovav1.ManagedInstanceConfig) error {
+	if instance == nil {
+		return nil
+	}
+	if instance.StorageSize != nil && instance.StorageSize.Sign() <= 0 {
+		return fmt.Errorf("instance.storageSize 必须大于 0")
+	}
+	if instance.StorageClassName != nil && *instance.StorageClassName != "" {
+		if errs := validation.IsDNS1123Subdomain(*instance.StorageClassName); len(errs) > 0 {
+			return fmt.Errorf("instance.storageClassName 无效: %s", strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
 // validateJWT 验证 JWT 配置
+// 密钥未配置时由 Operator 自动生成
 func validateJWT(jwt *kubenovav1.JWTConfig) error {
-	if jwt.AccessSecret == "" {
-		return fmt.Errorf("访问令牌密钥不能为空")
-	}
-	if len(jwt.AccessSecret) < 32 {
+	if jwt.AccessSecret != "" && len(jwt.AccessSecret) < 32 {
 		return fmt.Errorf("访问令牌密钥长度至少需要 32 个字符，当前长度: %d", len(jwt.AccessSecret))
 	}
-	if jwt.RefreshSecret == "" {
-		return fmt.Errorf("刷新令牌密钥不能为空")
-	}
-	if len(jwt.RefreshSecret) < 32 {
+	if jwt.RefreshSecret != "" && len(jwt.RefreshSecret) < 32 {
 		return fmt.Errorf("刷新令牌密钥长度至少需要 32 个字符，当前长度: %d", len(jwt.RefreshSecret))
 	}
+
+	rotation := jwt.Rotation
+	if rotation == nil || !rotation.Enabled {
+		return nil
+	}
+	if jwt.AccessSecret != "" || jwt.RefreshSecret != "" {
+		return fmt.Errorf("密钥轮换只适用于自动生成的密钥，启用轮换时不能配置 accessSecret 或 refreshSecret")
+	}
+
+	interval := 30 * 24 * time.Hour
+	if rotation.Interval != "" {
+		d, err := time.ParseDuration(rotation.Interval)
+		if err != nil {
+			return fmt.Errorf("rotation.interval 格式无效: %w", err)
+		}
+		if d < time.Hour {
+			return fmt.Errorf("rotation.interval 不能小于 1h")
+		}
+		interval = d
+	}
+	if rotation.GracePeriod != "" {
+		d, err := time.ParseDuration(rotation.GracePeriod)
+		if err != nil {
+			return fmt.Errorf("rotation.gracePeriod 格式无效: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("rotation.gracePeriod 必须大于 0")
+		}
+		// 过渡期超过轮换周期时，旧密钥会在仍被使用时被下一次轮换覆盖
+		if d >= interval {
+			return fmt.Errorf("rotation.gracePeriod 必须小于 rotation.interval")
+		}
+	} else if refreshExpire := time.Duration(jwt.RefreshExpire) * time.Second; refreshExpire >= interval {
+		return fmt.Errorf("未配置 rotation.gracePeriod 时默认使用 refreshExpire，refreshExpire 必须小于 rotation.interval")
+	}
+	return nil
+}
+
+// rpcClientTargets 各服务调用的 RPC 服务
+var rpcClientTargets = map[string][]string{
+	"portal-api":   {"portal-rpc"},
+	"manager-api":  {"manager-rpc", "portal-rpc"},
+	"manager-rpc":  {"portal-rpc"},
+	"workload-api": {"manager-rpc", "portal-rpc"},
+	"console-api":  {"manager-rpc", "console-rpc", "portal-rpc"},
+}
+
+// validateRPCClients 验证 RPC 客户端配置
+// 只能覆盖服务实际调用的 RPC 服务
+func validateRPCClients(kn *kubenovav1.KubeNova) error {
+	services := map[string]*kubenovav1.ServiceConfig{
+		"portalRPC":  kn.Spec.Services.PortalRPC,
+		"consoleRPC": kn.Spec.Services.ConsoleRPC,
+	}
+	for name, sc := range services {
+		if sc != nil && len(sc.RPCClients) > 0 {
+			return fmt.Errorf("%s 不调用其他 RPC 服务，不能配置 rpcClients", name)
+		}
+	}
+
+	for caller, sc := range kn.GetRPCCallers() {
+		if sc == nil {
+			continue
+		}
+		seen := make(map[string]bool, len(sc.RPCClients))
+		for _, client := range sc.RPCClients {
+			if !slices.Contains(rpcClientTargets[caller], client.Target) {
+				return fmt.Errorf("%s 不调用 %s，可选的目标服务: %s", caller, client.Target, strings.Join(rpcClientTargets[caller], ", "))
+			}
+			if seen[client.Target] {
+				return fmt.Errorf("%s 的 rpcClients 中目标服务 %s 重复", caller, client.Target)
+			}
+			seen[client.Target] = true
+			if client.Timeout != nil && *client.Timeout <= 0 {
+				return fmt.Errorf("%s 调用 %s 的超时时间必须大于 0", caller, client.Target)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRPCServices 验证只适用于 RPC 服务的配置
+func validateRPCServices(kn *kubenovav1.KubeNova) error {
+	apiServices := map[string]*kubenovav1.ServiceConfig{
+		"portalAPI":   kn.Spec.Services.PortalAPI,
+		"managerAPI":  kn.Spec.Services.ManagerAPI,
+		"workloadAPI": kn.Spec.Services.WorkloadAPI,
+		"consoleAPI":  kn.Spec.Services.ConsoleAPI,
+	}
+	for name, sc := range apiServices {
+		if sc != nil && sc.Headless {
+			return fmt.Errorf("%s 不是 RPC 服务，不能设置 headless", name)
+		}
+	}
+	return nil
+}
+
+// validateLifecycles 验证各组件的优雅停止配置
+func validateLifecycles(kn *kubenovav1.KubeNova) error {
+	lifecycles := map[string]*kubenovav1.LifecycleConfig{
+		"web": kn.Spec.Web.Lifecycle,
+	}
+	for name, sc := range map[string]*kubenovav1.ServiceConfig{
+		"portalAPI":   kn.Spec.Services.PortalAPI,
+		"portalRPC":   kn.Spec.Services.PortalRPC,
+		"managerAPI":  kn.Spec.Services.ManagerAPI,
+		"managerRPC":  kn.Spec.Services.ManagerRPC,
+		"workloadAPI": kn.Spec.Services.WorkloadAPI,
+		"consoleAPI":  kn.Spec.Services.ConsoleAPI,
+		"consoleRPC":  kn.Spec.Services.ConsoleRPC,
+	} {
+		lifecycles[name] = sc.GetLifecycle()
+	}
+
+	for name, lifecycle := range lifecycles {
+		// preStop 等待时间计入优雅停止时间，等待结束后进程需要留有退出时间
+		if lifecycle.GetPreStopSleepSeconds() >= lifecycle.GetTerminationGracePeriodSeconds() {
+			return fmt.Errorf("%s 的 preStopSleepSeconds(%d) 必须小于 terminationGracePeriodSeconds(%d)",
+				name, lifecycle.GetPreStopSleepSeconds(), lifecycle.GetTerminationGracePeriodSeconds())
+		}
+	}
+	return nil
+}
+
+// localePattern 语言环境格式(例如：en_US.UTF-8、C.UTF-8)
+var localePattern = regexp.MustCompile(`^[A-Za-z]+(_[A-Za-z]+)?(\.[A-Za-z0-9-]+)?(@[A-Za-z0-9]+)?$`)
+
+// validateLocale 验证时区、语言环境和日志时间格式
+func validateLocale(kn *kubenovav1.KubeNova) error {
+	timezones := map[string]string{
+		"timezone":     kn.Spec.Timezone,
+		"web.timezone": kn.Spec.Web.Timezone,
+	}
+	for name, sc := range map[string]*kubenovav1.ServiceConfig{
+		"portalAPI":   kn.Spec.Services.PortalAPI,
+		"portalRPC":   kn.Spec.Services.PortalRPC,
+		"managerAPI":  kn.Spec.Services.ManagerAPI,
+		"managerRPC":  kn.Spec.Services.ManagerRPC,
+		"workloadAPI": kn.Spec.Services.WorkloadAPI,
+		"consoleAPI":  kn.Spec.Services.ConsoleAPI,
+		"consoleRPC":  kn.Spec.Services.ConsoleRPC,
+	} {
+		if sc != nil {
+			timezones[name+".timezone"] = sc.Timezone
+		}
+	}
+	for field, tz := range timezones {
+		if tz == "" {
+			continue
+		}
+		if _, err := time.LoadLocation(tz); err != nil || tz == "Local" {
+			return fmt.Errorf("%s 不是有效的 IANA 时区名称: %s", field, tz)
+		}
+	}
+
+	if kn.Spec.Locale != "" && !localePattern.MatchString(kn.Spec.Locale) {
+		return fmt.Errorf("locale 格式无效: %s", kn.Spec.Locale)
+	}
+	// 时间格式通过环境变量替换写入服务配置文件的双引号字符串中
+	if strings.ContainsAny(kn.Spec.LogTimeFormat, "\"\\\n\r$") {
+		return fmt.Errorf("logTimeFormat 不能包含引号、反斜杠、$ 或换行")
+	}
+	return nil
+}
+
+var (
+	// serviceBuiltinVolumes 后端服务 Pod 中 Operator 生成的卷
+	serviceBuiltinVolumes = []string{"config", "minio-certs", "internal-tls", "logs", "cache"}
+	// webBuiltinVolumes Web Pod 中 Operator 生成的卷
+	webBuiltinVolumes = []string{"nginx-config", "nginx-conf-d", "cache", "logs", "run", "tls-certs", "internal-ca"}
+	// webBuiltinContainers Web Pod 中 Operator 生成的容器
+	webBuiltinContainers = []string{"kube-nova-web", "nginx-reloader", "oauth2-proxy"}
+	// reservedPodLabels Operator 使用的 Pod 标签，Service 选择器和反亲和性依赖 app 标签
+	reservedPodLabels = []string{"app", "component", "tier"}
+)
+
+// podExtensions 后端服务和 Web 共用的 Pod 扩展配置
+type podExtensions struct {
+	envFrom           []corev1.EnvFromSource
+	initContainers    []corev1.Container
+	extraContainers   []corev1.Container
+	extraVolumes      []corev1.Volume
+	extraVolumeMounts []corev1.VolumeMount
+	podAnnotations    map[string]string
+	podLabels         map[string]string
+}
+
+// validatePodExtensions 验证额外容器、卷、环境变量来源和 Pod 元数据
+// 容器和卷在 CRD 中没有 schema，这里检查 Operator 合并时依赖的字段
+func validatePodExtensions(kn *kubenovav1.KubeNova) error {
+	web := &kn.Spec.Web
+	if err := validatePodExtensionsFor("web", webBuiltinContainers, webBuiltinVolumes, podExtensions{
+		envFrom:           web.EnvFrom,
+		initContainers:    web.InitContainers,
+		extraContainers:   web.ExtraContainers,
+		extraVolumes:      web.ExtraVolumes,
+		extraVolumeMounts: web.ExtraVolumeMounts,
+		podAnnotations:    web.PodAnnotations,
+		podLabels:         web.PodLabels,
+	}); err != nil {
+		return err
+	}
+
+	for _, svc := range []struct {
+		field, name string
+		config      *kubenovav1.ServiceConfig
+	}{
+		{"portalAPI", "portal-api", kn.Spec.Services.PortalAPI},
+		{"portalRPC", "portal-rpc", kn.Spec.Services.PortalRPC},
+		{"managerAPI", "manager-api", kn.Spec.Services.ManagerAPI},
+		{"managerRPC", "manager-rpc", kn.Spec.Services.ManagerRPC},
+		{"workloadAPI", "workload-api", kn.Spec.Services.WorkloadAPI},
+		{"consoleAPI", "console-api", kn.Spec.Services.ConsoleAPI},
+		{"consoleRPC", "console-rpc", kn.Spec.Services.ConsoleRPC},
+	} {
+		sc := svc.config
+		if sc == nil {
+			continue
+		}
+		if err := validatePodExtensionsFor(svc.field, []string{svc.name}, serviceBuiltinVolumes, podExtensions{
+			envFrom:           sc.EnvFrom,
+			initContainers:    sc.InitContainers,
+			extraContainers:   sc.ExtraContainers,
+			extraVolumes:      sc.ExtraVolumes,
+			extraVolumeMounts: sc.ExtraVolumeMounts,
+			podAnnotations:    sc.PodAnnotations,
+			podLabels:         sc.PodLabels,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePodExtensionsFor 验证单个组件的 Pod 扩展配置
+func validatePodExtensionsFor(field string, builtinContainers, builtinVolumes []string, ext podExtensions) error {
+	for _, source := range ext.envFrom {
+		switch {
+		case source.ConfigMapRef != nil && source.SecretRef != nil:
+			return fmt.Errorf("%s 的 envFrom 每一项只能配置 configMapRef 或 secretRef 其中之一", field)
+		case source.ConfigMapRef != nil:
+			if source.ConfigMapRef.Name == "" {
+				return fmt.Errorf("%s 的 envFrom 中 configMapRef 名称不能为空", field)
+			}
+			if err := validateConfigMapName(source.ConfigMapRef.Name); err != nil {
+				return fmt.Errorf("%s 的 envFrom: %w", field, err)
+			}
+		case source.SecretRef != nil:
+			if err := validateSecretName(source.SecretRef.Name); err != nil {
+				return fmt.Errorf("%s 的 envFrom: %w", field, err)
+			}
+		default:
+			return fmt.Errorf("%s 的 envFrom 每一项必须配置 configMapRef 或 secretRef", field)
+		}
+	}
+
+	// 同一个 Pod 中 init 容器和普通容器的名称不能重复
+	containerNames := make(map[string]bool)
+	for _, name := range builtinContainers {
+		containerNames[name] = true
+	}
+	for listName, containers := range map[string][]corev1.Container{
+		"initContainers":  ext.initContainers,
+		"extraContainers": ext.extraContainers,
+	} {
+		for _, c := range containers {
+			if errs := validation.IsDNS1123Label(c.Name); len(errs) > 0 {
+				return fmt.Errorf("%s 的 %s 中容器名称 %q 无效: %s", field, listName, c.Name, strings.Join(errs, "; "))
+			}
+			if containerNames[c.Name] {
+				return fmt.Errorf("%s 的 %s 中容器名称 %s 与其他容器重复", field, listName, c.Name)
+			}
+			containerNames[c.Name] = true
+			if c.Image == "" {
+				return fmt.Errorf("%s 的 %s 中容器 %s 未配置镜像", field, listName, c.Name)
+			}
+		}
+	}
+
+	volumeNames := make(map[string]bool, len(ext.extraVolumes))
+	for _, v := range ext.extraVolumes {
+		if errs := validation.IsDNS1123Label(v.Name); len(errs) > 0 {
+			return fmt.Errorf("%s 的 extraVolumes 中卷名称 %q 无效: %s", field, v.Name, strings.Join(errs, "; "))
+		}
+		if slices.Contains(builtinVolumes, v.Name) {
+			return fmt.Errorf("%s 的 extraVolumes 中卷名称 %s 与 Operator 生成的卷重复", field, v.Name)
+		}
+		if volumeNames[v.Name] {
+			return fmt.Errorf("%s 的 extraVolumes 中卷名称 %s 重复", field, v.Name)
+		}
+		volumeNames[v.Name] = true
+	}
+	for _, m := range ext.extraVolumeMounts {
+		if !volumeNames[m.Name] {
+			return fmt.Errorf("%s 的 extraVolumeMounts 引用的卷 %s 不在 extraVolumes 中", field, m.Name)
+		}
+		if !strings.HasPrefix(m.MountPath, "/") {
+			return fmt.Errorf("%s 的 extraVolumeMounts 中卷 %s 的挂载路径必须是绝对路径", field, m.Name)
+		}
+	}
+
+	for key, value := range ext.podLabels {
+		if slices.Contains(reservedPodLabels, key) || strings.HasPrefix(key, "app.kubernetes.io/") {
+			return fmt.Errorf("%s 的 podLabels 不能覆盖 Operator 使用的标签 %s", field, key)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("%s 的 podLabels 中的标签 %q 无效: %s", field, key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("%s 的 podLabels 中标签 %s 的值无效: %s", field, key, strings.Join(errs, "; "))
+		}
+	}
+	for key := range ext.podAnnotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("%s 的 podAnnotations 中的注解 %q 无效: %s", field, key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateDataVolumes 验证后端服务和 Web 的日志、缓存目录卷配置
+// 只有 console-api 和 console-rpc 使用缓存目录
+func validateDataVolumes(kn *kubenovav1.KubeNova) error {
+	if err := validateVolumeConfig("web.volumes.logs", kn.Spec.Web.GetLogsVolume()); err != nil {
+		return err
+	}
+	if err := validateVolumeConfig("web.volumes.cache", kn.Spec.Web.GetCacheVolume()); err != nil {
+		return err
+	}
+	if err := validateCacheReplicas("web", kn.Spec.Web.GetCacheVolume(), kn.Spec.Web.GetWebReplicas()); err != nil {
+		return err
+	}
+
+	for _, svc := range []struct {
+		field     string
+		config    *kubenovav1.ServiceConfig
+		needCache bool
+	}{
+		{"portalAPI", kn.Spec.Services.PortalAPI, false},
+		{"portalRPC", kn.Spec.Services.PortalRPC, false},
+		{"managerAPI", kn.Spec.Services.ManagerAPI, false},
+		{"managerRPC", kn.Spec.Services.ManagerRPC, false},
+		{"workloadAPI", kn.Spec.Services.WorkloadAPI, false},
+		{"consoleAPI", kn.Spec.Services.ConsoleAPI, true},
+		{"consoleRPC", kn.Spec.Services.ConsoleRPC, true},
+	} {
+		if err := validateVolumeConfig(svc.field+".volumes.logs", svc.config.GetLogsVolume()); err != nil {
+			return err
+		}
+		cache := svc.config.GetCacheVolume()
+		if cache != nil && !svc.needCache {
+			return fmt.Errorf("%s 没有缓存目录，不支持 volumes.cache", svc.field)
+		}
+		if err := validateVolumeConfig(svc.field+".volumes.cache", cache); err != nil {
+			return err
+		}
+		if err := validateCacheReplicas(svc.field, cache, svc.config.GetReplicas()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCacheReplicas 验证使用已有 PVC 的缓存目录只用于单副本
+// 缓存目录在 PVC 中使用固定子目录，多个副本同时写入会互相覆盖
+func validateCacheReplicas(field string, v *kubenovav1.VolumeConfig, replicas int32) error {
+	if v == nil || v.Type != kubenovav1.VolumeTypePersistentVolumeClaim || replicas <= 1 {
+		return nil
+	}
+	return fmt.Errorf("%s.volumes.cache: persistentVolumeClaim 类型只支持单副本(当前 %d 个副本)，多副本请使用 emptyDir 或 ephemeral", field, replicas)
+}
+
+// validateVolumeConfig 验证单个目录的卷配置，每种类型只允许对应的字段
+func validateVolumeConfig(field string, v *kubenovav1.VolumeConfig) error {
+	if v == nil {
+		return nil
+	}
+	if v.Size != nil && v.Size.Sign() <= 0 {
+		return fmt.Errorf("%s.size 必须大于 0", field)
+	}
+
+	switch v.Type {
+	case "", kubenovav1.VolumeTypeEmptyDir:
+		if v.StorageClassName != nil || v.ClaimName != "" {
+			return fmt.Errorf("%s: emptyDir 类型不支持 storageClassName 和 claimName", field)
+		}
+	case kubenovav1.VolumeTypeEphemeral:
+		if v.Size == nil {
+			return fmt.Errorf("%s: ephemeral 类型必须配置 size", field)
+		}
+		if v.Medium != "" || v.ClaimName != "" {
+			return fmt.Errorf("%s: ephemeral 类型不支持 medium 和 claimName", field)
+		}
+		if v.StorageClassName != nil && *v.StorageClassName != "" {
+			if errs := validation.IsDNS1123Subdomain(*v.StorageClassName); len(errs) > 0 {
+				return fmt.Errorf("%s.storageClassName 无效: %s", field, strings.Join(errs, "; "))
+			}
+		}
+	case kubenovav1.VolumeTypePersistentVolumeClaim:
+		if v.ClaimName == "" {
+			return fmt.Errorf("%s: persistentVolumeClaim 类型必须配置 claimName", field)
+		}
+		if errs := validation.IsDNS1123Subdomain(v.ClaimName); len(errs) > 0 {
+			return fmt.Errorf("%s.claimName 无效: %s", field, strings.Join(errs, "; "))
+		}
+		if v.Size != nil || v.Medium != "" || v.StorageClassName != nil {
+			return fmt.Errorf("%s: persistentVolumeClaim 类型不支持 size、medium 和 storageClassName，容量由已有 PVC 决定", field)
+		}
+	default:
+		return fmt.Errorf("%s.type 无效: %s", field, v.Type)
+	}
+	return nil
+}
+
+// cronFieldPattern Cron 表达式单个字段的格式(数字、*、范围、步长、列表和月份/星期名称)
+var cronFieldPattern = regexp.MustCompile(`^[0-9A-Za-z*?,/-]+$`)
+
+// cronMacros CronJob 支持的预定义计划
+var cronMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// validateDemo 验证演示环境的定时重置配置
+// 重置会删除数据库和存储桶中的数据，只允许在启用 demoMode 时配置
+func validateDemo(kn *kubenovav1.KubeNova) error {
+	portal := kn.Spec.Services.Portal
+	if portal == nil || portal.Demo == nil {
+		return nil
+	}
+	if !portal.DemoMode {
+		return fmt.Errorf("portal.demo 需要同时启用 portal.demoMode")
+	}
+	demo := portal.Demo
+
+	schedule := strings.TrimSpace(demo.ResetSchedule)
+	if strings.HasPrefix(schedule, "TZ=") || strings.HasPrefix(schedule, "CRON_TZ=") {
+		return fmt.Errorf("resetSchedule 不能指定时区，重置按全局 timezone 执行")
+	}
+	if !slices.Contains(cronMacros, schedule) {
+		fields := strings.Fields(schedule)
+		if len(fields) != 5 {
+			return fmt.Errorf("resetSchedule 必须是 5 个字段的 Cron 表达式: %s", demo.ResetSchedule)
+		}
+		for _, field := range fields {
+			if !cronFieldPattern.MatchString(field) {
+				return fmt.Errorf("resetSchedule 格式无效: %s", demo.ResetSchedule)
+			}
+		}
+	}
+
+	seed := demo.Seed
+	if seed.ConfigMap == nil && seed.SQLObject == "" && seed.ObjectsPrefix == "" {
+		return fmt.Errorf("seed 至少需要配置 configMap、sqlObject 或 objectsPrefix 其中之一")
+	}
+	if seed.ConfigMap != nil && seed.SQLObject != "" {
+		return fmt.Errorf("seed.configMap 和 seed.sqlObject 只能配置其中之一")
+	}
+	if seed.ConfigMap != nil {
+		if errs := validation.IsDNS1123Subdomain(seed.ConfigMap.Name); len(errs) > 0 {
+			return fmt.Errorf("seed.configMap.name 无效: %s", strings.Join(errs, "; "))
+		}
+		if errs := validation.IsConfigMapKey(seed.ConfigMap.GetKey()); len(errs) > 0 {
+			return fmt.Errorf("seed.configMap.key 无效: %s", strings.Join(errs, "; "))
+		}
+	}
+	if seed.SQLObject != "" || seed.ObjectsPrefix != "" {
+		if seed.Bucket == "" {
+			return fmt.Errorf("使用 sqlObject 或 objectsPrefix 时必须配置 seed.bucket")
+		}
+		if !bucketNamePattern.MatchString(seed.Bucket) {
+			return fmt.Errorf("seed.bucket 格式无效: %s", seed.Bucket)
+		}
+		// 镜像同步会删除目标存储桶中多余的对象，种子存储桶不能是平台存储桶
+		if seed.Bucket == kn.GetStorageBucket() {
+			return fmt.Errorf("seed.bucket 不能与平台存储桶 %s 相同", seed.Bucket)
+		}
+	} else if seed.Bucket != "" {
+		return fmt.Errorf("seed.bucket 仅在配置 sqlObject 或 objectsPrefix 时生效")
+	}
+	for field, path := range map[string]string{
+		"seed.sqlObject":     seed.SQLObject,
+		"seed.objectsPrefix": seed.ObjectsPrefix,
+	} {
+		if strings.HasPrefix(path, "/") || slices.Contains(strings.Split(path, "/"), "..") {
+			return fmt.Errorf("%s 必须是存储桶内的相对路径: %s", field, path)
+		}
+	}
+	if strings.HasSuffix(seed.SQLObject, "/") {
+		return fmt.Errorf("seed.sqlObject 必须是文件路径: %s", seed.SQLObject)
+	}
+
+	// 重建数据库的 SQL 使用反引号包裹数据库名称
+	if strings.Contains(kn.GetDatabaseName(), "`") {
+		return fmt.Errorf("数据库名称不能包含反引号")
+	}
+	return nil
+}
+
+// validateMaintenance 验证维护模式配置
+func validateMaintenance(m *kubenovav1.MaintenanceConfig) error {
+	if m == nil || !m.Enabled {
+		return nil
+	}
+	switch m.Mode {
+	case "", kubenovav1.MaintenanceModePage:
+	case kubenovav1.MaintenanceModePaused:
+		if m.ScaleDownBackend {
+			return fmt.Errorf("scaleDownBackend 仅在 maintenancePage 模式下生效")
+		}
+	default:
+		return fmt.Errorf("不支持的维护模式: %s", m.Mode)
+	}
+	return nil
+}
+
+// validateDeletionOptions 验证删除选项
+func validateDeletionOptions(opts *kubenovav1.DeletionOptions) error {
+	if opts == nil || opts.PodTerminationTimeout == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(opts.PodTerminationTimeout)
+	if err != nil {
+		return fmt.Errorf("podTerminationTimeout 格式无效: %w", err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("podTerminationTimeout 必须大于 0")
+	}
+	return nil
+}
+
+// validateInternalTLS 验证内部 TLS 配置
+func validateInternalTLS(cfg *kubenovav1.InternalTLSConfig) error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	durations := make(map[string]time.Duration, 3)
+	for name, value := range map[string]string{
+		"caValidity":   cfg.CAValidity,
+		"certValidity": cfg.CertValidity,
+		"renewBefore":  cfg.RenewBefore,
+	} {
+		if value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s 格式无效: %w", name, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("%s 必须大于 0", name)
+		}
+		durations[name] = d
+	}
+
+	certValidity, ok := durations["certValidity"]
+	if !ok {
+		certValidity = 365 * 24 * time.Hour
+	}
+	renewBefore, ok := durations["renewBefore"]
+	if !ok {
+		renewBefore = 30 * 24 * time.Hour
+	}
+	if renewBefore >= certValidity {
+		return fmt.Errorf("renewBefore 必须小于 certValidity")
+	}
+	return nil
+}
+
+// alertmanagerDurationPattern Alertmanager 时间格式(例如：30s、5m、4h)
+var alertmanagerDurationPattern = regexp.MustCompile(`^([0-9]+(ms|s|m|h|d|w|y))+$`)
+
+// alertLabelNamePattern Prometheus 标签名称格式
+var alertLabelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// validateAlerting 验证告警接入配置
+func validateAlerting(kn *kubenovav1.KubeNova) error {
+	if !kn.IsAlertingEnabled() {
+		return nil
+	}
+	alerting := kn.Spec.Alerting
+
+	if kn.Spec.Services.ManagerAPI != nil && !kn.Spec.Services.ManagerAPI.IsServiceEnabled() {
+		return fmt.Errorf("告警接入需要启用 manager-api")
+	}
+	if path := alerting.GetWebhookPath(); !strings.HasPrefix(path, "/") || strings.ContainsAny(path, " ?#\n") {
+		return fmt.Errorf("webhookPath 必须以 / 开头且不能包含空白、? 或 #")
+	}
+	if strings.ContainsAny(alerting.GetReceiverName(), "\n\r") {
+		return fmt.Errorf("receiverName 不能包含换行")
+	}
+	for key := range alerting.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("labels 中的标签 %q 无效: %s", key, strings.Join(errs, "; "))
+		}
+	}
+
+	route := alerting.Route
+	if route == nil {
+		return nil
+	}
+	for _, m := range route.Matchers {
+		if !alertLabelNamePattern.MatchString(m.Name) {
+			return fmt.Errorf("matchers 中的标签名称 %q 无效", m.Name)
+		}
+		if strings.ContainsAny(m.Value, "\n\r") {
+			return fmt.Errorf("matchers 中标签 %q 的值不能包含换行", m.Name)
+		}
+		if matchType := m.GetMatchType(); matchType == "=~" || matchType == "!~" {
+			if _, err := regexp.Compile("^(?:" + m.Value + ")$"); err != nil {
+				return fmt.Errorf("matchers 中标签 %q 的正则表达式无效: %w", m.Name, err)
+			}
+		}
+	}
+	for _, label := range route.GroupBy {
+		if label != "..." && !alertLabelNamePattern.MatchString(label) {
+			return fmt.Errorf("groupBy 中的标签名称 %q 无效", label)
+		}
+	}
+	for name, value := range map[string]string{
+		"groupWait":      route.GroupWait,
+		"groupInterval":  route.GroupInterval,
+		"repeatInterval": route.RepeatInterval,
+	} {
+		if value != "" && !alertmanagerDurationPattern.MatchString(value) {
+			return fmt.Errorf("%s 格式无效: %s", name, value)
+		}
+	}
+	return nil
+}
+
+// nginxSizePattern Nginx 大小格式(例如：1024m)
+var nginxSizePattern = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
+
+// nginxRatePattern Nginx 限流速率格式(例如：100r/s)
+var nginxRatePattern = regexp.MustCompile(`^[1-9][0-9]*r/[sm]$`)
+
+// nginxTimePattern Nginx 时间格式(例如：65s)
+var nginxTimePattern = regexp.MustCompile(`^[0-9]+(ms|s|m|h)?$`)
+
+// nginxHeaderPattern 请求头名称格式
+var nginxHeaderPattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// validateNginx 验证 Nginx 配置
+// 配置值会直接渲染到 nginx.conf 中，需要防止非法值导致 Nginx 无法启动
+func validateNginx(kn *kubenovav1.KubeNova) error {
+	cfg := kn.Spec.Web.Nginx
+	if cfg == nil {
+		return nil
+	}
+
+	if workers := cfg.GetWorkerProcesses(); workers != "auto" {
+		if n, err := strconv.Atoi(workers); err != nil || n <= 0 {
+			return fmt.Errorf("workerProcesses 必须是 auto 或正整数，当前值: %s", workers)
+		}
+	}
+	if cfg.WorkerConnections < 0 {
+		return fmt.Errorf("workerConnections 不能为负数")
+	}
+	if cfg.GetWorkerConnections() > cfg.GetWorkerRlimitNofile() {
+		return fmt.Errorf("workerConnections(%d) 不能大于 workerRlimitNofile(%d)",
+			cfg.GetWorkerConnections(), cfg.GetWorkerRlimitNofile())
+	}
+	if !nginxSizePattern.MatchString(cfg.GetClientMaxBodySize()) {
+		return fmt.Errorf("clientMaxBodySize 格式无效: %s", cfg.ClientMaxBodySize)
+	}
+	if cfg.GetMaxConnsPerIP() < 0 {
+		return fmt.Errorf("maxConnsPerIP 不能为负数")
+	}
+
+	if !nginxRatePattern.MatchString(cfg.GetGeneralRate()) {
+		return fmt.Errorf("rateLimit.generalRate 格式无效: %s", cfg.GetGeneralRate())
+	}
+	if !nginxRatePattern.MatchString(cfg.GetAPIRate()) {
+		return fmt.Errorf("rateLimit.apiRate 格式无效: %s", cfg.GetAPIRate())
+	}
+	if cfg.GetGeneralBurst() < 0 || cfg.GetAPIBurst() < 0 || cfg.GetWebSocketBurst() < 0 {
+		return fmt.Errorf("rateLimit 突发请求数不能为负数")
+	}
+
+	if level := cfg.GetGzipCompLevel(); level < 1 || level > 9 {
+		return fmt.Errorf("gzip.compLevel 必须在 1-9 之间，当前值: %d", level)
+	}
+	if cfg.GetGzipMinLength() < 0 {
+		return fmt.Errorf("gzip.minLength 不能为负数")
+	}
+
+	if !nginxTimePattern.MatchString(cfg.GetKeepaliveTimeout()) {
+		return fmt.Errorf("keepalive.timeout 格式无效: %s", cfg.GetKeepaliveTimeout())
+	}
+
+	if cfg.RealIP != nil {
+		if len(cfg.RealIP.TrustedProxies) == 0 {
+			return fmt.Errorf("realIP 已配置但 trustedProxies 为空")
+		}
+		if err := validateIPOrCIDRs("realIP.trustedProxies", cfg.RealIP.TrustedProxies); err != nil {
+			return err
+		}
+		// 监听端口未启用 proxy_protocol，使用 proxy_protocol 时 Nginx 无法解析客户端地址
+		if header := cfg.GetRealIPHeader(); header == "proxy_protocol" {
+			return fmt.Errorf("realIP.header 不支持 proxy_protocol，监听端口未启用 PROXY 协议")
+		} else if !nginxHeaderPattern.MatchString(header) {
+			return fmt.Errorf("realIP.header 格式无效: %s", header)
+		}
+	}
+
+	switch cfg.GetAccessLogFormat() {
+	case "main", "json", "off":
+	default:
+		return fmt.Errorf("不支持的 accessLogFormat: %s", cfg.AccessLogFormat)
+	}
+
+	if cfg.Snippets != nil {
+		if err := validateNginxSnippets(kn, cfg.Snippets); err != nil {
+			return fmt.Errorf("snippets 配置错误: %w", err)
+		}
+	}
+	return nil
+}
+
+// corsOriginPattern CORS 来源格式(例如：https://app.example.com:8443)
+var corsOriginPattern = regexp.MustCompile(`^https?://[A-Za-z0-9.-]+(:[0-9]+)?$`)
+
+// frameAncestorPattern CSP frame-ancestors 来源格式(例如：https://*.example.com)
+var frameAncestorPattern = regexp.MustCompile(`^([a-z][a-z0-9+.-]*:(//)?)?(\*\.)?[A-Za-z0-9.-]+(:([0-9]+|\*))?(/[^\s;,'"]*)?$`)
+
+// managedSecurityHeaders 由 securityHeaders 字段生成的响应头，不能通过 customHeaders 重复设置
+var managedSecurityHeaders = map[string]bool{
+	"x-frame-options":           true,
+	"x-content-type-options":    true,
+	"x-xss-protection":          true,
+	"referrer-policy":           true,
+	"permissions-policy":        true,
+	"content-security-policy":   true,
+	"strict-transport-security": true,
+}
+
+// validateSecurityHeaders 验证安全响应头配置
+func validateSecurityHeaders(kn *kubenovav1.KubeNova) error {
+	headers := kn.Spec.Web.SecurityHeaders
+	if headers == nil {
+		return nil
+	}
+	if kn.Spec.Web.CustomNginxConfigMap != "" {
+		return fmt.Errorf("不能与 customNginxConfigMap 同时配置")
+	}
+
+	switch headers.FrameOptions {
+	case "", "DENY", "SAMEORIGIN", "off":
+	default:
+		return fmt.Errorf("不支持的 frameOptions: %s", headers.FrameOptions)
+	}
+	for _, origin := range headers.FrameAncestors {
+		if !frameAncestorPattern.MatchString(origin) {
+			return fmt.Errorf("frameAncestors 来源格式无效: %q", origin)
+		}
+	}
+	if len(headers.FrameAncestors) > 0 && strings.Contains(strings.ToLower(headers.ContentSecurityPolicy), "frame-ancestors") {
+		return fmt.Errorf("contentSecurityPolicy 不能包含 frame-ancestors，请使用 frameAncestors 配置")
+	}
+
+	values := map[string]string{
+		"contentSecurityPolicy": headers.ContentSecurityPolicy,
+		"referrerPolicy":        headers.ReferrerPolicy,
+		"permissionsPolicy":     headers.PermissionsPolicy,
+	}
+	for field, value := range values {
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("%s 不能包含换行符", field)
+		}
+	}
+
+	if hsts := headers.HSTS; hsts != nil {
+		if hsts.MaxAge != nil && *hsts.MaxAge < 0 {
+			return fmt.Errorf("hsts.maxAge 不能为负数")
+		}
+		// HSTS preload 列表要求包含子域名且有效期至少一年
+		if hsts.Preload {
+			if hsts.IncludeSubDomains != nil && !*hsts.IncludeSubDomains {
+				return fmt.Errorf("hsts.preload 需要开启 includeSubDomains")
+			}
+			if hsts.MaxAge != nil && *hsts.MaxAge < 31536000 {
+				return fmt.Errorf("hsts.preload 需要 maxAge 不小于 31536000")
+			}
+		}
+	}
+
+	if cors := headers.CORS; cors != nil {
+		if len(cors.AllowedOrigins) == 0 {
+			return fmt.Errorf("cors.allowedOrigins 不能为空")
+		}
+		for _, origin := range cors.AllowedOrigins {
+			if origin == "*" {
+				if len(cors.AllowedOrigins) > 1 {
+					return fmt.Errorf("cors.allowedOrigins 中的 \"*\" 不能与其他来源同时配置")
+				}
+				if cors.AllowCredentials {
+					return fmt.Errorf("cors.allowCredentials 不能与 \"*\" 来源同时使用")
+				}
+				continue
+			}
+			if !corsOriginPattern.MatchString(origin) {
+				return fmt.Errorf("cors.allowedOrigins 来源格式无效: %q", origin)
+			}
+		}
+		if strings.ContainsAny(cors.AllowedMethods+cors.AllowedHeaders, "\r\n") {
+			return fmt.Errorf("cors.allowedMethods 和 cors.allowedHeaders 不能包含换行符")
+		}
+		if cors.MaxAge != nil && *cors.MaxAge < 0 {
+			return fmt.Errorf("cors.maxAge 不能为负数")
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, header := range headers.CustomHeaders {
+		if !nginxHeaderPattern.MatchString(header.Name) {
+			return fmt.Errorf("customHeaders 名称格式无效: %q", header.Name)
+		}
+		name := strings.ToLower(header.Name)
+		if managedSecurityHeaders[name] {
+			return fmt.Errorf("customHeaders 不能设置 %s，请使用对应的 securityHeaders 字段", header.Name)
+		}
+		if seen[name] {
+			return fmt.Errorf("customHeaders 中重复的响应头: %s", header.Name)
+		}
+		seen[name] = true
+		if header.Value == "" || strings.ContainsAny(header.Value, "\r\n") {
+			return fmt.Errorf("customHeaders %s 的值不能为空或包含换行符", header.Name)
+		}
+	}
 	return nil
 }
 
-// ValidateTLSSecret 验证 TLS Secret 是否存在（运行时验证）
-func ValidateTLSSecret(secretName string) error {
-	if secretName == "" {
-		return fmt.Errorf("TLS Secret 名称不能为空")
+// validateIPOrCIDRs 验证 IP 或 CIDR 列表
+func validateIPOrCIDRs(field string, values []string) error {
+	for _, value := range values {
+		if _, _, err := net.ParseCIDR(value); err != nil && net.ParseIP(value) == nil {
+			return fmt.Errorf("%s 格式错误，必须是 IP 或 CIDR: %q", field, value)
+		}
+	}
+	return nil
+}
+
+// authProxyURLPattern 外部认证代理地址格式(不能包含路径)
+var authProxyURLPattern = regexp.MustCompile(`^https?://[A-Za-z0-9.-]+(:[0-9]+)?/?$`)
+
+// validateAccessControl 验证访问控制配置
+func validateAccessControl(kn *kubenovav1.KubeNova) error {
+	access := kn.Spec.Web.AccessControl
+	if access == nil {
+		return nil
+	}
+	// Ingress 模式下全局规则通过 Ingress 注解生效，其余规则需要 Nginx 获取客户端真实 IP
+	ingressMode := kn.Spec.Web.ExposeType == "ingress"
+
+	if kn.Spec.Web.CustomNginxConfigMap != "" {
+		if len(access.Paths) > 0 || access.Auth != nil || (access.HasRules() && !ingressMode) {
+			return fmt.Errorf("使用 customNginxConfigMap 时只支持 Ingress 模式下的全局 allow/deny")
+		}
+	}
+
+	if err := validateIPOrCIDRs("allow", access.Allow); err != nil {
+		return err
+	}
+	if err := validateIPOrCIDRs("deny", access.Deny); err != nil {
+		return err
+	}
+	if access.HasRules() && !ingressMode && !kn.IsClientIPVisibleToNginx() {
+		return fmt.Errorf("%s 模式下需要配置 nginx.realIP%s 才能按客户端 IP 控制访问", kn.Spec.Web.ExposeType, localPolicyHint(kn))
+	}
+
+	builtinPaths := make(map[string]bool)
+	for _, path := range kn.GetNginxLocationPaths() {
+		builtinPaths[path] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range access.Paths {
+		if !builtinPaths[rule.Path] {
+			return fmt.Errorf("paths 中的 %s 不是内置 location 路径", rule.Path)
+		}
+		if seen[rule.Path] {
+			return fmt.Errorf("paths 中重复的路径: %s", rule.Path)
+		}
+		seen[rule.Path] = true
+		if len(rule.Allow) == 0 && len(rule.Deny) == 0 {
+			return fmt.Errorf("paths 中的 %s 未配置 allow 或 deny", rule.Path)
+		}
+		if err := validateIPOrCIDRs(fmt.Sprintf("paths[%s].allow", rule.Path), rule.Allow); err != nil {
+			return err
+		}
+		if err := validateIPOrCIDRs(fmt.Sprintf("paths[%s].deny", rule.Path), rule.Deny); err != nil {
+			return err
+		}
+	}
+	if len(access.Paths) > 0 && !kn.IsClientIPVisibleToNginx() {
+		return fmt.Errorf("%s 模式下按路径控制访问需要配置 nginx.realIP%s", kn.Spec.Web.ExposeType, localPolicyHint(kn))
+	}
+
+	if access.Auth != nil {
+		if err := validateExternalAuth(access.Auth, builtinPaths); err != nil {
+			return fmt.Errorf("auth 配置错误: %w", err)
+		}
+	}
+	return nil
+}
+
+// localPolicyHint LoadBalancer 模式下提示可以改用 Local 外部流量策略保留客户端 IP
+func localPolicyHint(kn *kubenovav1.KubeNova) string {
+	if kn.Spec.Web.ExposeType == "loadbalancer" {
+		return " 或 loadBalancer.externalTrafficPolicy=Local"
+	}
+	return ""
+}
+
+// validateExternalAuth 验证外部认证配置
+func validateExternalAuth(auth *kubenovav1.ExternalAuthConfig, builtinPaths map[string]bool) error {
+	switch auth.GetAuthMode() {
+	case kubenovav1.AuthModeExternal:
+		if !authProxyURLPattern.MatchString(auth.URL) {
+			return fmt.Errorf("external 模式需要配置 url(例如：http://oauth2-proxy.auth.svc:4180)，且不能包含路径，当前值: %q", auth.URL)
+		}
+	case kubenovav1.AuthModeSidecar, kubenovav1.AuthModeDeployment:
+		proxy := auth.OAuth2Proxy
+		if proxy == nil || proxy.SecretName == "" {
+			return fmt.Errorf("%s 模式需要配置 oauth2Proxy.secretName", auth.GetAuthMode())
+		}
+		if err := validateSecretName(proxy.SecretName); err != nil {
+			return fmt.Errorf("oauth2Proxy.secretName 无效: %w", err)
+		}
+		if proxy.GetProvider() == "oidc" && proxy.IssuerURL == "" {
+			return fmt.Errorf("oidc 提供方需要配置 oauth2Proxy.issuerURL")
+		}
+		for _, arg := range proxy.ExtraArgs {
+			if !strings.HasPrefix(arg, "--") {
+				return fmt.Errorf("oauth2Proxy.extraArgs 参数格式无效(需要以 -- 开头): %q", arg)
+			}
+		}
+	default:
+		return fmt.Errorf("不支持的 mode: %s", auth.Mode)
+	}
+
+	for _, header := range auth.ResponseHeaders {
+		if !nginxHeaderPattern.MatchString(header) {
+			return fmt.Errorf("responseHeaders 名称格式无效: %q", header)
+		}
+	}
+	for _, path := range auth.ExcludePaths {
+		if !builtinPaths[path] {
+			return fmt.Errorf("excludePaths 中的 %s 不是内置 location 路径", path)
+		}
+	}
+	return nil
+}
+
+// builtinUpstreams 生成配置中内置的 upstream 名称
+var builtinUpstreams = map[string]bool{
+	"portal_api":    true,
+	"manager_api":   true,
+	"workload_api":  true,
+	"console_api":   true,
+	"minio_backend": true,
+}
+
+// validateNginxSnippets 验证 Nginx 自定义片段配置
+func validateNginxSnippets(kn *kubenovav1.KubeNova, snippets *kubenovav1.NginxSnippetsConfig) error {
+	if kn.Spec.Web.CustomNginxConfigMap != "" {
+		return fmt.Errorf("不能与 customNginxConfigMap 同时配置")
+	}
+
+	if snippets.ConfigMapName != "" {
+		if err := validateConfigMapName(snippets.ConfigMapName); err != nil {
+			return fmt.Errorf("configMapName 无效: %w", err)
+		}
+	}
+
+	builtinPaths := make(map[string]bool)
+	for _, path := range kn.GetNginxLocationPaths() {
+		builtinPaths[path] = true
+	}
+	for _, location := range snippets.Locations {
+		if !builtinPaths[location.Path] {
+			return fmt.Errorf("locations 中的路径 %s 不是内置 location，可选值: %s",
+				location.Path, strings.Join(kn.GetNginxLocationPaths(), ", "))
+		}
+	}
+
+	upstreams := make(map[string]bool)
+	for _, upstream := range snippets.Upstreams {
+		if builtinUpstreams[upstream.Name] {
+			return fmt.Errorf("upstream 名称 %s 与内置 upstream 冲突", upstream.Name)
+		}
+		if upstreams[upstream.Name] {
+			return fmt.Errorf("upstream 名称 %s 重复", upstream.Name)
+		}
+		if len(upstream.Servers) == 0 {
+			return fmt.Errorf("upstream %s 未配置 servers", upstream.Name)
+		}
+		upstreams[upstream.Name] = true
+	}
+
+	extraPaths := make(map[string]bool)
+	for _, location := range snippets.ExtraLocations {
+		path := strings.Join(strings.Fields(location.Path), " ")
+		if path == "" {
+			return fmt.Errorf("extraLocations 的 path 不能为空")
+		}
+		if builtinPaths[path] || path == "/health" {
+			return fmt.Errorf("extraLocations 的路径 %s 与内置 location 冲突，请使用 locations 为其追加配置", path)
+		}
+		if extraPaths[path] {
+			return fmt.Errorf("extraLocations 的路径 %s 重复", path)
+		}
+		extraPaths[path] = true
+		if location.Upstream != "" && !upstreams[location.Upstream] {
+			return fmt.Errorf("extraLocations %s 引用的 upstream %s 未定义", path, location.Upstream)
+		}
+		if location.Upstream == "" && strings.TrimSpace(location.Snippet) == "" {
+			return fmt.Errorf("extraLocations %s 必须配置 upstream 或 snippet", path)
+		}
+	}
+	return nil
+}
+
+// validateConfigMapName 验证 ConfigMap 名称格式
+func validateConfigMapName(name string) error {
+	if len(name) > 253 {
+		return fmt.Errorf("configMap 名称过长，最大长度 253，当前长度: %d", len(name))
+	}
+	if strings.Contains(name, " ") {
+		return fmt.Errorf("configMap 名称不能包含空格")
 	}
 	return nil
 }
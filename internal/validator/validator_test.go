@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Kube-nova By YanShicheng.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	kubenovav1 "github.com/yanshicheng/kube-nova-operator/api/v1"
+)
+
+func TestValidateDataVolumes(t *testing.T) {
+	claim := func(name string) *kubenovav1.ComponentVolumesConfig {
+		return &kubenovav1.ComponentVolumesConfig{
+			Logs:  &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypePersistentVolumeClaim, ClaimName: name},
+			Cache: &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypePersistentVolumeClaim, ClaimName: name},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(kn *kubenovav1.KubeNova)
+		wantErr string
+	}{
+		{name: "defaults"},
+		{
+			name: "web cache claim with single replica",
+			mutate: func(kn *kubenovav1.KubeNova) {
+				kn.Spec.Web.Replicas = 1
+				kn.Spec.Web.Volumes = claim("web-data")
+			},
+		},
+		{
+			name: "web cache claim with default replicas",
+			mutate: func(kn *kubenovav1.KubeNova) {
+				kn.Spec.Web.Volumes = claim("web-data")
+			},
+			wantErr: "web.volumes.cache: persistentVolumeClaim 类型只支持单副本(当前 3 个副本)",
+		},
+		{
+			name: "service logs claim with multiple replicas",
+			mutate: func(kn *kubenovav1.KubeNova) {
+				kn.Spec.Services.ConsoleAPI = &kubenovav1.ServiceConfig{
+					Replicas: 3,
+					Volumes: &kubenovav1.ComponentVolumesConfig{
+						Logs: &kubenovav1.VolumeConfig{Type: kubenovav1.VolumeTypePersistentVolumeClaim, ClaimName: "logs"},
+					},
+				}
+			},
+		},
+		{
+			name: "service cache claim with multiple replicas",
+			mutate: func(kn *kubenovav1.KubeNova) {
+				kn.Spec.Services.ConsoleAPI = &kubenovav1.ServiceConfig{Replicas: 2, Volumes: claim("console-data")}
+			},
+			wantErr: "consoleAPI.volumes.cache: persistentVolumeClaim 类型只支持单副本(当前 2 个副本)",
+		},
+		{
+			name: "service cache claim with single replica",
+			mutate: func(kn *kubenovav1.KubeNova) {
+				kn.Spec.Services.ConsoleRPC = &kubenovav1.ServiceConfig{Replicas: 1, Volumes: claim("console-data")}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kn := &kubenovav1.KubeNova{}
+			if tt.mutate != nil {
+				tt.mutate(kn)
+			}
+			err := validateDataVolumes(kn)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateDataVolumes() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateDataVolumes() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}